@@ -7,9 +7,12 @@ package api
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
+	tracertypes "github.com/DataDog/chaos-controller/o11y/tracer/types"
 	chaostypes "github.com/DataDog/chaos-controller/types"
 )
 
@@ -29,6 +32,9 @@ type DisruptionArgs struct {
 	Kind                 chaostypes.DisruptionKindName
 	TargetPodIP          string
 	MetricsSink          string
+	TracerSink           string
+	TracerOTLP           otlp.Config
+	SpanContext          string
 	DisruptionName       string
 	DisruptionNamespace  string
 	TargetName           string
@@ -69,6 +75,28 @@ func (d DisruptionArgs) CreateCmdArgs(args []string) []string {
 		"--log-context-target-node-name", d.TargetNodeName,
 	)
 
+	// tracing args, the span context is the parent of the injector spans
+	if d.TracerSink != "" {
+		args = append(args, "--tracer-sink", d.TracerSink)
+
+		if d.SpanContext != "" {
+			args = append(args, "--span-context", d.SpanContext)
+		}
+	}
+
+	// the otlp headers may hold credentials so they are not passed to the injector at all
+	if d.TracerSink == string(tracertypes.SinkDriverOTLP) {
+		args = append(args,
+			"--tracer-otlp-protocol", d.TracerOTLP.Protocol,
+			"--tracer-otlp-endpoint", d.TracerOTLP.Endpoint,
+			"--tracer-otlp-sampling-ratio", strconv.FormatFloat(d.TracerOTLP.SamplingRatio, 'f', -1, 64),
+		)
+
+		if d.TracerOTLP.Insecure {
+			args = append(args, "--tracer-otlp-insecure")
+		}
+	}
+
 	// enable dry-run mode
 	if d.DryRun {
 		args = append(args, "--dry-run")
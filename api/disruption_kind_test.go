@@ -0,0 +1,59 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package api_test
+
+import (
+	"github.com/DataDog/chaos-controller/api"
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DisruptionArgs.CreateCmdArgs", func() {
+	var args api.DisruptionArgs
+
+	BeforeEach(func() {
+		args = api.DisruptionArgs{
+			MetricsSink: "noop",
+			TracerOTLP: otlp.Config{
+				Protocol:      otlp.ProtocolHTTP,
+				Endpoint:      "collector:4318",
+				Insecure:      true,
+				Headers:       map[string]string{"authorization": "secret"},
+				SamplingRatio: 0.5,
+			},
+		}
+	})
+
+	It("should not pass any tracing arg without tracer sink", func() {
+		Expect(args.CreateCmdArgs(nil)).ToNot(ContainElements("--tracer-sink", "--span-context"))
+	})
+
+	It("should pass the tracer sink and the span context", func() {
+		args.TracerSink = "datadog"
+		args.SpanContext = `{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}`
+
+		cmdArgs := args.CreateCmdArgs(nil)
+
+		Expect(cmdArgs).To(ContainElements("--tracer-sink", "datadog", "--span-context", args.SpanContext))
+		Expect(cmdArgs).ToNot(ContainElement("--tracer-otlp-endpoint"))
+	})
+
+	It("should pass the otlp configuration without the headers", func() {
+		args.TracerSink = "otlp"
+
+		cmdArgs := args.CreateCmdArgs(nil)
+
+		Expect(cmdArgs).To(ContainElements(
+			"--tracer-otlp-protocol", "http",
+			"--tracer-otlp-endpoint", "collector:4318",
+			"--tracer-otlp-sampling-ratio", "0.5",
+			"--tracer-otlp-insecure",
+		))
+		Expect(cmdArgs).ToNot(ContainElement(ContainSubstring("secret")))
+	})
+})
@@ -0,0 +1,258 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	SchemeBuilder.Register(&DisruptionBlackout{}, &DisruptionBlackoutList{})
+}
+
+//+kubebuilder:object:root=true
+
+// DisruptionBlackout is the Schema for the disruptionblackouts API
+// no disruption can be created in the matching namespaces while one of its windows is active
+// +kubebuilder:resource:scope=Cluster,shortName=diblk
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type DisruptionBlackout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DisruptionBlackoutSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DisruptionBlackoutList contains a list of DisruptionBlackout
+type DisruptionBlackoutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisruptionBlackout `json:"items"`
+}
+
+// DisruptionBlackoutSpec defines the desired state of DisruptionBlackout
+type DisruptionBlackoutSpec struct {
+	// Reason of the blackout, given to the users whose disruptions are denied or skipped
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+	// Windows are the periods during which disruptions are not allowed
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Windows []DisruptionBlackoutWindow `json:"windows"`
+	// NamespaceSelector restricts the blackout to the namespaces matching the selector, all namespaces are blacked out if not specified
+	// +nullable
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// DisruptionBlackoutWindow is either a recurring window starting on a cron schedule for the given duration,
+// or a one-off window between a start and an end time (e.g. a release freeze)
+type DisruptionBlackoutWindow struct {
+	// Schedule is the cron expression of the start of a recurring window (e.g. "0 9 * * 1-5")
+	Schedule string `json:"schedule,omitempty"`
+	// Duration of a recurring window
+	Duration DisruptionDuration `json:"duration,omitempty"`
+	// TimeZone is the IANA time zone name the schedule is evaluated in (e.g. "Europe/Paris"), defaults to the time zone of the controller
+	TimeZone string `json:"timeZone,omitempty"`
+	// Start of a one-off window
+	// +nullable
+	Start *metav1.Time `json:"start,omitempty"`
+	// End of a one-off window
+	// +nullable
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// IsRecurring returns true if the window starts on a cron schedule
+func (w DisruptionBlackoutWindow) IsRecurring() bool {
+	return w.Schedule != ""
+}
+
+// Validate validates the window
+func (w DisruptionBlackoutWindow) Validate() (retErr error) {
+	if w.IsRecurring() == (w.Start != nil || w.End != nil) {
+		return errors.New("a blackout window must define either a schedule and a duration or a start and an end")
+	}
+
+	if w.IsRecurring() {
+		if _, err := cron.ParseStandard(w.Schedule); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid blackout window schedule %s: %w", w.Schedule, err))
+		}
+
+		if w.Duration.Duration() <= 0 {
+			retErr = multierror.Append(retErr, fmt.Errorf("blackout window with schedule %s must have a positive duration", w.Schedule))
+		}
+
+		if _, err := w.getLocation(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+
+		return retErr
+	}
+
+	if w.Start == nil || w.End == nil || !w.End.After(w.Start.Time) {
+		retErr = multierror.Append(retErr, errors.New("a one-off blackout window must have an end after its start"))
+	}
+
+	return retErr
+}
+
+// IsActive returns true if the given time is within the window
+func (w DisruptionBlackoutWindow) IsActive(now time.Time) (bool, error) {
+	if !w.IsRecurring() {
+		return w.Start != nil && w.End != nil && !now.Before(w.Start.Time) && now.Before(w.End.Time), nil
+	}
+
+	sched, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("invalid blackout window schedule %s: %w", w.Schedule, err)
+	}
+
+	location, err := w.getLocation()
+	if err != nil {
+		return false, err
+	}
+
+	// the window is active if it started during the last duration
+	windowStart := sched.Next(now.In(location).Add(-w.Duration.Duration()))
+
+	return !windowStart.After(now), nil
+}
+
+// getLocation returns the location the schedule is evaluated in, defaulting to the local time zone of the controller
+func (w DisruptionBlackoutWindow) getLocation() (*time.Location, error) {
+	if w.TimeZone == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s: %w", w.TimeZone, err)
+	}
+
+	return location, nil
+}
+
+// Validate validates the blackout spec
+func (s DisruptionBlackoutSpec) Validate() (retErr error) {
+	if s.Reason == "" {
+		retErr = multierror.Append(retErr, errors.New("a blackout must have a reason"))
+	}
+
+	if len(s.Windows) == 0 {
+		retErr = multierror.Append(retErr, errors.New("a blackout must have at least one window"))
+	}
+
+	for i, window := range s.Windows {
+		if err := window.Validate(); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("window %d: %w", i, err))
+		}
+	}
+
+	if s.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid namespace selector: %w", err))
+		}
+	}
+
+	return multierror.Prefix(retErr, "Spec:")
+}
+
+// IsActive returns true if one of the blackout windows is active at the given time
+func (s DisruptionBlackoutSpec) IsActive(now time.Time) (bool, error) {
+	for _, window := range s.Windows {
+		active, err := window.IsActive(now)
+		if err != nil {
+			return false, err
+		}
+
+		if active {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// AppliesTo returns true if the blackout applies to a namespace with the given labels
+func (s DisruptionBlackoutSpec) AppliesTo(namespaceLabels labels.Set) (bool, error) {
+	if s.NamespaceSelector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+
+	return selector.Matches(namespaceLabels), nil
+}
+
+// GetActiveBlackout returns the first blackout active at the given time for the given namespace, or nil if disruptions are allowed
+// an invalid blackout is ignored so it can't block every disruption of the cluster, it is logged and reported with a warning event instead
+func GetActiveBlackout(ctx context.Context, k8sClient client.Client, log *zap.SugaredLogger, recorder record.EventRecorder, namespace string, now time.Time) (*DisruptionBlackout, error) {
+	blackouts := &DisruptionBlackoutList{}
+	if err := k8sClient.List(ctx, blackouts); err != nil {
+		return nil, fmt.Errorf("error listing disruption blackouts: %w", err)
+	}
+
+	var namespaceLabels labels.Set
+
+	for i, blackout := range blackouts.Items {
+		if err := blackout.Spec.Validate(); err != nil {
+			if log != nil {
+				log.Warnw("ignoring invalid disruption blackout", "blackout", blackout.Name, "error", err)
+			}
+
+			if recorder != nil {
+				recorder.Event(&blackouts.Items[i], Events[EventBlackoutInvalid].Type, string(EventBlackoutInvalid), fmt.Sprintf(Events[EventBlackoutInvalid].OnDisruptionTemplateMessage, err))
+			}
+
+			continue
+		}
+
+		active, err := blackout.Spec.IsActive(now)
+		if err != nil || !active {
+			continue
+		}
+
+		// only fetch the namespace labels once, and only if needed
+		if blackout.Spec.NamespaceSelector != nil && namespaceLabels == nil {
+			ns := &corev1.Namespace{}
+			if err := k8sClient.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+				return nil, fmt.Errorf("error getting namespace %s: %w", namespace, err)
+			}
+
+			namespaceLabels = labels.Set(ns.Labels)
+			if namespaceLabels == nil {
+				namespaceLabels = labels.Set{}
+			}
+		}
+
+		applies, err := blackout.Spec.AppliesTo(namespaceLabels)
+		if err != nil || !applies {
+			continue
+		}
+
+		return &blackouts.Items[i], nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,169 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionBlackout", func() {
+	// a monday at 10:00 UTC
+	now := time.Date(2023, time.October, 2, 10, 0, 0, 0, time.UTC)
+	businessHours := DisruptionBlackoutWindow{Schedule: "0 9 * * 1-5", Duration: "8h", TimeZone: "UTC"}
+	releaseFreeze := DisruptionBlackoutWindow{
+		Start: &metav1.Time{Time: now.Add(-time.Hour)},
+		End:   &metav1.Time{Time: now.Add(time.Hour)},
+	}
+
+	Describe("Validate", func() {
+		DescribeTable("with valid blackouts",
+			func(spec DisruptionBlackoutSpec) {
+				Expect(spec.Validate()).Should(Succeed())
+			},
+			Entry("with a recurring window",
+				DisruptionBlackoutSpec{Reason: "peak hours", Windows: []DisruptionBlackoutWindow{businessHours}},
+			),
+			Entry("with a one-off window and a namespace selector",
+				DisruptionBlackoutSpec{
+					Reason:            "release freeze",
+					Windows:           []DisruptionBlackoutWindow{releaseFreeze},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				},
+			),
+		)
+
+		DescribeTable("with invalid blackouts",
+			func(spec DisruptionBlackoutSpec, expectedErrorMessage string) {
+				err := spec.Validate()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("without reason",
+				DisruptionBlackoutSpec{Windows: []DisruptionBlackoutWindow{businessHours}},
+				"a blackout must have a reason",
+			),
+			Entry("without windows",
+				DisruptionBlackoutSpec{Reason: "freeze"},
+				"a blackout must have at least one window",
+			),
+			Entry("with a window defining both a schedule and a start",
+				DisruptionBlackoutSpec{Reason: "freeze", Windows: []DisruptionBlackoutWindow{{Schedule: "0 9 * * *", Duration: "1h", Start: releaseFreeze.Start}}},
+				"must define either a schedule and a duration or a start and an end",
+			),
+			Entry("with an invalid schedule",
+				DisruptionBlackoutSpec{Reason: "freeze", Windows: []DisruptionBlackoutWindow{{Schedule: "every day", Duration: "1h"}}},
+				"invalid blackout window schedule every day",
+			),
+			Entry("with a recurring window without duration",
+				DisruptionBlackoutSpec{Reason: "freeze", Windows: []DisruptionBlackoutWindow{{Schedule: "0 9 * * *"}}},
+				"must have a positive duration",
+			),
+			Entry("with an unknown time zone",
+				DisruptionBlackoutSpec{Reason: "freeze", Windows: []DisruptionBlackoutWindow{{Schedule: "0 9 * * *", Duration: "1h", TimeZone: "Mars/Olympus"}}},
+				"unknown time zone Mars/Olympus",
+			),
+			Entry("with a one-off window ending before its start",
+				DisruptionBlackoutSpec{Reason: "freeze", Windows: []DisruptionBlackoutWindow{{Start: releaseFreeze.End, End: releaseFreeze.Start}}},
+				"a one-off blackout window must have an end after its start",
+			),
+		)
+	})
+
+	DescribeTable("DisruptionBlackoutWindow.IsActive",
+		func(window DisruptionBlackoutWindow, at time.Time, expected bool) {
+			active, err := window.IsActive(at)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(Equal(expected))
+		},
+		Entry("during a recurring window", businessHours, now, true),
+		Entry("at the start of a recurring window", businessHours, now.Add(-time.Hour), true),
+		Entry("at the end of a recurring window", businessHours, now.Add(7*time.Hour), false),
+		Entry("before a recurring window", businessHours, now.Add(-2*time.Hour), false),
+		Entry("on a day without recurring window", businessHours, now.Add(-48*time.Hour), false),
+		Entry("in another time zone", DisruptionBlackoutWindow{Schedule: "0 9 * * *", Duration: "2h", TimeZone: "Asia/Tokyo"}, now, false),
+		Entry("during a one-off window", releaseFreeze, now, true),
+		Entry("after a one-off window", releaseFreeze, now.Add(2*time.Hour), false),
+	)
+
+	Describe("GetActiveBlackout", func() {
+		var (
+			blackouts []runtime.Object
+			namespace *corev1.Namespace
+			recorder  *record.FakeRecorder
+		)
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "chaos-demo", Labels: map[string]string{"team": "payments"}}}
+			blackouts = nil
+			recorder = record.NewFakeRecorder(10)
+		})
+
+		getActiveBlackout := func() (*DisruptionBlackout, error) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(AddToScheme(scheme)).To(Succeed())
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(blackouts, namespace)...).Build()
+
+			return GetActiveBlackout(context.Background(), k8sClient, zap.NewNop().Sugar(), recorder, "chaos-demo", now)
+		}
+
+		newBlackout := func(name string, window DisruptionBlackoutWindow, selector *metav1.LabelSelector) *DisruptionBlackout {
+			return &DisruptionBlackout{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: DisruptionBlackoutSpec{
+					Reason:            name,
+					Windows:           []DisruptionBlackoutWindow{window},
+					NamespaceSelector: selector,
+				},
+			}
+		}
+
+		It("should return nothing without blackouts", func() {
+			Expect(getActiveBlackout()).To(BeNil())
+		})
+
+		It("should return the active blackout matching the namespace", func() {
+			blackouts = append(blackouts,
+				newBlackout("night", DisruptionBlackoutWindow{Schedule: "0 22 * * *", Duration: "8h", TimeZone: "UTC"}, nil),
+				newBlackout("freeze", releaseFreeze, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}),
+			)
+
+			blackout, err := getActiveBlackout()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(blackout).ToNot(BeNil())
+			Expect(blackout.Name).To(Equal("freeze"))
+		})
+
+		It("should ignore active blackouts of other namespaces", func() {
+			blackouts = append(blackouts, newBlackout("freeze", releaseFreeze, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}}))
+
+			Expect(getActiveBlackout()).To(BeNil())
+		})
+
+		It("should ignore invalid blackouts and report them with a warning event", func() {
+			blackouts = append(blackouts, newBlackout("invalid", DisruptionBlackoutWindow{Schedule: "every day"}, nil))
+
+			Expect(getActiveBlackout()).To(BeNil())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning BlackoutInvalid The blackout is ignored as it is invalid")))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the DisruptionBlackout validating webhook
+func (b *DisruptionBlackout) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(b).
+		Complete()
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-disruptionblackout,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=disruptionblackouts,verbs=create;update,versions=v1beta1,name=vdisruptionblackout.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DisruptionBlackout{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// invalid blackouts are rejected as they would be ignored when checking if disruptions are allowed
+func (b *DisruptionBlackout) ValidateCreate() error {
+	return b.Spec.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (b *DisruptionBlackout) ValidateUpdate(old runtime.Object) error {
+	return b.Spec.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (b *DisruptionBlackout) ValidateDelete() error {
+	return nil
+}
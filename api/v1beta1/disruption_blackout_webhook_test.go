@@ -0,0 +1,38 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DisruptionBlackout webhook", func() {
+	It("should accept a valid blackout", func() {
+		blackout := &DisruptionBlackout{
+			Spec: DisruptionBlackoutSpec{
+				Reason:  "release freeze",
+				Windows: []DisruptionBlackoutWindow{{Schedule: "0 9 * * 1-5", Duration: "8h", TimeZone: "Europe/Paris"}},
+			},
+		}
+
+		Expect(blackout.ValidateCreate()).To(Succeed())
+		Expect(blackout.ValidateUpdate(blackout)).To(Succeed())
+	})
+
+	It("should reject a blackout with an unknown time zone", func() {
+		blackout := &DisruptionBlackout{
+			Spec: DisruptionBlackoutSpec{
+				Reason:  "release freeze",
+				Windows: []DisruptionBlackoutWindow{{Schedule: "0 9 * * 1-5", Duration: "8h", TimeZone: "Europe/Pariss"}},
+			},
+		}
+
+		Expect(blackout.ValidateCreate()).ToNot(Succeed())
+		Expect(blackout.ValidateUpdate(blackout)).ToNot(Succeed())
+	})
+})
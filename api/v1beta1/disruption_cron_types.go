@@ -5,12 +5,38 @@
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 func init() {
 	SchemeBuilder.Register(&DisruptionCron{}, &DisruptionCronList{})
 }
 
+// DisruptionCronConcurrencyPolicy describes how a new run is handled when the disruption of a previous run is still ongoing
+type DisruptionCronConcurrencyPolicy string
+
+const (
+	// DisruptionCronConcurrencyPolicyForbid skips the new run if the disruption of the previous run is still ongoing
+	DisruptionCronConcurrencyPolicyForbid DisruptionCronConcurrencyPolicy = "Forbid"
+	// DisruptionCronConcurrencyPolicyReplace deletes the disruption of the previous run and starts the new run once it is gone
+	DisruptionCronConcurrencyPolicyReplace DisruptionCronConcurrencyPolicy = "Replace"
+)
+
+const (
+	// DefaultDisruptionCronHistoryLimit is the number of past runs kept in the status when no limit is specified
+	DefaultDisruptionCronHistoryLimit = 10
+	// MaxDisruptionCronHistoryLimit is the maximum number of past runs kept in the status
+	MaxDisruptionCronHistoryLimit = 100
+)
+
 //+kubebuilder:object:root=true
 
 // DisruptionCron is the Schema for the disruptioncron API
@@ -40,6 +66,28 @@ type DisruptionCronSpec struct {
 	// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	Schedule string `json:"schedule"`
 
+	// TimeZone is the name of the time zone the schedule is evaluated in, from the IANA time zone database (e.g. "Europe/Paris").
+	// Defaults to the time zone of the controller, which is usually UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Suspend tells the controller to stop creating disruptions, runs scheduled while suspended are skipped.
+	// It does not apply to the disruption already running.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a run while the disruption of the previous one is still ongoing (defaults to Forbid):
+	// - Forbid: the new run is skipped
+	// - Replace: the ongoing disruption is deleted and replaced by the new run
+	// +kubebuilder:validation:Enum=Forbid;Replace;""
+	// +ddmark:validation:Enum=Forbid;Replace;""
+	ConcurrencyPolicy DisruptionCronConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// HistoryLimit is the number of past runs kept in the status (defaults to 10)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=0
+	// +ddmark:validation:Maximum=100
+	HistoryLimit int `json:"historyLimit,omitempty"`
+
 	// Optional deadline for starting the disruption if it misses scheduled time
 	// for any reason.  Missed disruption executions will be counted as failed ones.
 	// +nullable
@@ -48,7 +96,6 @@ type DisruptionCronSpec struct {
 	// +kubebuilder:validation:Required
 	// +ddmark:validation:Required=true
 	// TargetResource specifies the resource to run disruptions against.
-	// It can only be a deployment or statefulset.
 	TargetResource TargetResourceSpec `json:"targetResource"`
 
 	// +kubebuilder:validation:Required
@@ -57,21 +104,59 @@ type DisruptionCronSpec struct {
 	DisruptionTemplate DisruptionSpec `json:"disruptionTemplate"`
 }
 
+// TargetResourceKinds are the kinds of target resources natively supported, any other kind requires the APIVersion to be specified
+var TargetResourceKinds = []string{"deployment", "statefulset", "daemonset", "replicaset", "job"}
+
 // TargetResource specifies the long-lived resource to be targeted for disruptions.
 // DisruptionCrons are intended to exist semi-permanently, and thus appropriate targets can only be other long-lived resources,
 // such as statefulsets or deployment.
 type TargetResourceSpec struct {
-	// +kubebuilder:validation:Enum=deployment;statefulset
 	// +kubebuilder:validation:Required
-	// +ddmark:validation:Enum=deployment;statefulset
 	// +ddmark:validation:Required=true
-	// Kind specifies the type of the long-lived resource. Allowed values: "deployment", "statefulset".
+	// Kind specifies the type of the long-lived resource. Allowed values: "deployment", "statefulset", "daemonset", "replicaset", "job",
+	// or the kind of any other resource having a spec.selector field when apiVersion is set (e.g. "Rollout").
 	Kind string `json:"kind"`
 
 	// +kubebuilder:validation:Required
 	// +ddmark:validation:Required=true
 	// Name specifies the name of the specific instance of the long-lived resource to be targeted.
 	Name string `json:"name"`
+
+	// APIVersion specifies the group and version of a resource which is not natively supported (e.g. "argoproj.io/v1alpha1").
+	// When set, the resource is looked up by its kind and apiVersion, and its pods are selected using its spec.selector field.
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// IsGeneric returns true if the target resource is not natively supported and must be looked up by its kind and apiVersion
+func (t TargetResourceSpec) IsGeneric() bool {
+	return t.APIVersion != ""
+}
+
+// Validate ensures the kind of the target resource is supported
+func (t TargetResourceSpec) Validate() error {
+	if t.Name == "" {
+		return errors.New("target resource name must be specified")
+	}
+
+	if t.IsGeneric() {
+		if t.Kind == "" {
+			return errors.New("target resource kind must be specified along with its apiVersion")
+		}
+
+		if _, err := schema.ParseGroupVersion(t.APIVersion); err != nil {
+			return fmt.Errorf("invalid target resource apiVersion %s: %w", t.APIVersion, err)
+		}
+
+		return nil
+	}
+
+	for _, kind := range TargetResourceKinds {
+		if t.Kind == kind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported target resource kind %s, it must be one of %s, or the apiVersion must be specified", t.Kind, strings.Join(TargetResourceKinds, ", "))
 }
 
 // DisruptionCronStatus defines the observed state of DisruptionCron
@@ -83,4 +168,59 @@ type DisruptionCronStatus struct {
 	// Time when the target resource was previously missing.
 	// +nullable
 	TargetResourcePreviouslyMissing *metav1.Time `json:"targetResourcePreviouslyMissing,omitempty"`
+
+	// History of the most recent runs, from the oldest to the newest.
+	// +nullable
+	History []DisruptionCronRun `json:"history,omitempty"`
+}
+
+// DisruptionCronRun is a past or ongoing run of a DisruptionCron
+type DisruptionCronRun struct {
+	// DisruptionName is the name of the disruption created for the run
+	DisruptionName string `json:"disruptionName"`
+	// StartTime is the creation time of the disruption
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time the disruption ended or has been deleted, it is empty while the run is ongoing
+	// +nullable
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// InjectionStatus is the last observed injection status of the disruption
+	InjectionStatus chaostypes.DisruptionInjectionStatus `json:"injectionStatus,omitempty"`
+	// Health is the last observed health of the disruption targets
+	// +nullable
+	Health *TargetsHealth `json:"health,omitempty"`
+}
+
+// GetConcurrencyPolicy returns the concurrency policy of the DisruptionCron, defaulting to Forbid
+func (s DisruptionCronSpec) GetConcurrencyPolicy() DisruptionCronConcurrencyPolicy {
+	if s.ConcurrencyPolicy == "" {
+		return DisruptionCronConcurrencyPolicyForbid
+	}
+
+	return s.ConcurrencyPolicy
+}
+
+// GetHistoryLimit returns the number of past runs to keep in the status
+func (s DisruptionCronSpec) GetHistoryLimit() int {
+	switch {
+	case s.HistoryLimit <= 0:
+		return DefaultDisruptionCronHistoryLimit
+	case s.HistoryLimit > MaxDisruptionCronHistoryLimit:
+		return MaxDisruptionCronHistoryLimit
+	}
+
+	return s.HistoryLimit
+}
+
+// GetLocation returns the location the schedule is evaluated in, defaulting to the local time zone of the controller
+func (s DisruptionCronSpec) GetLocation() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s: %w", s.TimeZone, err)
+	}
+
+	return location, nil
 }
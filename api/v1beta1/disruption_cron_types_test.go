@@ -0,0 +1,59 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TargetResourceSpec", func() {
+	Describe("Validate", func() {
+		DescribeTable("with valid target resources",
+			func(targetResource TargetResourceSpec) {
+				Expect(targetResource.Validate()).Should(Succeed())
+			},
+			Entry("with a deployment", TargetResourceSpec{Kind: "deployment", Name: "demo"}),
+			Entry("with a statefulset", TargetResourceSpec{Kind: "statefulset", Name: "demo"}),
+			Entry("with a daemonset", TargetResourceSpec{Kind: "daemonset", Name: "demo"}),
+			Entry("with a replicaset", TargetResourceSpec{Kind: "replicaset", Name: "demo"}),
+			Entry("with a job", TargetResourceSpec{Kind: "job", Name: "demo"}),
+			Entry("with a generic resource", TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1"}),
+		)
+
+		DescribeTable("with invalid target resources",
+			func(targetResource TargetResourceSpec, expectedErrorMessage string) {
+				err := targetResource.Validate()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("without name", TargetResourceSpec{Kind: "deployment"}, "target resource name must be specified"),
+			Entry("with an unknown kind", TargetResourceSpec{Kind: "Rollout", Name: "demo"}, "unsupported target resource kind Rollout"),
+			Entry("with an apiVersion but no kind", TargetResourceSpec{Name: "demo", APIVersion: "argoproj.io/v1alpha1"}, "target resource kind must be specified"),
+			Entry("with an invalid apiVersion", TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1/rollouts"}, "invalid target resource apiVersion"),
+		)
+	})
+})
+
+var _ = Describe("DisruptionRolloutSpec", func() {
+	DescribeTable("ValidateTargetResource",
+		func(targetResource TargetResourceSpec, expectedValid bool) {
+			spec := DisruptionRolloutSpec{TargetResource: targetResource}
+
+			if expectedValid {
+				Expect(spec.ValidateTargetResource()).Should(Succeed())
+			} else {
+				Expect(spec.ValidateTargetResource()).ShouldNot(Succeed())
+			}
+		},
+		Entry("with a deployment", TargetResourceSpec{Kind: "deployment", Name: "demo"}, true),
+		Entry("with a daemonset", TargetResourceSpec{Kind: "daemonset", Name: "demo"}, true),
+		Entry("with a job", TargetResourceSpec{Kind: "job", Name: "demo"}, false),
+		Entry("with a generic resource", TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1"}, false),
+	)
+})
@@ -0,0 +1,278 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultGuardrailsInterval is the interval between two evaluations of the guardrails when none is specified
+	DefaultGuardrailsInterval = 30 * time.Second
+	// MinimumGuardrailsInterval is the smallest allowed interval between two evaluations of the guardrails
+	MinimumGuardrailsInterval = 5 * time.Second
+	// DefaultGuardrailHTTPTimeout is the timeout of an HTTP probe when none is specified
+	DefaultGuardrailHTTPTimeout = 5 * time.Second
+)
+
+// GuardrailComparison is the operator used to compare the result of a Prometheus query with a threshold
+type GuardrailComparison string
+
+const (
+	GuardrailComparisonGreaterThan        GuardrailComparison = ">"
+	GuardrailComparisonGreaterThanOrEqual GuardrailComparison = ">="
+	GuardrailComparisonLessThan           GuardrailComparison = "<"
+	GuardrailComparisonLessThanOrEqual    GuardrailComparison = "<="
+	GuardrailComparisonEqual              GuardrailComparison = "=="
+	GuardrailComparisonNotEqual           GuardrailComparison = "!="
+)
+
+// Compare returns true if the given value compared to the threshold with the operator is true
+func (c GuardrailComparison) Compare(value, threshold float64) bool {
+	switch c {
+	case GuardrailComparisonGreaterThan:
+		return value > threshold
+	case GuardrailComparisonGreaterThanOrEqual:
+		return value >= threshold
+	case GuardrailComparisonLessThan:
+		return value < threshold
+	case GuardrailComparisonLessThanOrEqual:
+		return value <= threshold
+	case GuardrailComparisonEqual:
+		return value == threshold
+	case GuardrailComparisonNotEqual:
+		return value != threshold
+	}
+
+	return false
+}
+
+// Guardrails holds the steady-state checks periodically evaluated while the disruption is ongoing
+// the disruption is cleaned early as soon as one of the checks is breached
+type Guardrails struct {
+	// Interval between two evaluations of the checks (defaults to 30s)
+	Interval DisruptionDuration `json:"interval,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Checks []GuardrailCheck `json:"checks"`
+}
+
+// GuardrailCheck is a single steady-state check, only one kind of check can be defined per item
+// +ddmark:validation:ExclusiveFields={HTTP,Prometheus,TargetReadiness}
+// +ddmark:validation:ExclusiveFields={Prometheus,HTTP,TargetReadiness}
+// +ddmark:validation:ExclusiveFields={TargetReadiness,HTTP,Prometheus}
+// +ddmark:validation:AtLeastOneOf={HTTP,Prometheus,TargetReadiness}
+type GuardrailCheck struct {
+	// Name uniquely identifies the check in the disruption events and status
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Name string `json:"name"`
+	// FailureThreshold is the number of consecutive failed evaluations before the check is considered breached (defaults to 1)
+	// +kubebuilder:validation:Minimum=0
+	// +ddmark:validation:Minimum=0
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// +nullable
+	HTTP *GuardrailHTTPCheck `json:"http,omitempty"`
+	// +nullable
+	Prometheus *GuardrailPrometheusCheck `json:"prometheus,omitempty"`
+	// +nullable
+	TargetReadiness *GuardrailTargetReadinessCheck `json:"targetReadiness,omitempty"`
+}
+
+// GuardrailHTTPCheck is breached when the given URL can't be reached or answers with an unexpected status code
+// the URL host must be allowed in the controller configuration
+type GuardrailHTTPCheck struct {
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	URL string `json:"url"`
+	// ExpectedStatusCodes are the status codes considered healthy (defaults to any 2xx status code)
+	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
+	// Timeout of the request (defaults to 5s)
+	Timeout DisruptionDuration `json:"timeout,omitempty"`
+}
+
+// GuardrailPrometheusCheck is breached when any sample returned by the query matches the comparison with the threshold
+// the query is run against the Prometheus endpoint configured in the controller
+type GuardrailPrometheusCheck struct {
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Query string `json:"query"`
+	// +kubebuilder:validation:Enum=">";">=";"<";"<=";"==";"!="
+	// +ddmark:validation:Enum=">";">=";"<";"<=";"==";"!="
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Comparison GuardrailComparison `json:"comparison"`
+	// Threshold is a float value, as a string
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Threshold string `json:"threshold"`
+}
+
+// GuardrailTargetReadinessCheck is breached when the ratio of ready targets (pods or nodes) goes below the given percentage
+type GuardrailTargetReadinessCheck struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=0
+	// +ddmark:validation:Maximum=100
+	MinReadyPercentage int `json:"minReadyPercentage"`
+}
+
+// GuardrailsStatus holds the result of the last evaluation of the guardrails
+type GuardrailsStatus struct {
+	// +nullable
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime,omitempty"`
+	// +nullable
+	Checks []GuardrailCheckStatus `json:"checks,omitempty"`
+	// BreachedCheck is the name of the check which caused the disruption to be cleaned early
+	BreachedCheck string `json:"breachedCheck,omitempty"`
+}
+
+// GuardrailCheckStatus holds the result of the last evaluation of a check
+type GuardrailCheckStatus struct {
+	Name                string `json:"name"`
+	ConsecutiveFailures int    `json:"consecutiveFailures,omitempty"`
+	Message             string `json:"message,omitempty"`
+}
+
+// GetInterval returns the interval between two evaluations of the guardrails
+func (g Guardrails) GetInterval() time.Duration {
+	if interval := g.Interval.Duration(); interval > 0 {
+		return interval
+	}
+
+	return DefaultGuardrailsInterval
+}
+
+// Validate validates the guardrails
+func (g Guardrails) Validate() (retErr error) {
+	if interval := g.Interval.Duration(); interval != 0 && interval < MinimumGuardrailsInterval {
+		retErr = multierror.Append(retErr, fmt.Errorf("guardrails interval of %s should be greater than %s", interval, MinimumGuardrailsInterval))
+	}
+
+	if len(g.Checks) == 0 {
+		retErr = multierror.Append(retErr, errors.New("guardrails must have at least one check"))
+	}
+
+	names := map[string]struct{}{}
+
+	for _, check := range g.Checks {
+		if _, ok := names[check.Name]; ok {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check names must be unique, %s is defined several times", check.Name))
+		}
+
+		names[check.Name] = struct{}{}
+
+		if err := check.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
+	return retErr
+}
+
+// ValidateHosts validates the urls of the http checks target hosts allowed in the controller configuration
+func (g Guardrails) ValidateHosts(allowedHosts []string) (retErr error) {
+	for _, check := range g.Checks {
+		if check.HTTP == nil {
+			continue
+		}
+
+		u, err := url.ParseRequestURI(check.HTTP.URL)
+		if err != nil {
+			// invalid urls are reported by Validate
+			continue
+		}
+
+		if err := ValidateGuardrailURL(u, allowedHosts); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s: %w", check.Name, err))
+		}
+	}
+
+	return retErr
+}
+
+// ValidateGuardrailURL returns an error if the given url is not an http or https url targeting one of the allowed hosts
+// hosts are compared case-insensitively and without their port
+func ValidateGuardrailURL(u *url.URL, allowedHosts []string) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url scheme %s is not supported, it must be http or https", u.Scheme)
+	}
+
+	for _, allowedHost := range allowedHosts {
+		if strings.EqualFold(u.Hostname(), allowedHost) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("url host %s is not allowed by the controller, allowed hosts are %v", u.Hostname(), allowedHosts)
+}
+
+// GetFailureThreshold returns the number of consecutive failed evaluations before the check is considered breached
+func (c GuardrailCheck) GetFailureThreshold() int {
+	if c.FailureThreshold > 0 {
+		return c.FailureThreshold
+	}
+
+	return 1
+}
+
+// Validate validates a single guardrail check
+func (c GuardrailCheck) Validate() (retErr error) {
+	if c.Name == "" {
+		retErr = multierror.Append(retErr, errors.New("guardrail checks must have a name"))
+	}
+
+	definedChecks := 0
+
+	if c.HTTP != nil {
+		definedChecks++
+
+		if _, err := url.ParseRequestURI(c.HTTP.URL); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s has an invalid url: %w", c.Name, err))
+		}
+
+		for _, code := range c.HTTP.ExpectedStatusCodes {
+			if code < 100 || code > 599 {
+				retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s has an invalid expected status code %d", c.Name, code))
+			}
+		}
+	}
+
+	if c.Prometheus != nil {
+		definedChecks++
+
+		if c.Prometheus.Query == "" {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s must have a prometheus query", c.Name))
+		}
+
+		if _, err := strconv.ParseFloat(c.Prometheus.Threshold, 64); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s has an invalid threshold %s: %w", c.Name, c.Prometheus.Threshold, err))
+		}
+	}
+
+	if c.TargetReadiness != nil {
+		definedChecks++
+
+		if c.TargetReadiness.MinReadyPercentage < 0 || c.TargetReadiness.MinReadyPercentage > 100 {
+			retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s minReadyPercentage must be between 0 and 100", c.Name))
+		}
+	}
+
+	if definedChecks != 1 {
+		retErr = multierror.Append(retErr, fmt.Errorf("guardrail check %s must define exactly one of http, prometheus or targetReadiness", c.Name))
+	}
+
+	return retErr
+}
@@ -0,0 +1,159 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guardrails", func() {
+	httpCheck := GuardrailCheck{
+		Name: "probe",
+		HTTP: &GuardrailHTTPCheck{
+			URL: "http://demo.chaos-demo.svc.cluster.local:8080/health",
+		},
+	}
+	prometheusCheck := GuardrailCheck{
+		Name: "error-rate",
+		Prometheus: &GuardrailPrometheusCheck{
+			Query:      "sum(rate(errors[1m]))",
+			Comparison: GuardrailComparisonGreaterThan,
+			Threshold:  "0.1",
+		},
+	}
+
+	Describe("Validate", func() {
+		DescribeTable("with valid guardrails",
+			func(guardrails Guardrails) {
+				// Action
+				err := guardrails.Validate()
+
+				// Assert
+				Expect(err).ShouldNot(HaveOccurred())
+			},
+			Entry("with an http check",
+				Guardrails{Checks: []GuardrailCheck{httpCheck}},
+			),
+			Entry("with several checks and an interval",
+				Guardrails{
+					Interval: "1m",
+					Checks: []GuardrailCheck{
+						httpCheck,
+						prometheusCheck,
+						{Name: "readiness", TargetReadiness: &GuardrailTargetReadinessCheck{MinReadyPercentage: 80}},
+					},
+				},
+			),
+		)
+
+		DescribeTable("with invalid guardrails",
+			func(guardrails Guardrails, expectedErrorMessage string) {
+				// Action
+				err := guardrails.Validate()
+
+				// Assert
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("without checks",
+				Guardrails{},
+				"guardrails must have at least one check",
+			),
+			Entry("with a too short interval",
+				Guardrails{Interval: "1s", Checks: []GuardrailCheck{httpCheck}},
+				"guardrails interval of 1s should be greater than 5s",
+			),
+			Entry("with duplicated check names",
+				Guardrails{Checks: []GuardrailCheck{httpCheck, httpCheck}},
+				"probe is defined several times",
+			),
+			Entry("with an invalid url",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "demo"}}}},
+				"guardrail check probe has an invalid url",
+			),
+			Entry("with an invalid threshold",
+				Guardrails{Checks: []GuardrailCheck{{Name: "error-rate", Prometheus: &GuardrailPrometheusCheck{Query: "up", Comparison: GuardrailComparisonLessThan, Threshold: "one"}}}},
+				"guardrail check error-rate has an invalid threshold one",
+			),
+			Entry("with several kinds of checks in the same item",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: httpCheck.HTTP, Prometheus: prometheusCheck.Prometheus}}},
+				"must define exactly one of http, prometheus or targetReadiness",
+			),
+		)
+	})
+
+	Describe("ValidateHosts", func() {
+		allowedHosts := []string{"demo.chaos-demo.svc.cluster.local"}
+
+		DescribeTable("with allowed hosts",
+			func(guardrails Guardrails) {
+				Expect(guardrails.ValidateHosts(allowedHosts)).To(Succeed())
+			},
+			Entry("with an http check targeting an allowed host",
+				Guardrails{Checks: []GuardrailCheck{httpCheck}},
+			),
+			Entry("with an http check targeting an allowed host in another case",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "https://DEMO.chaos-demo.svc.cluster.local/health"}}}},
+			),
+			Entry("without http checks",
+				Guardrails{Checks: []GuardrailCheck{prometheusCheck}},
+			),
+		)
+
+		DescribeTable("with hosts which are not allowed",
+			func(guardrails Guardrails, expectedErrorMessage string) {
+				err := guardrails.ValidateHosts(allowedHosts)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("with an http check targeting another host",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "http://169.254.169.254/latest/meta-data/"}}}},
+				"guardrail check probe: url host 169.254.169.254 is not allowed",
+			),
+			Entry("with an http check targeting a subdomain of an allowed host",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "http://evil.demo.chaos-demo.svc.cluster.local/"}}}},
+				"is not allowed",
+			),
+			Entry("with an http check using another scheme",
+				Guardrails{Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "file://demo.chaos-demo.svc.cluster.local/etc/passwd"}}}},
+				"url scheme file is not supported",
+			),
+		)
+
+		It("should deny any http check when no host is allowed", func() {
+			Expect(Guardrails{Checks: []GuardrailCheck{httpCheck}}.ValidateHosts(nil)).ToNot(Succeed())
+		})
+	})
+
+	Describe("GetInterval", func() {
+		It("should return the default interval when none is specified", func() {
+			Expect(Guardrails{}.GetInterval()).To(Equal(DefaultGuardrailsInterval))
+		})
+
+		It("should return the specified interval", func() {
+			Expect(Guardrails{Interval: "2m"}.GetInterval()).To(Equal(2 * time.Minute))
+		})
+	})
+
+	DescribeTable("GuardrailComparison.Compare",
+		func(comparison GuardrailComparison, value float64, expected bool) {
+			Expect(comparison.Compare(value, 1)).To(Equal(expected))
+		},
+		Entry("greater than", GuardrailComparisonGreaterThan, 2.0, true),
+		Entry("not greater than", GuardrailComparisonGreaterThan, 1.0, false),
+		Entry("greater than or equal", GuardrailComparisonGreaterThanOrEqual, 1.0, true),
+		Entry("less than", GuardrailComparisonLessThan, 0.5, true),
+		Entry("less than or equal", GuardrailComparisonLessThanOrEqual, 1.5, false),
+		Entry("equal", GuardrailComparisonEqual, 1.0, true),
+		Entry("not equal", GuardrailComparisonNotEqual, 1.0, false),
+		Entry("unknown comparison", GuardrailComparison("~"), 1.0, false),
+	)
+})
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetsHealth aggregates the health signals observed on the targets by the observer during the disruption,
+// so the resilience of the targets can be compared across runs, for instance the runs of a DisruptionCron
+type TargetsHealth struct {
+	// ContainerRestarts is the number of container restarts observed on the targets
+	ContainerRestarts int `json:"containerRestarts,omitempty"`
+	// ReadinessLostCount is the number of times a target went from ready to not ready
+	ReadinessLostCount int `json:"readinessLostCount,omitempty"`
+	// NodePressureCount is the number of memory, disk, PID pressure or network unavailable conditions raised on the targets nodes
+	NodePressureCount int `json:"nodePressureCount,omitempty"`
+	// UnhealthyTargets are the targets in a warning state which have not recovered yet
+	// +nullable
+	UnhealthyTargets []string `json:"unhealthyTargets,omitempty"`
+	// TimeToRecover is the time it took for all the unhealthy targets to recover once the disruption started to be cleaned,
+	// the targets are only observed until the end of the cleanup so it is empty if no target was unhealthy during the cleanup
+	// or if some of them have not recovered by its end
+	TimeToRecover DisruptionDuration `json:"timeToRecover,omitempty"`
+}
+
+// TargetHealthSignal holds the health signals observed on a target on a single update
+type TargetHealthSignal struct {
+	// Target is the name of the pod or node
+	Target string
+	// ContainerRestarts is the number of new container restarts
+	ContainerRestarts int
+	// ReadinessLost is true if the target went from ready to not ready
+	ReadinessLost bool
+	// NodePressures is the number of newly raised node pressure conditions
+	NodePressures int
+	// Unhealthy is true if the target entered a warning state
+	Unhealthy bool
+	// Recovered is true if the target recovered from a warning state
+	Recovered bool
+}
+
+// IsEmpty returns true if the signal holds nothing to aggregate
+func (s TargetHealthSignal) IsEmpty() bool {
+	return s.ContainerRestarts == 0 && !s.ReadinessLost && s.NodePressures == 0 && !s.Unhealthy && !s.Recovered
+}
+
+// Record aggregates the given signal, cleanupStartTime being the time the disruption started to be cleaned, if it has
+// it returns true if the health has been modified
+func (h *TargetsHealth) Record(signal TargetHealthSignal, cleanupStartTime *metav1.Time, now time.Time) bool {
+	updated := false
+
+	if signal.ContainerRestarts > 0 {
+		h.ContainerRestarts += signal.ContainerRestarts
+		updated = true
+	}
+
+	if signal.ReadinessLost {
+		h.ReadinessLostCount++
+		updated = true
+	}
+
+	if signal.NodePressures > 0 {
+		h.NodePressureCount += signal.NodePressures
+		updated = true
+	}
+
+	index := -1
+
+	for i, target := range h.UnhealthyTargets {
+		if target == signal.Target {
+			index = i
+			break
+		}
+	}
+
+	switch {
+	case signal.Unhealthy && index == -1:
+		h.UnhealthyTargets = append(h.UnhealthyTargets, signal.Target)
+
+		// the targets have not fully recovered anymore
+		if cleanupStartTime != nil {
+			h.TimeToRecover = ""
+		}
+
+		updated = true
+	case signal.Recovered && !signal.Unhealthy && index != -1:
+		h.UnhealthyTargets = append(h.UnhealthyTargets[:index], h.UnhealthyTargets[index+1:]...)
+
+		if len(h.UnhealthyTargets) == 0 && cleanupStartTime != nil {
+			timeToRecover := now.Sub(cleanupStartTime.Time).Round(time.Second)
+			if timeToRecover < 0 {
+				timeToRecover = 0
+			}
+
+			h.TimeToRecover = DisruptionDuration(timeToRecover.String())
+		}
+
+		updated = true
+	}
+
+	return updated
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TargetsHealth", func() {
+	var (
+		now    time.Time
+		health TargetsHealth
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+		health = TargetsHealth{}
+	})
+
+	Describe("Record", func() {
+		It("aggregates the restarts, readiness losses and node pressures", func() {
+			// Action
+			Expect(health.Record(TargetHealthSignal{Target: "pod-1", ContainerRestarts: 2}, nil, now)).To(BeTrue())
+			Expect(health.Record(TargetHealthSignal{Target: "pod-2", ContainerRestarts: 1, ReadinessLost: true, Unhealthy: true}, nil, now)).To(BeTrue())
+			Expect(health.Record(TargetHealthSignal{Target: "node-1", NodePressures: 2}, nil, now)).To(BeTrue())
+
+			// Assert
+			Expect(health.ContainerRestarts).To(Equal(3))
+			Expect(health.ReadinessLostCount).To(Equal(1))
+			Expect(health.NodePressureCount).To(Equal(2))
+			Expect(health.UnhealthyTargets).To(Equal([]string{"pod-2"}))
+		})
+
+		It("does not modify the health for a target recovering without being unhealthy", func() {
+			Expect(health.Record(TargetHealthSignal{Target: "pod-1", Recovered: true}, nil, now)).To(BeFalse())
+			Expect(health).To(Equal(TargetsHealth{}))
+		})
+
+		It("does not track the time to recover of targets recovering before the cleanup", func() {
+			// Arrange
+			health.UnhealthyTargets = []string{"pod-1"}
+
+			// Action
+			updated := health.Record(TargetHealthSignal{Target: "pod-1", Recovered: true}, nil, now)
+
+			// Assert
+			Expect(updated).To(BeTrue())
+			Expect(health.UnhealthyTargets).To(BeEmpty())
+			Expect(health.TimeToRecover).To(BeEmpty())
+		})
+
+		It("tracks the time for all targets to recover once the cleanup started", func() {
+			// Arrange
+			cleanupStartTime := metav1.NewTime(now)
+			health.UnhealthyTargets = []string{"pod-1", "pod-2"}
+
+			// Action
+			health.Record(TargetHealthSignal{Target: "pod-1", Recovered: true}, &cleanupStartTime, now.Add(30*time.Second))
+
+			// Assert
+			Expect(health.UnhealthyTargets).To(Equal([]string{"pod-2"}))
+			Expect(health.TimeToRecover).To(BeEmpty())
+
+			By("setting the time to recover once the last target recovered")
+			health.Record(TargetHealthSignal{Target: "pod-2", Recovered: true}, &cleanupStartTime, now.Add(90*time.Second))
+			Expect(health.UnhealthyTargets).To(BeEmpty())
+			Expect(health.TimeToRecover).To(Equal(DisruptionDuration("1m30s")))
+
+			By("resetting the time to recover when a target is unhealthy again during the cleanup")
+			health.Record(TargetHealthSignal{Target: "pod-1", Unhealthy: true}, &cleanupStartTime, now.Add(2*time.Minute))
+			Expect(health.UnhealthyTargets).To(Equal([]string{"pod-1"}))
+			Expect(health.TimeToRecover).To(BeEmpty())
+		})
+	})
+})
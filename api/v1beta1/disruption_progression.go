@@ -0,0 +1,101 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// MinimumProgressionInterval is the smallest allowed interval between two steps of a progression
+const MinimumProgressionInterval = 30 * time.Second
+
+// Progression escalates the number of targets of the disruption stepwise, starting from the disruption count
+// the disruption moves to the next step once the current one has been fully injected for the interval,
+// and only while the guardrails checks and the targets health stay green
+type Progression struct {
+	// Steps are the successive counts of targets to escalate to after the disruption count, in either integer form or percent form appended with a %
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Steps []intstr.IntOrString `json:"steps"`
+	// Interval is the minimum time a step must be fully injected before escalating to the next one
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Interval DisruptionDuration `json:"interval"`
+	// MinReadyPercentage holds the escalation while the percentage of ready targets is below the given value (disabled by default)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=0
+	// +ddmark:validation:Maximum=100
+	MinReadyPercentage int `json:"minReadyPercentage,omitempty"`
+}
+
+// ProgressionStatus holds the current step of the progression
+type ProgressionStatus struct {
+	// CurrentStep is the index of the current step, 0 being the disruption count and 1 the first step of the progression
+	CurrentStep int `json:"currentStep"`
+	// LastStepTime is the time the disruption escalated to the current step, or the time the disruption was first fully injected for the initial step
+	// +nullable
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty"`
+	// Message explains why the progression is on hold, if so
+	Message string `json:"message,omitempty"`
+}
+
+// Validate validates the progression
+func (p Progression) Validate() (retErr error) {
+	if len(p.Steps) == 0 {
+		retErr = multierror.Append(retErr, errors.New("progression must have at least one step"))
+	}
+
+	for i := range p.Steps {
+		if err := ValidateCount(&p.Steps[i]); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("progression step %d: %w", i+1, err))
+		}
+	}
+
+	if interval := p.Interval.Duration(); interval < MinimumProgressionInterval {
+		retErr = multierror.Append(retErr, fmt.Errorf("progression interval of %s should be greater than %s", interval, MinimumProgressionInterval))
+	}
+
+	if p.MinReadyPercentage < 0 || p.MinReadyPercentage > 100 {
+		retErr = multierror.Append(retErr, errors.New("progression minReadyPercentage must be between 0 and 100"))
+	}
+
+	return retErr
+}
+
+// GetCount returns the count of targets of the given step, the initial step being the given disruption count
+func (p Progression) GetCount(count *intstr.IntOrString, step int) *intstr.IntOrString {
+	if step <= 0 || len(p.Steps) == 0 {
+		return count
+	}
+
+	if step > len(p.Steps) {
+		step = len(p.Steps)
+	}
+
+	return &p.Steps[step-1]
+}
+
+// IsLastStep returns true if the given step is the last one of the progression
+func (p Progression) IsLastStep(step int) bool {
+	return step >= len(p.Steps)
+}
+
+// GetTargetsCount returns the count of targets of the current progression step, or the disruption count if it has no progression
+func (r *Disruption) GetTargetsCount() *intstr.IntOrString {
+	if r.Spec.Progression == nil || r.Status.Progression == nil {
+		return r.Spec.Count
+	}
+
+	return r.Spec.Progression.GetCount(r.Spec.Count, r.Status.Progression.CurrentStep)
+}
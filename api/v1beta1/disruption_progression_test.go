@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Progression", func() {
+	tenPercent := intstr.FromString("10%")
+	quarter := intstr.FromString("25%")
+
+	Describe("Validate", func() {
+		DescribeTable("with valid progressions",
+			func(progression Progression) {
+				// Action
+				err := progression.Validate()
+
+				// Assert
+				Expect(err).ShouldNot(HaveOccurred())
+			},
+			Entry("with a single step",
+				Progression{Steps: []intstr.IntOrString{tenPercent}, Interval: "30s"},
+			),
+			Entry("with several steps and a minimum readiness",
+				Progression{Steps: []intstr.IntOrString{intstr.FromInt(3), tenPercent, quarter}, Interval: "5m", MinReadyPercentage: 80},
+			),
+		)
+
+		DescribeTable("with invalid progressions",
+			func(progression Progression, expectedErrorMessage string) {
+				// Action
+				err := progression.Validate()
+
+				// Assert
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("without steps",
+				Progression{Interval: "1m"},
+				"progression must have at least one step",
+			),
+			Entry("with an invalid step",
+				Progression{Steps: []intstr.IntOrString{tenPercent, intstr.FromString("120%")}, Interval: "1m"},
+				"progression step 2: count must be a positive integer or a valid percentage value",
+			),
+			Entry("with a too short interval",
+				Progression{Steps: []intstr.IntOrString{tenPercent}, Interval: "10s"},
+				"progression interval of 10s should be greater than 30s",
+			),
+			Entry("with an invalid minimum readiness",
+				Progression{Steps: []intstr.IntOrString{tenPercent}, Interval: "1m", MinReadyPercentage: 120},
+				"progression minReadyPercentage must be between 0 and 100",
+			),
+		)
+	})
+
+	Describe("GetTargetsCount", func() {
+		count := intstr.FromInt(1)
+
+		DescribeTable("should return the count of the current step",
+			func(progression *Progression, status *ProgressionStatus, expectedCount intstr.IntOrString) {
+				// Arrange
+				disruption := Disruption{
+					Spec:   DisruptionSpec{Count: &count, Progression: progression},
+					Status: DisruptionStatus{Progression: status},
+				}
+
+				// Action && Assert
+				Expect(*disruption.GetTargetsCount()).To(Equal(expectedCount))
+			},
+			Entry("without progression",
+				nil, nil, count,
+			),
+			Entry("without progression status",
+				&Progression{Steps: []intstr.IntOrString{tenPercent, quarter}}, nil, count,
+			),
+			Entry("on the initial step",
+				&Progression{Steps: []intstr.IntOrString{tenPercent, quarter}}, &ProgressionStatus{CurrentStep: 0}, count,
+			),
+			Entry("on an intermediate step",
+				&Progression{Steps: []intstr.IntOrString{tenPercent, quarter}}, &ProgressionStatus{CurrentStep: 1}, tenPercent,
+			),
+			Entry("on the last step",
+				&Progression{Steps: []intstr.IntOrString{tenPercent, quarter}}, &ProgressionStatus{CurrentStep: 2}, quarter,
+			),
+			Entry("beyond the last step",
+				&Progression{Steps: []intstr.IntOrString{tenPercent, quarter}}, &ProgressionStatus{CurrentStep: 5}, quarter,
+			),
+		)
+	})
+})
@@ -0,0 +1,280 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DisruptionQuotas are the limits of disruptions allowed to run concurrently, a zero value disables the related quota
+type DisruptionQuotas struct {
+	// MaxDisruptionsPerNamespace is the maximum number of disruptions running at once in a namespace
+	MaxDisruptionsPerNamespace int
+	// MaxDisruptionsPerCluster is the maximum number of disruptions running at once in the cluster
+	MaxDisruptionsPerCluster int
+	// MaxDisruptionsPerKind is the maximum number of disruptions of the same kind running at once in the cluster
+	MaxDisruptionsPerKind int
+	// MaxWorkloadDisruptedPercentage is the maximum percentage of the pods of a workload targeted by all the running disruptions at once
+	MaxWorkloadDisruptedPercentage int
+}
+
+// IsZero returns true if no quota is enabled
+func (q DisruptionQuotas) IsZero() bool {
+	return q == DisruptionQuotas{}
+}
+
+// Check returns the reasons why the given disruption exceeds the quotas, or nothing if it can run
+// targets are the pods the disruption is about to inject, the worst case is assumed from its selector and count if they are not known yet
+// only the running disruptions created before the given one are accounted for, so the oldest disruption wins when two of them compete for the same quota
+func (q DisruptionQuotas) Check(ctx context.Context, k8sClient client.Client, r *Disruption, targets []string) ([]string, error) {
+	if q.IsZero() {
+		return nil, nil
+	}
+
+	disruptions := &DisruptionList{}
+	if err := k8sClient.List(ctx, disruptions); err != nil {
+		return nil, fmt.Errorf("error listing disruptions: %w", err)
+	}
+
+	others := []Disruption{}
+
+	for _, disruption := range disruptions.Items {
+		if isRunningBefore(disruption, *r) {
+			others = append(others, disruption)
+		}
+	}
+
+	reasons := q.checkConcurrency(r, others)
+
+	if q.MaxWorkloadDisruptedPercentage > 0 && r.Spec.Level != chaostypes.DisruptionLevelNode {
+		workloadReasons, err := q.checkWorkloads(ctx, k8sClient, r, others, targets)
+		if err != nil {
+			return nil, err
+		}
+
+		reasons = append(reasons, workloadReasons...)
+	}
+
+	return reasons, nil
+}
+
+// checkConcurrency checks the number of disruptions running alongside the given one per namespace, cluster and kind
+func (q DisruptionQuotas) checkConcurrency(r *Disruption, others []Disruption) []string {
+	reasons := []string{}
+
+	if q.MaxDisruptionsPerCluster > 0 && len(others) >= q.MaxDisruptionsPerCluster {
+		reasons = append(reasons, fmt.Sprintf("%d disruptions are already running in the cluster while the quota is %d", len(others), q.MaxDisruptionsPerCluster))
+	}
+
+	if q.MaxDisruptionsPerNamespace > 0 {
+		namespaceCount := 0
+
+		for _, other := range others {
+			if other.Namespace == r.Namespace {
+				namespaceCount++
+			}
+		}
+
+		if namespaceCount >= q.MaxDisruptionsPerNamespace {
+			reasons = append(reasons, fmt.Sprintf("%d disruptions are already running in the %s namespace while the quota is %d", namespaceCount, r.Namespace, q.MaxDisruptionsPerNamespace))
+		}
+	}
+
+	if q.MaxDisruptionsPerKind > 0 {
+		for _, kind := range r.Spec.KindNames() {
+			kindCount := 0
+
+			for _, other := range others {
+				for _, otherKind := range other.Spec.KindNames() {
+					if otherKind == kind {
+						kindCount++
+					}
+				}
+			}
+
+			if kindCount >= q.MaxDisruptionsPerKind {
+				reasons = append(reasons, fmt.Sprintf("%d %s disruptions are already running in the cluster while the quota is %d", kindCount, kind, q.MaxDisruptionsPerKind))
+			}
+		}
+	}
+
+	return reasons
+}
+
+// checkWorkloads checks the percentage of the pods of each workload targeted by the given disruption which would be disrupted at once
+func (q DisruptionQuotas) checkWorkloads(ctx context.Context, k8sClient client.Client, r *Disruption, others []Disruption, targets []string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := k8sClient.List(ctx, pods, client.InNamespace(r.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing namespace pods: %w", err)
+	}
+
+	// pods already disrupted by the other running disruptions of the namespace
+	disrupted := map[string]bool{}
+
+	for _, other := range others {
+		if other.Namespace != r.Namespace || other.Spec.Level == chaostypes.DisruptionLevelNode {
+			continue
+		}
+
+		for target := range other.Status.TargetInjections {
+			disrupted[target] = true
+		}
+	}
+
+	workloadPods := map[string][]string{}
+	podWorkloads := map[string]string{}
+
+	for _, pod := range pods.Items {
+		if workload := getPodWorkload(pod); workload != "" {
+			workloadPods[workload] = append(workloadPods[workload], pod.Name)
+			podWorkloads[pod.Name] = workload
+		}
+	}
+
+	// pods of each workload which would be disrupted by the given disruption
+	newlyDisrupted := map[string]int{}
+
+	if targets != nil {
+		for _, target := range targets {
+			if workload, ok := podWorkloads[target]; ok && !disrupted[target] {
+				newlyDisrupted[workload]++
+			}
+		}
+	} else {
+		var err error
+		if newlyDisrupted, err = getWorstCaseNewlyDisrupted(r, pods.Items, podWorkloads, disrupted); err != nil {
+			return nil, err
+		}
+	}
+
+	workloads := make([]string, 0, len(newlyDisrupted))
+	for workload := range newlyDisrupted {
+		workloads = append(workloads, workload)
+	}
+
+	sort.Strings(workloads)
+
+	reasons := []string{}
+
+	for _, workload := range workloads {
+		total := newlyDisrupted[workload]
+
+		for _, pod := range workloadPods[workload] {
+			if disrupted[pod] {
+				total++
+			}
+		}
+
+		if percentage := float64(total) / float64(len(workloadPods[workload])) * 100; percentage > float64(q.MaxWorkloadDisruptedPercentage) {
+			reasons = append(reasons, fmt.Sprintf("%.2f %% of the pods of the %s workload would be disrupted at once while the quota is %d %%", percentage, workload, q.MaxWorkloadDisruptedPercentage))
+		}
+	}
+
+	return reasons, nil
+}
+
+// getWorstCaseNewlyDisrupted returns, for each workload, the highest number of its pods the given disruption could disrupt
+// on top of the already disrupted ones, considering its selector and its count
+func getWorstCaseNewlyDisrupted(r *Disruption, pods []corev1.Pod, podWorkloads map[string]string, disrupted map[string]bool) (map[string]int, error) {
+	selector := labels.SelectorFromValidatedSet(r.Spec.Selector)
+
+	if r.Spec.AdvancedSelector != nil {
+		reqs, err := AdvancedSelectorsToRequirements(r.Spec.AdvancedSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		selector = selector.Add(reqs...)
+	}
+
+	matching := 0
+	candidates := map[string]int{}
+
+	for _, pod := range pods {
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		matching++
+
+		if workload, ok := podWorkloads[pod.Name]; ok && !disrupted[pod.Name] {
+			candidates[workload]++
+		}
+	}
+
+	count := r.Spec.Count
+	if r.Spec.Progression != nil {
+		// a progressive disruption eventually escalates to its last step count
+		count = r.Spec.Progression.GetCount(r.Spec.Count, len(r.Spec.Progression.Steps))
+	}
+
+	if count == nil {
+		return candidates, nil
+	}
+
+	planned, err := intstr.GetScaledValueFromIntOrPercent(count, matching, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get count: %w", err)
+	}
+
+	for workload, candidatesCount := range candidates {
+		if candidatesCount > planned {
+			candidates[workload] = planned
+		}
+	}
+
+	return candidates, nil
+}
+
+// getPodWorkload returns the kind and the name of the workload controlling the given pod, or an empty string if the pod is not controlled
+// pods of a replicaset created by a deployment are considered as part of the deployment
+func getPodWorkload(pod corev1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return ""
+	}
+
+	if hash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok && owner.Kind == "ReplicaSet" && strings.HasSuffix(owner.Name, "-"+hash) {
+		return "Deployment/" + strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+
+	return owner.Kind + "/" + owner.Name
+}
+
+// isRunningBefore returns true if the given disruption was running before the reference one
+// an expired or deleted disruption is not running anymore, and the reference disruption itself is not accounted for
+func isRunningBefore(disruption Disruption, reference Disruption) bool {
+	if !disruption.DeletionTimestamp.IsZero() || disruption.Status.InjectionStatus.Previously() {
+		return false
+	}
+
+	if disruption.Namespace == reference.Namespace && disruption.Name == reference.Name {
+		return false
+	}
+
+	// the reference disruption is being created, all the existing disruptions were created before it
+	if reference.CreationTimestamp.IsZero() {
+		return true
+	}
+
+	if !disruption.CreationTimestamp.Equal(&reference.CreationTimestamp) {
+		return disruption.CreationTimestamp.Before(&reference.CreationTimestamp)
+	}
+
+	// both disruptions were created during the same second, use their names to keep the order consistent
+	return disruption.Namespace+"/"+disruption.Name < reference.Namespace+"/"+reference.Name
+}
@@ -0,0 +1,189 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionQuotas", func() {
+	now := time.Now()
+
+	newDisruption := func(namespace, name string, createdAgo time.Duration, targets ...string) *Disruption {
+		count := intstr.FromInt(1)
+		disruption := &Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(now.Add(-createdAgo)),
+			},
+			Spec: DisruptionSpec{
+				Level:    chaostypes.DisruptionLevelPod,
+				Selector: map[string]string{"app": "demo"},
+				Count:    &count,
+				Network:  &NetworkDisruptionSpec{Drop: 100},
+			},
+			Status: DisruptionStatus{
+				InjectionStatus:  chaostypes.DisruptionInjectionStatusInjected,
+				TargetInjections: TargetInjections{},
+			},
+		}
+
+		for _, target := range targets {
+			disruption.Status.TargetInjections[target] = TargetInjection{InjectionStatus: chaostypes.DisruptionTargetInjectionStatusInjected}
+		}
+
+		return disruption
+	}
+
+	newPod := func(name string) *corev1.Pod {
+		controller := true
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "chaos-demo",
+				Labels:    map[string]string{"app": "demo", "pod-template-hash": "5d4f8"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "demo-5d4f8", Controller: &controller},
+				},
+			},
+		}
+	}
+
+	var (
+		quotas  DisruptionQuotas
+		objects []runtime.Object
+	)
+
+	BeforeEach(func() {
+		quotas = DisruptionQuotas{}
+		objects = []runtime.Object{}
+
+		for i := 0; i < 4; i++ {
+			objects = append(objects, newPod(fmt.Sprintf("demo-5d4f8-%d", i)))
+		}
+	})
+
+	check := func(r *Disruption, targets []string) ([]string, error) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(AddToScheme(scheme)).To(Succeed())
+
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+
+		return quotas.Check(context.Background(), k8sClient, r, targets)
+	}
+
+	It("should not check anything without quotas", func() {
+		objects = append(objects, newDisruption("chaos-demo", "running", time.Minute))
+
+		Expect(check(newDisruption("chaos-demo", "new", 0), nil)).To(BeEmpty())
+	})
+
+	DescribeTable("concurrency quotas",
+		func(setQuotas func(q *DisruptionQuotas), running []*Disruption, expectedReason string) {
+			setQuotas(&quotas)
+
+			for _, disruption := range running {
+				objects = append(objects, disruption)
+			}
+
+			// the disruption is being created, so it has no creation timestamp yet
+			disruption := newDisruption("chaos-demo", "new", 0)
+			disruption.CreationTimestamp = metav1.Time{}
+
+			reasons, err := check(disruption, nil)
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			if expectedReason == "" {
+				Expect(reasons).To(BeEmpty())
+			} else {
+				Expect(reasons).To(ContainElement(ContainSubstring(expectedReason)))
+			}
+		},
+		Entry("with room in the namespace",
+			func(q *DisruptionQuotas) { q.MaxDisruptionsPerNamespace = 2 },
+			[]*Disruption{newDisruption("chaos-demo", "running", time.Minute), newDisruption("other", "running", time.Minute)},
+			"",
+		),
+		Entry("with too many disruptions in the namespace",
+			func(q *DisruptionQuotas) { q.MaxDisruptionsPerNamespace = 1 },
+			[]*Disruption{newDisruption("chaos-demo", "running", time.Minute)},
+			"1 disruptions are already running in the chaos-demo namespace while the quota is 1",
+		),
+		Entry("with too many disruptions in the cluster",
+			func(q *DisruptionQuotas) { q.MaxDisruptionsPerCluster = 2 },
+			[]*Disruption{newDisruption("chaos-demo", "running", time.Minute), newDisruption("other", "running", time.Minute)},
+			"2 disruptions are already running in the cluster while the quota is 2",
+		),
+		Entry("with too many disruptions of the same kind",
+			func(q *DisruptionQuotas) { q.MaxDisruptionsPerKind = 1 },
+			[]*Disruption{newDisruption("other", "running", time.Minute)},
+			"1 network-disruption disruptions are already running in the cluster while the quota is 1",
+		),
+		Entry("without accounting for expired disruptions",
+			func(q *DisruptionQuotas) { q.MaxDisruptionsPerCluster = 1 },
+			[]*Disruption{func() *Disruption {
+				disruption := newDisruption("chaos-demo", "expired", time.Hour)
+				disruption.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusPreviouslyInjected
+
+				return disruption
+			}()},
+			"",
+		),
+	)
+
+	It("should only account for the disruptions created before the checked one", func() {
+		quotas.MaxDisruptionsPerNamespace = 1
+		objects = append(objects, newDisruption("chaos-demo", "younger", time.Second))
+
+		Expect(check(newDisruption("chaos-demo", "older", time.Minute), nil)).To(BeEmpty())
+	})
+
+	Describe("workload quota", func() {
+		BeforeEach(func() {
+			quotas.MaxWorkloadDisruptedPercentage = 50
+			objects = append(objects, newDisruption("chaos-demo", "running", time.Minute, "demo-5d4f8-0"))
+		})
+
+		It("should allow targets keeping the workload under the quota", func() {
+			Expect(check(newDisruption("chaos-demo", "new", 0), []string{"demo-5d4f8-1"})).To(BeEmpty())
+		})
+
+		It("should deny targets bringing the workload over the quota", func() {
+			reasons, err := check(newDisruption("chaos-demo", "new", 0), []string{"demo-5d4f8-1", "demo-5d4f8-2"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reasons).To(ConsistOf("75.00 % of the pods of the Deployment/demo workload would be disrupted at once while the quota is 50 %"))
+		})
+
+		It("should assume the worst case from the count when targets are not known yet", func() {
+			disruption := newDisruption("chaos-demo", "new", 0)
+			count := intstr.FromString("75%")
+			disruption.Spec.Count = &count
+
+			reasons, err := check(disruption, nil)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reasons).To(ConsistOf("100.00 % of the pods of the Deployment/demo workload would be disrupted at once while the quota is 50 %"))
+		})
+	})
+})
@@ -5,6 +5,9 @@
 package v1beta1
 
 import (
+	"fmt"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,13 +47,84 @@ type DisruptionRolloutSpec struct {
 	// +kubebuilder:validation:Required
 	// +ddmark:validation:Required=true
 	// TargetResource specifies the resource to run disruptions against.
-	// It can only be a deployment or statefulset.
+	// It can only be a deployment, statefulset or daemonset, as changes of other resources are not watched.
 	TargetResource TargetResourceSpec `json:"targetResource"`
 
 	// +kubebuilder:validation:Required
 	// +ddmark:validation:Required=true
 	// Specifies the Disruption that will be created when executing a disruptionrollout.
 	DisruptionTemplate DisruptionSpec `json:"disruptionTemplate"`
+
+	// Triggers specifies the changes of the target resource which trigger a disruption.
+	// Defaults to any change of the containers of the target resource.
+	// +nullable
+	Triggers *DisruptionRolloutTriggers `json:"triggers,omitempty"`
+
+	// WaitForRolloutComplete delays the disruption until the target resource reports its rollout as complete,
+	// so the disruption tests the new version only. The DelayedStartTolerance still applies while waiting.
+	WaitForRolloutComplete bool `json:"waitForRolloutComplete,omitempty"`
+}
+
+// DisruptionRolloutTriggers specifies the changes of the pod template of the target resource which trigger a disruption
+type DisruptionRolloutTriggers struct {
+	// ContainerChanges triggers a disruption on any change of the containers specs,
+	// it is the default trigger when no other trigger is specified
+	ContainerChanges bool `json:"containerChanges,omitempty"`
+	// ImageChanges triggers a disruption when the image of a container changes
+	ImageChanges bool `json:"imageChanges,omitempty"`
+	// EnvChanges triggers a disruption when the environment variables of a container change
+	EnvChanges bool `json:"envChanges,omitempty"`
+	// Containers restricts the containers triggers to the given containers and init containers (defaults to all of them)
+	// +nullable
+	Containers []string `json:"containers,omitempty"`
+	// AnnotationChanges triggers a disruption when one of the given pod template annotations changes
+	// +nullable
+	AnnotationChanges []string `json:"annotationChanges,omitempty"`
+}
+
+// GetContainerChanges returns true if any change of the containers specs triggers a disruption,
+// which is the case when it is explicitly set or when no other trigger is specified
+func (t *DisruptionRolloutTriggers) GetContainerChanges() bool {
+	if t == nil {
+		return true
+	}
+
+	return t.ContainerChanges || (!t.ImageChanges && !t.EnvChanges && len(t.AnnotationChanges) == 0)
+}
+
+// WatchesContainer returns true if the changes of the given container are considered by the triggers
+func (t *DisruptionRolloutTriggers) WatchesContainer(name string) bool {
+	if t == nil || len(t.Containers) == 0 {
+		return true
+	}
+
+	for _, container := range t.Containers {
+		if container == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DisruptionRolloutTargetResourceKinds are the kinds of target resources whose changes are watched to trigger a DisruptionRollout
+var DisruptionRolloutTargetResourceKinds = []string{"deployment", "statefulset", "daemonset"}
+
+// ValidateTargetResource ensures the changes of the target resource can be watched
+func (s DisruptionRolloutSpec) ValidateTargetResource() error {
+	if err := s.TargetResource.Validate(); err != nil {
+		return err
+	}
+
+	if !s.TargetResource.IsGeneric() {
+		for _, kind := range DisruptionRolloutTargetResourceKinds {
+			if s.TargetResource.Kind == kind {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("unsupported target resource kind %s for a DisruptionRollout, it must be one of %s", s.TargetResource.Kind, strings.Join(DisruptionRolloutTargetResourceKinds, ", "))
 }
 
 // DisruptionRolloutStatus defines the observed state of DisruptionRollout
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+)
+
+// TopologyMode is the way targets are distributed across the topology domains
+type TopologyMode string
+
+const (
+	// TopologyModeSpread picks targets evenly across the topology domains
+	TopologyModeSpread TopologyMode = "spread"
+	// TopologyModeConcentrate picks targets from as few topology domains as possible
+	TopologyModeConcentrate TopologyMode = "concentrate"
+)
+
+// SelectionStrategy alters how targets are picked among the matching ones, instead of picking them randomly
+// it may select less targets than the disruption count if not enough targets satisfy the strategy
+type SelectionStrategy struct {
+	// MaxPerOwner is the maximum number of pods targeted per owner (e.g. ReplicaSet, StatefulSet), unlimited if not set
+	// +kubebuilder:validation:Minimum=0
+	// +ddmark:validation:Minimum=0
+	MaxPerOwner int `json:"maxPerOwner,omitempty"`
+	// TopologyKey is the node label whose values are the topology domains to distribute targets across (e.g. topology.kubernetes.io/zone or kubernetes.io/hostname)
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// TopologyMode either spreads targets evenly across the topology domains or concentrates them in as few topology domains as possible, defaults to spread
+	// +kubebuilder:validation:Enum=spread;concentrate;""
+	// +ddmark:validation:Enum=spread;concentrate;""
+	TopologyMode TopologyMode `json:"topologyMode,omitempty"`
+	// RespectPodDisruptionBudgets skips pods whose PodDisruptionBudgets don't allow any more disruption
+	RespectPodDisruptionBudgets bool `json:"respectPodDisruptionBudgets,omitempty"`
+}
+
+// GetTopologyMode returns the topology mode, defaulting to spread
+func (s SelectionStrategy) GetTopologyMode() TopologyMode {
+	if s.TopologyMode == "" {
+		return TopologyModeSpread
+	}
+
+	return s.TopologyMode
+}
+
+// Validate validates the selection strategy for a disruption of the given level
+func (s SelectionStrategy) Validate(level chaostypes.DisruptionLevel) (retErr error) {
+	if s.MaxPerOwner < 0 {
+		retErr = multierror.Append(retErr, errors.New("selectionStrategy.maxPerOwner must be positive"))
+	}
+
+	if s.TopologyMode != "" && s.TopologyMode != TopologyModeSpread && s.TopologyMode != TopologyModeConcentrate {
+		retErr = multierror.Append(retErr, fmt.Errorf("selectionStrategy.topologyMode must be either %s or %s", TopologyModeSpread, TopologyModeConcentrate))
+	}
+
+	if s.TopologyMode != "" && s.TopologyKey == "" {
+		retErr = multierror.Append(retErr, errors.New("selectionStrategy.topologyMode requires a topologyKey"))
+	}
+
+	if level == chaostypes.DisruptionLevelNode && (s.MaxPerOwner > 0 || s.RespectPodDisruptionBudgets) {
+		retErr = multierror.Append(retErr, errors.New("selectionStrategy.maxPerOwner and selectionStrategy.respectPodDisruptionBudgets can only be used to target pods"))
+	}
+
+	return retErr
+}
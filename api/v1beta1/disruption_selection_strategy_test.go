@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SelectionStrategy", func() {
+	Describe("Validate", func() {
+		DescribeTable("with valid selection strategies",
+			func(strategy SelectionStrategy, level chaostypes.DisruptionLevel) {
+				Expect(strategy.Validate(level)).Should(Succeed())
+			},
+			Entry("with owners spreading and pod disruption budgets",
+				SelectionStrategy{MaxPerOwner: 1, RespectPodDisruptionBudgets: true}, chaostypes.DisruptionLevelPod,
+			),
+			Entry("with a topology key only",
+				SelectionStrategy{TopologyKey: "topology.kubernetes.io/zone"}, chaostypes.DisruptionLevelNode,
+			),
+			Entry("with a concentrated topology",
+				SelectionStrategy{TopologyKey: "kubernetes.io/hostname", TopologyMode: TopologyModeConcentrate}, chaostypes.DisruptionLevelPod,
+			),
+		)
+
+		DescribeTable("with invalid selection strategies",
+			func(strategy SelectionStrategy, level chaostypes.DisruptionLevel, expectedErrorMessage string) {
+				err := strategy.Validate(level)
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("with a negative maxPerOwner",
+				SelectionStrategy{MaxPerOwner: -1}, chaostypes.DisruptionLevelPod,
+				"selectionStrategy.maxPerOwner must be positive",
+			),
+			Entry("with an unknown topology mode",
+				SelectionStrategy{TopologyKey: "zone", TopologyMode: "random"}, chaostypes.DisruptionLevelPod,
+				"selectionStrategy.topologyMode must be either spread or concentrate",
+			),
+			Entry("with a topology mode without topology key",
+				SelectionStrategy{TopologyMode: TopologyModeSpread}, chaostypes.DisruptionLevelPod,
+				"selectionStrategy.topologyMode requires a topologyKey",
+			),
+			Entry("with pod disruption budgets on a node level disruption",
+				SelectionStrategy{RespectPodDisruptionBudgets: true}, chaostypes.DisruptionLevelNode,
+				"can only be used to target pods",
+			),
+		)
+	})
+})
@@ -41,6 +41,11 @@ func (r *Disruption) SpanContext(ctx context.Context) (context.Context, error) {
 	return ctx, nil
 }
 
+// MarshaledSpanContext returns this disruption's span context as stored in its annotations, or an empty string if it has none
+func (r *Disruption) MarshaledSpanContext() string {
+	return r.Annotations[annotationSpanContextKey]
+}
+
 // SetSpanContext store provided spanContext into expected disruption annotation
 func (r *Disruption) SetSpanContext(ctx context.Context) error {
 	var annotation = make(propagation.MapCarrier)
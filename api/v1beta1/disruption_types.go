@@ -85,6 +85,13 @@ type DisruptionSpec struct {
 	GRPC *GRPCDisruptionSpec `json:"grpc,omitempty"`
 	// +nullable
 	Reporting *Reporting `json:"reporting,omitempty"`
+	// +nullable
+	Guardrails *Guardrails `json:"guardrails,omitempty"` // steady-state checks cleaning the disruption early when breached
+	// +nullable
+	Progression *Progression `json:"progression,omitempty"` // escalate the number of targets stepwise
+	Paused      bool         `json:"paused,omitempty"`      // clean the injections on all targets until unpaused, without deleting the disruption
+	// +nullable
+	SelectionStrategy *SelectionStrategy `json:"selectionStrategy,omitempty"` // pick targets across owners, topology domains and pod disruption budgets instead of randomly
 }
 
 // DisruptionTriggers holds the options for changing when injector pods are created, and the timing of when the injection occurs
@@ -229,6 +236,15 @@ type DisruptionStatus struct {
 	InjectedTargetsCount int `json:"injectedTargetsCount"`
 	// Number of targets we want to target (count)
 	DesiredTargetsCount int `json:"desiredTargetsCount"`
+	// Result of the last evaluation of the guardrails
+	// +nullable
+	Guardrails *GuardrailsStatus `json:"guardrails,omitempty"`
+	// Current step of the progression
+	// +nullable
+	Progression *ProgressionStatus `json:"progression,omitempty"`
+	// Health signals observed on the targets during the disruption
+	// +nullable
+	Health *TargetsHealth `json:"health,omitempty"`
 }
 
 type DisruptionFilter struct {
@@ -444,6 +460,11 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 		}
 	}
 
+	// Rule: pause compatibility, those disruptions can't be injected again once cleaned
+	if s.Paused && (s.NodeFailure != nil || s.ContainerFailure != nil) {
+		retErr = multierror.Append(retErr, errors.New("paused is only compatible with network, cpu pressure, disk pressure, disk failure, dns and grpc disruptions"))
+	}
+
 	if s.GRPC != nil && s.Level != chaostypes.DisruptionLevelPod {
 		retErr = multierror.Append(retErr, errors.New("GRPC disruptions can only be applied at the pod level"))
 	}
@@ -453,6 +474,31 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 		retErr = multierror.Append(retErr, err)
 	}
 
+	// Rule: guardrails must be valid
+	if s.Guardrails != nil {
+		if err := s.Guardrails.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
+	// Rule: progression must be valid and requires dynamic targeting to add targets along the way
+	if s.Progression != nil {
+		if err := s.Progression.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+
+		if s.StaticTargeting {
+			retErr = multierror.Append(retErr, errors.New("progression cannot be used along with staticTargeting, as targets can't be added once selected"))
+		}
+	}
+
+	// Rule: selection strategy must be valid for the disruption level
+	if s.SelectionStrategy != nil {
+		if err := s.SelectionStrategy.Validate(s.Level); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
 	return retErr
 }
 
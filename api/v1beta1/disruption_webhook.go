@@ -51,6 +51,9 @@ var (
 	chaosNamespace                string
 	ddmarkClient                  ddmark.Client
 	safemodeEnvironment           string
+	blackoutEnabled               bool
+	quotas                        DisruptionQuotas
+	guardrailsAllowedHosts        []string
 )
 
 const SafemodeEnvironmentAnnotation = GroupName + "/environment"
@@ -78,6 +81,14 @@ func (r *Disruption) SetupWebhookWithManager(setupWebhookConfig utils.SetupWebho
 	cloudServicesProvidersManager = setupWebhookConfig.CloudServicesProvidersManager
 	chaosNamespace = setupWebhookConfig.ChaosNamespace
 	safemodeEnvironment = setupWebhookConfig.Environment
+	blackoutEnabled = setupWebhookConfig.DisruptionBlackoutEnabled
+	quotas = DisruptionQuotas{
+		MaxDisruptionsPerNamespace:     setupWebhookConfig.MaxDisruptionsPerNamespaceFlag,
+		MaxDisruptionsPerCluster:       setupWebhookConfig.MaxDisruptionsPerClusterFlag,
+		MaxDisruptionsPerKind:          setupWebhookConfig.MaxDisruptionsPerKindFlag,
+		MaxWorkloadDisruptedPercentage: setupWebhookConfig.MaxWorkloadDisruptedPercentageFlag,
+	}
+	guardrailsAllowedHosts = setupWebhookConfig.GuardrailsAllowedHosts
 
 	return ctrl.NewWebhookManagedBy(setupWebhookConfig.Manager).
 		For(r).
@@ -118,6 +129,18 @@ func (r *Disruption) ValidateCreate() error {
 		return errors.New("the controller is currently in delete-only mode, you can't create new disruptions for now")
 	}
 
+	// reject disruptions created during an active blackout window of their namespace
+	if blackoutEnabled {
+		blackout, err := GetActiveBlackout(context.Background(), k8sClient, logger, recorder, r.Namespace, time.Now())
+		if err != nil {
+			return fmt.Errorf("error checking disruption blackouts: %w", err)
+		}
+
+		if blackout != nil {
+			return fmt.Errorf("disruptions are not allowed in namespace %s for now due to the blackout %s: %s", r.Namespace, blackout.Name, blackout.Spec.Reason)
+		}
+	}
+
 	// reject disruptions with a name which would not be a valid label value
 	// according to https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
 	if _, err := labels.Parse(fmt.Sprintf("name=%s", r.Name)); err != nil {
@@ -144,7 +167,19 @@ func (r *Disruption) ValidateCreate() error {
 		// this is the minimum estimated number of tc filters we could have for the disruption
 		// knowing a service is filtered by both its service IP and the pod(s) IP where the service is
 		// we don't count the number of Pods hosting the service here because this could be changing
-		estimatedTcFiltersNb := len(r.Spec.Network.Hosts) + (len(r.Spec.Network.Services) * 2)
+		// the same goes for pods destinations, which are counted as a single filter at least
+		estimatedTcFiltersNb := (len(r.Spec.Network.Services) * 2) + len(r.Spec.Network.Pods)
+
+		// ip ranges without connection state can be filtered by the eBPF LPM classifier when there are too many of them
+		estimatedLPMEntriesNb := 0
+
+		for _, host := range r.Spec.Network.Hosts {
+			if host.IsLPMFilterable() {
+				estimatedLPMEntriesNb++
+			} else {
+				estimatedTcFiltersNb++
+			}
+		}
 
 		if r.Spec.Network.Cloud != nil {
 			clouds := r.Spec.Network.Cloud.TransformToCloudMap()
@@ -161,15 +196,28 @@ func (r *Disruption) ValidateCreate() error {
 					return err
 				}
 
-				for _, ipRanges := range ipRangesPerService {
-					estimatedTcFiltersNb += len(ipRanges)
+				for _, service := range serviceList {
+					if service.ConnState == "" {
+						estimatedLPMEntriesNb += len(ipRangesPerService[service.ServiceName])
+					} else {
+						estimatedTcFiltersNb += len(ipRangesPerService[service.ServiceName])
+					}
 				}
 			}
 		}
 
+		if estimatedLPMEntriesNb <= LPMFilterThreshold {
+			estimatedTcFiltersNb += estimatedLPMEntriesNb
+			estimatedLPMEntriesNb = 0
+		}
+
 		if estimatedTcFiltersNb > MaximumTCFilters {
 			return fmt.Errorf("the number of resources (ips, ip ranges, single port) to filter is too high (%d). Please remove some hosts, services or cloud managed services to be affected in the disruption. Maximum resources (ips, ip ranges, single port) filterable is %d", estimatedTcFiltersNb, MaximumTCFilters)
 		}
+
+		if estimatedLPMEntriesNb > MaximumLPMFilterEntries {
+			return fmt.Errorf("the number of ip ranges to filter is too high (%d). Please remove some hosts or cloud managed services to be affected in the disruption. Maximum ip ranges filterable is %d", estimatedLPMEntriesNb, MaximumLPMFilterEntries)
+		}
 	}
 
 	if err := r.Spec.Validate(); err != nil {
@@ -180,6 +228,17 @@ func (r *Disruption) ValidateCreate() error {
 		return err
 	}
 
+	// reject guardrails http checks targeting hosts which are not allowed by the controller
+	if r.Spec.Guardrails != nil {
+		if err := r.Spec.Guardrails.ValidateHosts(guardrailsAllowedHosts); err != nil {
+			if mErr := metricsSink.MetricValidationFailed(r.getMetricsTags()); mErr != nil {
+				logger.Errorw("error sending a metric", "error", mErr)
+			}
+
+			return err
+		}
+	}
+
 	multiErr := ddmarkClient.ValidateStructMultierror(r.Spec, "validation_webhook")
 	if multiErr.ErrorOrNil() != nil {
 		return multierror.Prefix(multiErr, "ddmark: ")
@@ -249,25 +308,29 @@ You first need to remove those chaos pods (and potentially their finalizers) to
 	}
 
 	// compare old and new disruption hashes and deny any spec changes
+	// pausing and resuming a disruption is always allowed
 	var oldHash, newHash string
 
+	oldSpec, newSpec := oldDisruption.Spec, r.Spec
+	oldSpec.Paused, newSpec.Paused = false, false
+
 	if oldDisruption.Spec.StaticTargeting {
-		oldHash, err = oldDisruption.Spec.Hash()
+		oldHash, err = oldSpec.Hash()
 		if err != nil {
 			return fmt.Errorf("error getting old disruption hash: %w", err)
 		}
 
-		newHash, err = r.Spec.Hash()
+		newHash, err = newSpec.Hash()
 
 		if err != nil {
 			return fmt.Errorf("error getting new disruption hash: %w", err)
 		}
 	} else {
-		oldHash, err = oldDisruption.Spec.HashNoCount()
+		oldHash, err = oldSpec.HashNoCount()
 		if err != nil {
 			return fmt.Errorf("error getting old disruption hash: %w", err)
 		}
-		newHash, err = r.Spec.HashNoCount()
+		newHash, err = newSpec.HashNoCount()
 		if err != nil {
 			return fmt.Errorf("error getting new disruption hash: %w", err)
 		}
@@ -279,10 +342,10 @@ You first need to remove those chaos pods (and potentially their finalizers) to
 		logger.Errorw("error when comparing disruption spec hashes", "oldHash", oldHash, "newHash", newHash)
 
 		if oldDisruption.Spec.StaticTargeting {
-			return fmt.Errorf("[StaticTargeting: true] a disruption spec cannot be updated, please delete and recreate it if needed")
+			return fmt.Errorf("[StaticTargeting: true] only a disruption spec's Paused field can be updated, please delete and recreate it if needed")
 		}
 
-		return fmt.Errorf("[StaticTargeting: false] only a disruption spec's Count field can be updated, please delete and recreate it if needed")
+		return fmt.Errorf("[StaticTargeting: false] only a disruption spec's Count and Paused fields can be updated, please delete and recreate it if needed")
 	}
 
 	if err := r.Spec.Validate(); err != nil {
@@ -390,6 +453,14 @@ func (r *Disruption) initialSafetyNets() ([]string, error) {
 			responses = append(responses, response)
 		}
 
+		if caught, response, err := safetyNetQuotas(r); err != nil {
+			return nil, fmt.Errorf("error checking for quotas safetynet: %w", err)
+		} else if caught {
+			logger.Debugw("the disruption exceeds the concurrent disruptions quotas", "SafetyNet Catch", "Generic")
+
+			responses = append(responses, response)
+		}
+
 		if r.Spec.Network != nil {
 			if caught := safetyNetNeitherHostNorPort(*r); caught {
 				logger.Debugw("the specified disruption either contains no Hosts or contains a Host which has neither a port nor a host. The more ambiguous, the larger the blast radius.", "SafetyNet Catch", "Network")
@@ -421,6 +492,11 @@ func safetyNetCountNotTooLarge(r *Disruption) (bool, string, error) {
 	}
 
 	userCount := r.Spec.Count
+	// a progressive disruption eventually escalates to its last step count
+	if r.Spec.Progression != nil {
+		userCount = r.Spec.Progression.GetCount(r.Spec.Count, len(r.Spec.Progression.Steps))
+	}
+
 	totalCount := 0
 	namespaceCount := 0
 	targetCount := 0
@@ -557,6 +633,23 @@ func safetyNetCountNotTooLarge(r *Disruption) (bool, string, error) {
 	return false, "", nil
 }
 
+// safetyNetQuotas is the safety net regarding the disruptions running concurrently
+// it will check the number of disruptions already running in the namespace, in the cluster and with the same kinds,
+// and the percentage of the pods of the targeted workloads which would be disrupted at once across all disruptions
+// returning true indicates the safety net caught something
+func safetyNetQuotas(r *Disruption) (bool, string, error) {
+	reasons, err := quotas.Check(context.Background(), k8sClient, r, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	if len(reasons) == 0 {
+		return false, "", nil
+	}
+
+	return true, "the disruption exceeds the concurrent disruptions quotas: " + strings.Join(reasons, ", "), nil
+}
+
 // safetyNetNeitherHostNorPort is the safety net regarding missing host and port values.
 // it will check against all defined hosts in the network disruption spec to see if any of them have a host and a
 // port missing. The more generic a hosts tuple is (Omitting fields such as port), the bigger the blast radius.
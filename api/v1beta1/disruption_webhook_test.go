@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -192,6 +193,27 @@ var _ = Describe("Disruption", func() {
 				})
 			})
 
+			When("paused is updated", func() {
+				BeforeEach(func() {
+					newDisruption.Spec.Paused = true
+				})
+
+				Context("DynamicTargeting (StaticTargeting=false)", func() {
+					It("should succeed", func() {
+						Expect(newDisruption.ValidateUpdate(oldDisruption)).Should(Succeed())
+					})
+				})
+
+				Context("StaticTargeting", func() {
+					It("should succeed", func() {
+						oldDisruption.Spec.StaticTargeting = true
+						newDisruption.Spec.StaticTargeting = true
+
+						Expect(newDisruption.ValidateUpdate(oldDisruption)).Should(Succeed())
+					})
+				})
+			})
+
 			When("StaticTargeting is updated", func() {
 				When("static to dynamic", func() {
 					BeforeEach(func() {
@@ -297,6 +319,28 @@ var _ = Describe("Disruption", func() {
 				})
 			})
 
+			When("disruption guardrails http checks target a host which is not allowed", func() {
+				It("should return an error", func() {
+					// Arrange
+					guardrailsAllowedHosts = []string{"demo.chaos-demo.svc.cluster.local"}
+					DeferCleanup(func() {
+						guardrailsAllowedHosts = nil
+					})
+
+					newDisruption.Spec.Guardrails = &Guardrails{
+						Checks: []GuardrailCheck{{Name: "probe", HTTP: &GuardrailHTTPCheck{URL: "http://169.254.169.254/latest/meta-data/"}}},
+					}
+
+					// Action
+					err := newDisruption.ValidateCreate()
+
+					// Assert
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).Should(ContainSubstring("guardrail check probe: url host 169.254.169.254 is not allowed by the controller"))
+					Expect(ddmarkMock.AssertNumberOfCalls(GinkgoT(), "ValidateStructMultierror", 0)).To(BeTrue())
+				})
+			})
+
 			When("disruption selectors are invalid", func() {
 				It("should return an error", func() {
 					invalidDisruption := newDisruption.DeepCopy()
@@ -463,6 +507,52 @@ var _ = Describe("Disruption", func() {
 				})
 			})
 		})
+
+		Describe("expectations with concurrency quotas", func() {
+			BeforeEach(func() {
+				ddmarkMock.EXPECT().ValidateStructMultierror(mock.Anything, mock.Anything).Return(&multierror.Error{})
+				recorder = record.NewFakeRecorder(1)
+				metricsSink = metricsnoop.New(logger)
+				tracerSink = tracernoop.New(logger)
+				deleteOnly = false
+				enableSafemode = true
+				quotas = DisruptionQuotas{MaxDisruptionsPerNamespace: 1}
+
+				running := makeValidDiskFailureDisruption()
+				running.Name = "running-disruption"
+
+				s := runtime.NewScheme()
+				Expect(scheme.AddToScheme(s)).To(Succeed())
+				Expect(AddToScheme(s)).To(Succeed())
+
+				k8sClient = fake.NewClientBuilder().WithScheme(s).WithObjects(running).Build()
+			})
+
+			JustBeforeEach(func() {
+				newDisruption = makeValidDiskFailureDisruption()
+				newDisruption.Spec.Level = chaostypes.DisruptionLevelPod
+			})
+
+			AfterEach(func() {
+				k8sClient = nil
+				newDisruption = nil
+				quotas = DisruptionQuotas{}
+			})
+
+			It("should deny a disruption exceeding the namespace quota", func() {
+				err := newDisruption.ValidateCreate()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("at least one of the initial safety nets caught an issue"))
+				Expect(err.Error()).Should(ContainSubstring("1 disruptions are already running in the " + chaosNamespace + " namespace while the quota is 1"))
+			})
+
+			It("should allow a disruption exceeding the quota when all the safety nets are disabled", func() {
+				newDisruption.Spec.Unsafemode = &UnsafemodeSpec{DisableAll: true}
+
+				Expect(newDisruption.ValidateCreate()).To(Succeed())
+			})
+		})
 	})
 })
 
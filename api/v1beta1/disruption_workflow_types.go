@@ -0,0 +1,305 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&DisruptionWorkflow{}, &DisruptionWorkflowList{})
+}
+
+// DisruptionWorkflowStepCondition is the condition the dependencies of a step must meet for the step to start
+type DisruptionWorkflowStepCondition string
+
+const (
+	// DisruptionWorkflowStepConditionCompleted starts the step once its dependencies are over, whatever their injection status
+	DisruptionWorkflowStepConditionCompleted DisruptionWorkflowStepCondition = "Completed"
+	// DisruptionWorkflowStepConditionInjected starts the step as soon as its dependencies injected all their targets, while they are still ongoing
+	DisruptionWorkflowStepConditionInjected DisruptionWorkflowStepCondition = "Injected"
+	// DisruptionWorkflowStepConditionCompletedAndInjected starts the step once its dependencies are over and only if they injected all their targets
+	DisruptionWorkflowStepConditionCompletedAndInjected DisruptionWorkflowStepCondition = "CompletedAndInjected"
+)
+
+// DisruptionWorkflowStepPhase is the phase of a workflow step
+type DisruptionWorkflowStepPhase string
+
+const (
+	// DisruptionWorkflowStepPhasePending means the step is waiting for its dependencies
+	DisruptionWorkflowStepPhasePending DisruptionWorkflowStepPhase = "Pending"
+	// DisruptionWorkflowStepPhaseWaiting means the step dependencies are met and the step is waiting for its wait duration to elapse
+	DisruptionWorkflowStepPhaseWaiting DisruptionWorkflowStepPhase = "Waiting"
+	// DisruptionWorkflowStepPhaseRunning means the step disruption has been created and is ongoing
+	DisruptionWorkflowStepPhaseRunning DisruptionWorkflowStepPhase = "Running"
+	// DisruptionWorkflowStepPhaseCompleted means the step disruption is over
+	DisruptionWorkflowStepPhaseCompleted DisruptionWorkflowStepPhase = "Completed"
+	// DisruptionWorkflowStepPhaseSkipped means the step dependencies did not meet the step condition
+	DisruptionWorkflowStepPhaseSkipped DisruptionWorkflowStepPhase = "Skipped"
+	// DisruptionWorkflowStepPhaseFailed means the step disruption could not be created
+	DisruptionWorkflowStepPhaseFailed DisruptionWorkflowStepPhase = "Failed"
+)
+
+// IsFinished returns true if the step won't evolve anymore
+func (p DisruptionWorkflowStepPhase) IsFinished() bool {
+	switch p {
+	case DisruptionWorkflowStepPhaseCompleted,
+		DisruptionWorkflowStepPhaseSkipped,
+		DisruptionWorkflowStepPhaseFailed:
+		return true
+	}
+
+	return false
+}
+
+// DisruptionWorkflowPhase is the phase of a workflow
+type DisruptionWorkflowPhase string
+
+const (
+	DisruptionWorkflowPhaseRunning   DisruptionWorkflowPhase = "Running"
+	DisruptionWorkflowPhaseCompleted DisruptionWorkflowPhase = "Completed"
+	DisruptionWorkflowPhaseFailed    DisruptionWorkflowPhase = "Failed"
+)
+
+//+kubebuilder:object:root=true
+
+// DisruptionWorkflow is the Schema for the disruptionworkflow API
+// +kubebuilder:resource:shortName=diwf
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type DisruptionWorkflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DisruptionWorkflowSpec   `json:"spec,omitempty"`
+	Status            DisruptionWorkflowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DisruptionWorkflowList contains a list of DisruptionWorkflow
+type DisruptionWorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisruptionWorkflow `json:"items"`
+}
+
+// DisruptionWorkflowSpec defines the desired state of DisruptionWorkflow
+type DisruptionWorkflowSpec struct {
+	// TargetResource specifies the resource to run the steps disruptions against, unless overridden by a step.
+	// If not set, the steps disruptions selectors are used as is.
+	// +nullable
+	TargetResource *TargetResourceSpec `json:"targetResource,omitempty"`
+
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	// Steps of the workflow, steps without dependencies start right away and in parallel
+	Steps []DisruptionWorkflowStep `json:"steps"`
+}
+
+// DisruptionWorkflowStep is a single disruption of a workflow
+type DisruptionWorkflowStep struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +ddmark:validation:Required=true
+	// Name uniquely identifies the step in the workflow, it is appended to the created disruption name
+	Name string `json:"name"`
+
+	// DependsOn lists the names of the steps that must meet the condition before this step starts
+	// +nullable
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Condition the dependencies must meet for the step to start (defaults to Completed):
+	// - Completed: the dependencies disruptions are over, whatever their injection status
+	// - Injected: the dependencies disruptions injected all their targets, the step starts while they are still ongoing
+	// - CompletedAndInjected: the dependencies disruptions are over and injected all their targets
+	// The step is skipped if its dependencies can't meet the condition anymore
+	// +kubebuilder:validation:Enum=Completed;Injected;CompletedAndInjected;""
+	// +ddmark:validation:Enum=Completed;Injected;CompletedAndInjected;""
+	Condition DisruptionWorkflowStepCondition `json:"condition,omitempty"`
+
+	// Wait is the time to wait once the condition is met before starting the step
+	// +nullable
+	Wait DisruptionDuration `json:"wait,omitempty"`
+
+	// Duration overrides the duration of the step disruption
+	// +nullable
+	Duration DisruptionDuration `json:"duration,omitempty"`
+
+	// TargetResource overrides the workflow target resource for this step
+	// +nullable
+	TargetResource *TargetResourceSpec `json:"targetResource,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	// Specifies the Disruption that will be created when executing the step.
+	DisruptionTemplate DisruptionSpec `json:"disruptionTemplate"`
+}
+
+// DisruptionWorkflowStatus defines the observed state of DisruptionWorkflow
+type DisruptionWorkflowStatus struct {
+	// +kubebuilder:validation:Enum=Running;Completed;Failed;""
+	Phase DisruptionWorkflowPhase `json:"phase,omitempty"`
+	// Message explains why the workflow failed, if so
+	Message string `json:"message,omitempty"`
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// +nullable
+	Steps []DisruptionWorkflowStepStatus `json:"steps,omitempty"`
+}
+
+// DisruptionWorkflowStepStatus defines the observed state of a workflow step
+type DisruptionWorkflowStepStatus struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Pending;Waiting;Running;Completed;Skipped;Failed
+	Phase DisruptionWorkflowStepPhase `json:"phase"`
+	// Message explains why the step has been skipped or failed, if so
+	Message string `json:"message,omitempty"`
+	// DisruptionName is the name of the disruption created for the step
+	DisruptionName string `json:"disruptionName,omitempty"`
+	// InjectionStatus is the last observed injection status of the step disruption
+	InjectionStatus chaostypes.DisruptionInjectionStatus `json:"injectionStatus,omitempty"`
+	// FullyInjected is true once the step disruption injected all its targets
+	FullyInjected bool `json:"fullyInjected,omitempty"`
+	// Time when the step condition was met
+	// +nullable
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// GetCondition returns the condition of the step, defaulting to Completed
+func (s DisruptionWorkflowStep) GetCondition() DisruptionWorkflowStepCondition {
+	if s.Condition == "" {
+		return DisruptionWorkflowStepConditionCompleted
+	}
+
+	return s.Condition
+}
+
+// GetTargetResource returns the target resource of the step, defaulting to the workflow one
+func (s DisruptionWorkflowSpec) GetTargetResource(step DisruptionWorkflowStep) *TargetResourceSpec {
+	if step.TargetResource != nil {
+		return step.TargetResource
+	}
+
+	return s.TargetResource
+}
+
+// Validate ensures the steps names are unique and the steps dependencies form an acyclic graph
+func (s DisruptionWorkflowSpec) Validate() (retErr error) {
+	if len(s.Steps) == 0 {
+		retErr = multierror.Append(retErr, errors.New("a workflow must have at least one step"))
+	}
+
+	if s.TargetResource != nil {
+		if err := s.TargetResource.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
+	steps := map[string]DisruptionWorkflowStep{}
+
+	for _, step := range s.Steps {
+		if step.Name == "" {
+			retErr = multierror.Append(retErr, errors.New("workflow steps must have a name"))
+		}
+
+		if _, ok := steps[step.Name]; ok {
+			retErr = multierror.Append(retErr, fmt.Errorf("workflow step names must be unique, %s is defined several times", step.Name))
+		}
+
+		steps[step.Name] = step
+
+		if step.TargetResource != nil {
+			if err := step.TargetResource.Validate(); err != nil {
+				retErr = multierror.Append(retErr, fmt.Errorf("workflow step %s: %w", step.Name, err))
+			}
+		}
+
+		switch step.GetCondition() {
+		case DisruptionWorkflowStepConditionCompleted, DisruptionWorkflowStepConditionInjected, DisruptionWorkflowStepConditionCompletedAndInjected:
+		default:
+			retErr = multierror.Append(retErr, fmt.Errorf("workflow step %s has an unknown condition %s", step.Name, step.Condition))
+		}
+	}
+
+	for _, step := range s.Steps {
+		for _, dependency := range step.DependsOn {
+			if _, ok := steps[dependency]; !ok {
+				retErr = multierror.Append(retErr, fmt.Errorf("workflow step %s depends on unknown step %s", step.Name, dependency))
+			}
+		}
+	}
+
+	if retErr != nil {
+		return retErr
+	}
+
+	// detect cycles with a depth-first search, a step being visited while it is already on the stack means a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := map[string]int{}
+
+	var visit func(name string) error
+
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("workflow steps dependencies must not form a cycle, step %s depends on itself", name)
+		case visited:
+			return nil
+		}
+
+		states[name] = visiting
+
+		for _, dependency := range steps[name].DependsOn {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		states[name] = visited
+
+		return nil
+	}
+
+	for _, step := range s.Steps {
+		if states[step.Name] == unvisited {
+			if err := visit(step.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetStepStatus returns the status of the given step, or nil if it doesn't exist
+func (s *DisruptionWorkflowStatus) GetStepStatus(name string) *DisruptionWorkflowStepStatus {
+	for i := range s.Steps {
+		if s.Steps[i].Name == name {
+			return &s.Steps[i]
+		}
+	}
+
+	return nil
+}
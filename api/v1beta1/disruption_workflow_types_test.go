@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DisruptionWorkflowSpec", func() {
+	Describe("Validate", func() {
+		DescribeTable("with valid workflows",
+			func(steps []DisruptionWorkflowStep) {
+				// Arrange
+				spec := DisruptionWorkflowSpec{Steps: steps}
+
+				// Action
+				err := spec.Validate()
+
+				// Assert
+				Expect(err).ShouldNot(HaveOccurred())
+			},
+			Entry("with a single step",
+				[]DisruptionWorkflowStep{{Name: "drop"}},
+			),
+			Entry("with parallel steps joining into a last one",
+				[]DisruptionWorkflowStep{
+					{Name: "drop"},
+					{Name: "cpu"},
+					{Name: "delay", DependsOn: []string{"drop", "cpu"}, Condition: DisruptionWorkflowStepConditionCompletedAndInjected},
+				},
+			),
+		)
+
+		DescribeTable("with invalid workflows",
+			func(steps []DisruptionWorkflowStep, expectedErrorMessage string) {
+				// Arrange
+				spec := DisruptionWorkflowSpec{Steps: steps}
+
+				// Action
+				err := spec.Validate()
+
+				// Assert
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+			},
+			Entry("without steps",
+				[]DisruptionWorkflowStep{},
+				"a workflow must have at least one step",
+			),
+			Entry("with duplicated step names",
+				[]DisruptionWorkflowStep{{Name: "drop"}, {Name: "drop"}},
+				"drop is defined several times",
+			),
+			Entry("with an unknown dependency",
+				[]DisruptionWorkflowStep{{Name: "drop", DependsOn: []string{"cpu"}}},
+				"workflow step drop depends on unknown step cpu",
+			),
+			Entry("with an unknown condition",
+				[]DisruptionWorkflowStep{{Name: "drop", Condition: "Started"}},
+				"workflow step drop has an unknown condition Started",
+			),
+			Entry("with a cycle",
+				[]DisruptionWorkflowStep{
+					{Name: "drop", DependsOn: []string{"delay"}},
+					{Name: "cpu", DependsOn: []string{"drop"}},
+					{Name: "delay", DependsOn: []string{"cpu"}},
+				},
+				"workflow steps dependencies must not form a cycle",
+			),
+			Entry("with a step depending on itself",
+				[]DisruptionWorkflowStep{{Name: "drop", DependsOn: []string{"drop"}}},
+				"workflow steps dependencies must not form a cycle",
+			),
+		)
+	})
+
+	Describe("GetTargetResource", func() {
+		workflowTarget := &TargetResourceSpec{Kind: "deployment", Name: "demo"}
+		stepTarget := &TargetResourceSpec{Kind: "statefulset", Name: "db"}
+		spec := DisruptionWorkflowSpec{TargetResource: workflowTarget}
+
+		It("should return the workflow target resource by default", func() {
+			Expect(spec.GetTargetResource(DisruptionWorkflowStep{})).To(Equal(workflowTarget))
+		})
+
+		It("should return the step target resource if specified", func() {
+			Expect(spec.GetTargetResource(DisruptionWorkflowStep{TargetResource: stepTarget})).To(Equal(stepTarget))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the DisruptionWorkflow validating webhook
+func (w *DisruptionWorkflow) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(w).
+		Complete()
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-disruptionworkflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=disruptionworkflows,verbs=create;update,versions=v1beta1,name=vdisruptionworkflow.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DisruptionWorkflow{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+// invalid workflows are rejected on creation instead of being marked as failed by the controller
+func (w *DisruptionWorkflow) ValidateCreate() error {
+	return w.Spec.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (w *DisruptionWorkflow) ValidateUpdate(old runtime.Object) error {
+	return w.Spec.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (w *DisruptionWorkflow) ValidateDelete() error {
+	return nil
+}
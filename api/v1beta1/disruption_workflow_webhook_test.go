@@ -0,0 +1,36 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DisruptionWorkflow webhook", func() {
+	It("should accept a valid workflow", func() {
+		workflow := &DisruptionWorkflow{
+			Spec: DisruptionWorkflowSpec{
+				Steps: []DisruptionWorkflowStep{{Name: "drop"}, {Name: "delay", DependsOn: []string{"drop"}}},
+			},
+		}
+
+		Expect(workflow.ValidateCreate()).To(Succeed())
+		Expect(workflow.ValidateUpdate(workflow)).To(Succeed())
+	})
+
+	It("should reject an invalid workflow", func() {
+		workflow := &DisruptionWorkflow{
+			Spec: DisruptionWorkflowSpec{
+				Steps: []DisruptionWorkflowStep{{Name: "drop", DependsOn: []string{"delay"}}},
+			},
+		}
+
+		Expect(workflow.ValidateCreate()).To(MatchError(ContainSubstring("depends on unknown step delay")))
+		Expect(workflow.ValidateUpdate(workflow)).ToNot(Succeed())
+	})
+})
@@ -10,8 +10,10 @@ import (
 )
 
 const (
-	EventOnTargetTemplate     string = "Failing probably caused by disruption %s: "
-	SourceDisruptionComponent string = "disruption-controller"
+	EventOnTargetTemplate            string = "Failing probably caused by disruption %s: "
+	SourceDisruptionComponent        string = "disruption-controller"
+	SourceDisruptionCronComponent    string = "disruption-cron-controller"
+	SourceDisruptionRolloutComponent string = "disruption-rollout-controller"
 )
 
 type DisruptionEventCategory string
@@ -23,6 +25,10 @@ const (
 	DisruptEvent DisruptionEventCategory = "DisruptionEvent"
 	// Event only attached to a chaos pod
 	ChaosPodEvent DisruptionEventCategory = "ChaosPodEvent"
+	// Event only attached to a DisruptionCron or a DisruptionRollout
+	RunEvent DisruptionEventCategory = "RunEvent"
+	// Event only attached to a DisruptionBlackout
+	BlackoutEvent DisruptionEventCategory = "BlackoutEvent"
 )
 
 // DisruptionEventReason is the string that uniquely identify a disruption event
@@ -66,13 +72,17 @@ const (
 
 	// Disruption related events
 	// Warning events
-	EventEmptyDisruption                DisruptionEventReason = "EmptyDisruption"
-	EventDisruptionCreationFailed       DisruptionEventReason = "CreateFailed"
-	EventDisruptionStuckOnRemoval       DisruptionEventReason = "StuckOnRemoval"
-	EventInvalidDisruptionLabelSelector DisruptionEventReason = "InvalidLabelSelector"
-	EventDisruptionNoMoreValidTargets   DisruptionEventReason = "NoMoreTargets"
-	EventDisruptionNoTargetsFound       DisruptionEventReason = "NoTargetsFound"
-	EventInvalidSpecDisruption          DisruptionEventReason = "InvalidSpec"
+	EventEmptyDisruption                    DisruptionEventReason = "EmptyDisruption"
+	EventDisruptionCreationFailed           DisruptionEventReason = "CreateFailed"
+	EventDisruptionStuckOnRemoval           DisruptionEventReason = "StuckOnRemoval"
+	EventInvalidDisruptionLabelSelector     DisruptionEventReason = "InvalidLabelSelector"
+	EventDisruptionNoMoreValidTargets       DisruptionEventReason = "NoMoreTargets"
+	EventDisruptionNoTargetsFound           DisruptionEventReason = "NoTargetsFound"
+	EventInvalidSpecDisruption              DisruptionEventReason = "InvalidSpec"
+	EventDisruptionGuardrailBreached        DisruptionEventReason = "GuardrailBreached"
+	EventDisruptionProgressionOnHold        DisruptionEventReason = "ProgressionOnHold"
+	EventDisruptionQuotaExceeded            DisruptionEventReason = "QuotaExceeded"
+	EventDisruptionSelectionStrategyLimited DisruptionEventReason = "SelectionStrategyLimited"
 	// Normal events
 	EventDisruptionChaosPodCreated DisruptionEventReason = "ChaosPodCreated"
 	EventDisruptionFinished        DisruptionEventReason = "Finished"
@@ -80,10 +90,25 @@ const (
 	EventDisruptionDurationOver    DisruptionEventReason = "DurationOver"
 	EventDisruptionGCOver          DisruptionEventReason = "GCOver"
 	EventDisrupted                 DisruptionEventReason = "Disrupted"
+	EventDisruptionProgressed      DisruptionEventReason = "Progressed"
+	EventDisruptionPaused          DisruptionEventReason = "Paused"
+	EventDisruptionResumed         DisruptionEventReason = "Resumed"
 
 	// Injection related events
 	// Warning events
 	EventChaosPodFailedState DisruptionEventReason = "ChaosPodWarningState"
+
+	// DisruptionCron and DisruptionRollout runs related events
+	// Warning events
+	EventRunSkipped DisruptionEventReason = "RunSkipped"
+	EventRunFailed  DisruptionEventReason = "RunFailed"
+	// Normal events
+	EventRunScheduled DisruptionEventReason = "RunScheduled"
+	EventRunReplacing DisruptionEventReason = "RunReplacing"
+
+	// DisruptionBlackout related events
+	// Warning events
+	EventBlackoutInvalid DisruptionEventReason = "BlackoutInvalid"
 )
 
 var Events = map[DisruptionEventReason]DisruptionEvent{
@@ -235,6 +260,30 @@ var Events = map[DisruptionEventReason]DisruptionEvent{
 		OnDisruptionTemplateMessage: "%s",
 		Category:                    DisruptEvent,
 	},
+	EventDisruptionGuardrailBreached: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionGuardrailBreached,
+		OnDisruptionTemplateMessage: "Guardrail %s has been breached, the disruption will now be cleaned",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionProgressionOnHold: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionProgressionOnHold,
+		OnDisruptionTemplateMessage: "Progression is on hold: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionQuotaExceeded: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionQuotaExceeded,
+		OnDisruptionTemplateMessage: "Injection is on hold as the disruption exceeds the concurrent disruptions quotas: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionSelectionStrategyLimited: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionSelectionStrategyLimited,
+		OnDisruptionTemplateMessage: "Not enough targets satisfy the selection strategy, only %s have been selected",
+		Category:                    DisruptEvent,
+	},
 	EventDisruptionChaosPodCreated: {
 		Type:                        corev1.EventTypeNormal,
 		Reason:                      EventDisruptionChaosPodCreated,
@@ -259,6 +308,24 @@ var Events = map[DisruptionEventReason]DisruptionEvent{
 		OnTargetTemplateMessage: "Pod %s from disruption %s targeted this resource for injection",
 		Category:                DisruptEvent,
 	},
+	EventDisruptionProgressed: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionProgressed,
+		OnDisruptionTemplateMessage: "Disruption escalated to %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionPaused: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionPaused,
+		OnDisruptionTemplateMessage: "Disruption has been paused, injections are being cleaned",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionResumed: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionResumed,
+		OnDisruptionTemplateMessage: "Disruption has been resumed, targets are being injected again",
+		Category:                    DisruptEvent,
+	},
 	EventChaosPodFailedState: {
 		Type:                           corev1.EventTypeWarning,
 		Reason:                         EventChaosPodFailedState,
@@ -266,6 +333,36 @@ var Events = map[DisruptionEventReason]DisruptionEvent{
 		OnDisruptionTemplateAggMessage: "Chaos pod(s) are not ready",
 		Category:                       ChaosPodEvent,
 	},
+	EventRunSkipped: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventRunSkipped,
+		OnDisruptionTemplateMessage: "Skipped the run scheduled at %s as %s",
+		Category:                    RunEvent,
+	},
+	EventRunFailed: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventRunFailed,
+		OnDisruptionTemplateMessage: "Failed to create the disruption of the run scheduled at %s: %s",
+		Category:                    RunEvent,
+	},
+	EventRunScheduled: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventRunScheduled,
+		OnDisruptionTemplateMessage: "Created disruption %s for the run scheduled at %s",
+		Category:                    RunEvent,
+	},
+	EventRunReplacing: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventRunReplacing,
+		OnDisruptionTemplateMessage: "Deleting the prior disruptions still running to start the run scheduled at %s",
+		Category:                    RunEvent,
+	},
+	EventBlackoutInvalid: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventBlackoutInvalid,
+		OnDisruptionTemplateMessage: "The blackout is ignored as it is invalid: %s",
+		Category:                    BlackoutEvent,
+	},
 }
 
 // IsNotifiableEvent this event can be broadcasted to our notifiers
@@ -8,12 +8,14 @@ package v1beta1
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -29,10 +31,14 @@ const (
 	MaxNetworkPathCharacters = 100
 	DefaultHTTPMethodFilter  = "ALL"
 	DefaultHTTPPathFilter    = "/"
+	// MaximumLPMFilterEntries is the size of the LPM tries of the eBPF classifier used to filter large sets of ip ranges
+	MaximumLPMFilterEntries = 16384
+	// LPMFilterThreshold is the number of ip ranges filters from which the injector uses the eBPF LPM classifier instead of tc filters
+	LPMFilterThreshold = 128
 )
 
 // NetworkDisruptionSpec represents a network disruption injection
-// +ddmark:validation:AtLeastOneOf={BandwidthLimit,Drop,Delay,Corrupt,Duplicate}
+// +ddmark:validation:AtLeastOneOf={BandwidthLimit,Drop,Delay,Corrupt,Duplicate,TCPFaults}
 type NetworkDisruptionSpec struct {
 	// +nullable
 	Hosts []NetworkDisruptionHostSpec `json:"hosts,omitempty"`
@@ -42,6 +48,8 @@ type NetworkDisruptionSpec struct {
 	// +nullable
 	Services []NetworkDisruptionServiceSpec `json:"services,omitempty"`
 	// +nullable
+	Pods []NetworkDisruptionPodSpec `json:"pods,omitempty"`
+	// +nullable
 	Cloud *NetworkDisruptionCloudSpec `json:"cloud,omitempty"`
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
@@ -82,6 +90,21 @@ type NetworkDisruptionSpec struct {
 	DeprecatedFlow string `json:"flow,omitempty"`
 	// +nullable
 	HTTP *NetworkHTTPFilters `json:"http,omitempty"`
+	// +nullable
+	TCPFaults *NetworkTCPFaults `json:"tcpFaults,omitempty"`
+}
+
+// NetworkTCPFaults contains tcp level faults applied to the outgoing tcp connections going to the disrupted hosts, services and pods
+// +ddmark:validation:AtLeastOneOf={Reset,DropSYN,IdleTimeout}
+type NetworkTCPFaults struct {
+	// Reset rejects the packets of established connections with a tcp reset, as a load balancer dropping connections would do
+	Reset bool `json:"reset,omitempty"`
+	// DropSYN drops the packets opening new connections only, established connections being left untouched
+	DropSYN bool `json:"dropSYN,omitempty"`
+	// IdleTimeout resets the connections idle for more than the given number of seconds, as a stateful firewall would do
+	// +kubebuilder:validation:Minimum=0
+	// +ddmark:validation:Minimum=0
+	IdleTimeout uint `json:"idleTimeout,omitempty"`
 }
 
 // NetworkHTTPFilters contains http filters
@@ -126,11 +149,37 @@ type NetworkDisruptionServicePortSpec struct {
 	Port int `json:"port,omitempty"`
 }
 
-// +ddmark:validation:AtLeastOneOf={AWSServiceList,GCPServiceList,DatadogServiceList}
+// NetworkDisruptionPodSpec targets the pods matching the given label selector in the given namespace,
+// which allows to disrupt headless or non-service workloads without enumerating their IPs
+type NetworkDisruptionPodSpec struct {
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Namespace string `json:"namespace"`
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Selector labels.Set `json:"selector"`
+	// +optional
+	Ports []NetworkDisruptionPodPortSpec `json:"ports,omitempty"`
+}
+
+type NetworkDisruptionPodPortSpec struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +ddmark:validation:Minimum=1
+	// +ddmark:validation:Maximum=65535
+	Port int `json:"port"`
+	// +kubebuilder:validation:Enum=tcp;udp;""
+	// +ddmark:validation:Enum=tcp;udp;""
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// +ddmark:validation:AtLeastOneOf={AWSServiceList,GCPServiceList,DatadogServiceList,AzureServiceList,CustomServiceList}
 type NetworkDisruptionCloudSpec struct {
 	AWSServiceList     *[]NetworkDisruptionCloudServiceSpec `json:"aws,omitempty"`
 	GCPServiceList     *[]NetworkDisruptionCloudServiceSpec `json:"gcp,omitempty"`
 	DatadogServiceList *[]NetworkDisruptionCloudServiceSpec `json:"datadog,omitempty"`
+	AzureServiceList   *[]NetworkDisruptionCloudServiceSpec `json:"azure,omitempty"`
+	CustomServiceList  *[]NetworkDisruptionCloudServiceSpec `json:"custom,omitempty"`
 }
 
 type NetworkDisruptionCloudServiceSpec struct {
@@ -181,6 +230,12 @@ func (s *NetworkDisruptionSpec) Validate() (retErr error) {
 		}
 	}
 
+	for _, pod := range s.Pods {
+		if err := pod.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
 	for _, host := range s.AllowedHosts {
 		if err := host.Validate(); err != nil {
 			retErr = multierror.Append(retErr, err)
@@ -202,6 +257,15 @@ func (s *NetworkDisruptionSpec) Validate() (retErr error) {
 		}
 	}
 
+	if s.TCPFaults != nil && s.TCPFaults.Reset && s.TCPFaults.IdleTimeout > 0 {
+		retErr = multierror.Append(retErr, errors.New("the reset and idleTimeout tcp faults can't be combined as every established connection is already reset"))
+	}
+
+	// iptables rules can't exclude the allowed hosts from the tcp faults as tc filters do
+	if s.TCPFaults != nil && len(s.AllowedHosts) > 0 {
+		retErr = multierror.Append(retErr, errors.New("tcp faults can't be combined with allowedHosts; use hosts, services or pods to select the disrupted destinations instead"))
+	}
+
 	return multierror.Prefix(retErr, "Network:")
 }
 
@@ -243,6 +307,16 @@ func (s *NetworkDisruptionSpec) GenerateArgs() []string {
 		args = append(args, "--services", fmt.Sprintf("%s;%s%s", service.Name, service.Namespace, ports))
 	}
 
+	// append pods
+	for _, pod := range s.Pods {
+		ports := ""
+		for _, port := range pod.Ports {
+			ports += fmt.Sprintf(";%d-%s", port.Port, port.Protocol)
+		}
+
+		args = append(args, "--pods", fmt.Sprintf("%s;%s%s", pod.Namespace, pod.Selector.String(), ports))
+	}
+
 	if s.HTTP != nil {
 		if s.HTTP.Path != "" {
 			args = append(args, "--path", s.HTTP.Path)
@@ -253,6 +327,20 @@ func (s *NetworkDisruptionSpec) GenerateArgs() []string {
 		}
 	}
 
+	if s.TCPFaults != nil {
+		if s.TCPFaults.Reset {
+			args = append(args, "--tcp-reset")
+		}
+
+		if s.TCPFaults.DropSYN {
+			args = append(args, "--tcp-drop-syn")
+		}
+
+		if s.TCPFaults.IdleTimeout > 0 {
+			args = append(args, "--tcp-idle-timeout", strconv.Itoa(int(s.TCPFaults.IdleTimeout)))
+		}
+	}
+
 	return args
 }
 
@@ -283,20 +371,48 @@ func (s *NetworkDisruptionSpec) Format() string {
 		networkVerbs = append(networkVerbs, fmt.Sprintf("corrupting %d%%", s.Corrupt))
 	}
 
-	if len(networkVerbs) == 0 {
+	tcpFaultsVerbs := []string{}
+
+	if s.TCPFaults != nil {
+		if s.TCPFaults.Reset {
+			tcpFaultsVerbs = append(tcpFaultsVerbs, "resetting established")
+		}
+
+		if s.TCPFaults.DropSYN {
+			tcpFaultsVerbs = append(tcpFaultsVerbs, "dropping new")
+		}
+
+		if s.TCPFaults.IdleTimeout != 0 {
+			tcpFaultsVerbs = append(tcpFaultsVerbs, fmt.Sprintf("resetting %ds idle", s.TCPFaults.IdleTimeout))
+		}
+	}
+
+	if len(networkVerbs) == 0 && len(tcpFaultsVerbs) == 0 {
 		return ""
 	}
 
-	networkDescription := "Network disruption " + strings.Join(networkVerbs, ", ")
+	networkDescription := "Network disruption"
+
+	if len(networkVerbs) > 0 {
+		networkDescription += " " + strings.Join(networkVerbs, ", ")
+
+		if addOfWord {
+			networkDescription += " of"
+		}
+
+		networkDescription += " the traffic"
 
-	if addOfWord {
-		networkDescription += " of"
+		if s.DelayJitter != 0 {
+			networkDescription += fmt.Sprintf(" with %dms of delay jitter", s.DelayJitter)
+		}
 	}
 
-	networkDescription += " the traffic"
+	if len(tcpFaultsVerbs) > 0 {
+		if len(networkVerbs) > 0 {
+			networkDescription += ","
+		}
 
-	if s.DelayJitter != 0 {
-		networkDescription += fmt.Sprintf(" with %dms of delay jitter", s.DelayJitter)
+		networkDescription += " " + strings.Join(tcpFaultsVerbs, ", ") + " tcp connections"
 	}
 
 	filterDescriptions := []string{}
@@ -339,6 +455,21 @@ func (s *NetworkDisruptionSpec) Format() string {
 		filterDescriptions = append(filterDescriptions, fmt.Sprintf(" going to %s/%s%s", service.Name, service.Namespace, portsDescription))
 	}
 
+	// Add pods to description
+	for _, pod := range s.Pods {
+		portsDescription := ""
+
+		for _, port := range pod.Ports {
+			portsDescription = fmt.Sprintf("%s%d,", portsDescription, port.Port)
+		}
+
+		if len(pod.Ports) > 0 {
+			portsDescription = fmt.Sprintf(" on port(s) %s", portsDescription[:len(portsDescription)-1])
+		}
+
+		filterDescriptions = append(filterDescriptions, fmt.Sprintf(" going to pods %s in namespace %s%s", pod.Selector.String(), pod.Namespace, portsDescription))
+	}
+
 	// Add cloud services to description
 	if s.Cloud != nil {
 		services := []NetworkDisruptionCloudServiceSpec{}
@@ -355,6 +486,14 @@ func (s *NetworkDisruptionSpec) Format() string {
 			services = append(services, *s.Cloud.GCPServiceList...)
 		}
 
+		if s.Cloud.AzureServiceList != nil {
+			services = append(services, *s.Cloud.AzureServiceList...)
+		}
+
+		if s.Cloud.CustomServiceList != nil {
+			services = append(services, *s.Cloud.CustomServiceList...)
+		}
+
 		for _, service := range services {
 			descr := ""
 
@@ -407,6 +546,14 @@ func (s *NetworkDisruptionCloudSpec) TransformToCloudMap() map[string][]NetworkD
 		clouds["Datadog"] = *s.DatadogServiceList
 	}
 
+	if s.AzureServiceList != nil {
+		clouds["Azure"] = *s.AzureServiceList
+	}
+
+	if s.CustomServiceList != nil {
+		clouds["Custom"] = *s.CustomServiceList
+	}
+
 	return clouds
 }
 
@@ -507,6 +654,65 @@ func NetworkDisruptionServiceSpecFromString(services []string) ([]NetworkDisrupt
 	return parsedServices, nil
 }
 
+// NetworkDisruptionPodSpecFromString parses the given pods to pod specs
+// The expected format for pods is <namespace>;<selector>;<port>-<protocol>;<port>-<protocol>...
+// where the selector is a comma separated list of key=value labels
+func NetworkDisruptionPodSpecFromString(pods []string) ([]NetworkDisruptionPodSpec, error) {
+	parsedPods := []NetworkDisruptionPodSpec{}
+
+	for _, pod := range pods {
+		parsedPod := strings.Split(pod, ";")
+		if len(parsedPod) < 2 {
+			return nil, fmt.Errorf("pod format is expected to follow '<namespace>;<selector>;<port>-<protocol>;<port>-<protocol>', unexpected format detected: %s", pod)
+		}
+
+		selector, err := labels.ConvertSelectorToLabelsMap(parsedPod[1])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected selector in pod %s: %w", pod, err)
+		}
+
+		ports := []NetworkDisruptionPodPortSpec{}
+
+		for _, unparsedPort := range parsedPod[2:] {
+			portValue, protocol, _ := strings.Cut(unparsedPort, "-")
+
+			port, err := strconv.Atoi(portValue)
+			if err != nil {
+				return nil, fmt.Errorf("port format is expected to be a valid integer, unexpected format detected in pod port: %s", unparsedPort)
+			}
+
+			ports = append(ports, NetworkDisruptionPodPortSpec{
+				Port:     port,
+				Protocol: protocol,
+			})
+		}
+
+		parsedPods = append(parsedPods, NetworkDisruptionPodSpec{
+			Namespace: parsedPod[0],
+			Selector:  selector,
+			Ports:     ports,
+		})
+	}
+
+	return parsedPods, nil
+}
+
+func (p NetworkDisruptionPodSpec) Validate() error {
+	if p.Namespace == "" {
+		return errors.New("the namespace field must be set for pods destinations")
+	}
+
+	if len(p.Selector) == 0 {
+		return fmt.Errorf("the selector field must be set for pods destinations in namespace %s", p.Namespace)
+	}
+
+	if _, err := labels.ValidatedSelectorFromSet(p.Selector); err != nil {
+		return fmt.Errorf("the selector specified for pods destinations in namespace %s is not valid: %w", p.Namespace, err)
+	}
+
+	return nil
+}
+
 func (h NetworkDisruptionHostSpec) Validate() error {
 	if h.Flow != "" {
 		if h.Host == "" && h.Port == 0 {
@@ -517,6 +723,22 @@ func (h NetworkDisruptionHostSpec) Validate() error {
 	return nil
 }
 
+// IsLPMFilterable returns true if the host is an IPv4 address or range without connection state,
+// which can then be filtered by the eBPF LPM classifier instead of a tc filter
+func (h NetworkDisruptionHostSpec) IsLPMFilterable() bool {
+	if h.ConnState != "" {
+		return false
+	}
+
+	if _, ipNet, err := net.ParseCIDR(h.Host); err == nil {
+		return ipNet.IP.To4() != nil
+	}
+
+	ip := net.ParseIP(h.Host)
+
+	return ip != nil && ip.To4() != nil
+}
+
 func (s NetworkDisruptionServiceSpec) ExtractAffectedPortsInServicePorts(k8sService *v1.Service) ([]v1.ServicePort, []NetworkDisruptionServicePortSpec) {
 	if len(s.Ports) == 0 {
 		return k8sService.Spec.Ports, nil
@@ -66,6 +66,54 @@ var _ = Describe("NetworkDisruptionSpec", func() {
 				Expect(result).To(Equal(expected))
 			})
 
+			It("expects good formatting for multiple pods", func() {
+				disruptionSpec := NetworkDisruptionSpec{
+					Pods: []NetworkDisruptionPodSpec{
+						{
+							Namespace: "demo-namespace",
+							Selector:  map[string]string{"app": "demo-job"},
+						},
+						{
+							Namespace: "demo-namespace",
+							Selector:  map[string]string{"app": "demo-daemon"},
+							Ports: []NetworkDisruptionPodPortSpec{
+								{
+									Port:     8180,
+									Protocol: "tcp",
+								},
+							},
+						},
+					},
+					Drop: 100,
+				}
+
+				expected := "Network disruption dropping 100% of the traffic going to pods app=demo-job in namespace demo-namespace and going to pods app=demo-daemon in namespace demo-namespace on port(s) 8180"
+				result := disruptionSpec.Format()
+
+				Expect(result).To(Equal(expected))
+			})
+
+			It("expects good formatting for tcp faults", func() {
+				disruptionSpec := NetworkDisruptionSpec{
+					Hosts: []NetworkDisruptionHostSpec{
+						{
+							Host: "1.2.3.4",
+							Port: 443,
+						},
+					},
+					Drop: 10,
+					TCPFaults: &NetworkTCPFaults{
+						DropSYN:     true,
+						IdleTimeout: 30,
+					},
+				}
+
+				expected := "Network disruption dropping 10% of the traffic, dropping new, resetting 30s idle tcp connections going to 1.2.3.4:443"
+				result := disruptionSpec.Format()
+
+				Expect(result).To(Equal(expected))
+			})
+
 			It("expects good formatting for cloud network disruption", func() {
 				disruptionSpec := NetworkDisruptionSpec{
 					Cloud: &NetworkDisruptionCloudSpec{
@@ -220,6 +268,78 @@ var _ = Describe("NetworkDisruptionSpec", func() {
 				),
 			)
 		})
+		Describe("test pods fields cases", func() {
+			DescribeTable("with invalid pods",
+				func(invalidDisruptionSpec NetworkDisruptionSpec, expectedErrorMessage string) {
+					// Action
+					err := invalidDisruptionSpec.Validate()
+
+					// Assert
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).Should(ContainSubstring(expectedErrorMessage))
+				},
+				Entry("When the namespace is empty",
+					NetworkDisruptionSpec{Pods: []NetworkDisruptionPodSpec{{Selector: map[string]string{"app": "demo"}}}},
+					"the namespace field must be set for pods destinations",
+				),
+				Entry("When the selector is empty",
+					NetworkDisruptionSpec{Pods: []NetworkDisruptionPodSpec{{Namespace: "demo-namespace"}}},
+					"the selector field must be set for pods destinations in namespace demo-namespace",
+				),
+				Entry("When the selector is invalid",
+					NetworkDisruptionSpec{Pods: []NetworkDisruptionPodSpec{{Namespace: "demo-namespace", Selector: map[string]string{"app": "demo app"}}}},
+					"the selector specified for pods destinations in namespace demo-namespace is not valid",
+				),
+			)
+		})
+		Describe("test tcp faults fields cases", func() {
+			It("should not allow to combine reset and idle timeout", func() {
+				disruptionSpec := NetworkDisruptionSpec{
+					TCPFaults: &NetworkTCPFaults{
+						Reset:       true,
+						IdleTimeout: 30,
+					},
+				}
+
+				err := disruptionSpec.Validate()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("the reset and idleTimeout tcp faults can't be combined"))
+			})
+
+			It("should not allow to combine tcp faults and allowed hosts", func() {
+				disruptionSpec := NetworkDisruptionSpec{
+					TCPFaults: &NetworkTCPFaults{
+						DropSYN: true,
+					},
+					AllowedHosts: []NetworkDisruptionHostSpec{
+						{
+							Host: "10.0.0.1",
+						},
+					},
+				}
+
+				err := disruptionSpec.Validate()
+
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("tcp faults can't be combined with allowedHosts"))
+			})
+
+			It("should generate the tcp faults arguments", func() {
+				disruptionSpec := NetworkDisruptionSpec{
+					TCPFaults: &NetworkTCPFaults{
+						DropSYN:     true,
+						IdleTimeout: 30,
+					},
+				}
+
+				args := disruptionSpec.GenerateArgs()
+
+				Expect(args).Should(ContainElement("--tcp-drop-syn"))
+				Expect(args).ShouldNot(ContainElement("--tcp-reset"))
+				Expect(args).Should(ContainElements("--tcp-idle-timeout", "30"))
+			})
+		})
 		Describe("test deprecated fields cases", func() {
 			port := 8080
 			DescribeTable("with deprecated field defined",
@@ -306,6 +426,51 @@ var _ = Describe("NetworkDisruptionSpec", func() {
 			Expect(actual).Should(Equal(expected))
 		})
 	})
+	When("NetworkDisruptionPodSpecFromString is called", func() {
+		It("parses the arguments generated by GenerateArgs", func() {
+			disruptionSpec := NetworkDisruptionSpec{
+				Pods: []NetworkDisruptionPodSpec{{
+					Namespace: "demo-namespace",
+					Selector:  map[string]string{"app": "demo", "tier": "backend"},
+					Ports: []NetworkDisruptionPodPortSpec{
+						{
+							Port:     8080,
+							Protocol: "tcp",
+						},
+						{
+							Port: 8180,
+						},
+					},
+				}},
+			}
+
+			args := disruptionSpec.GenerateArgs()
+			Expect(args).Should(ContainElement("demo-namespace;app=demo,tier=backend;8080-tcp;8180-"))
+
+			actual, err := NetworkDisruptionPodSpecFromString([]string{"demo-namespace;app=demo,tier=backend;8080-tcp;8180-"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(actual).Should(Equal(disruptionSpec.Pods))
+		})
+
+		It("fails on a missing selector", func() {
+			_, err := NetworkDisruptionPodSpecFromString([]string{"demo-namespace"})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("NetworkDisruptionHostSpec", func() {
+	DescribeTable("IsLPMFilterable",
+		func(host NetworkDisruptionHostSpec, expected bool) {
+			Expect(host.IsLPMFilterable()).To(Equal(expected))
+		},
+		Entry("with an ipv4 cidr", NetworkDisruptionHostSpec{Host: "10.0.0.0/8", Port: 443}, true),
+		Entry("with an ipv4", NetworkDisruptionHostSpec{Host: "10.0.0.1", Flow: FlowIngress}, true),
+		Entry("with an ipv6 cidr", NetworkDisruptionHostSpec{Host: "2001:db8::/32"}, false),
+		Entry("with a hostname", NetworkDisruptionHostSpec{Host: "example.com"}, false),
+		Entry("without host", NetworkDisruptionHostSpec{Port: 443}, false),
+		Entry("with a connection state", NetworkDisruptionHostSpec{Host: "10.0.0.0/8", ConnState: "new"}, false),
+	)
 })
 
 func randStringRunes(n int) string {
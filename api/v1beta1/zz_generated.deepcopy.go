@@ -234,6 +234,114 @@ func (in *Disruption) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBlackout) DeepCopyInto(out *DisruptionBlackout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBlackout.
+func (in *DisruptionBlackout) DeepCopy() *DisruptionBlackout {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBlackout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionBlackout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBlackoutList) DeepCopyInto(out *DisruptionBlackoutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DisruptionBlackout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBlackoutList.
+func (in *DisruptionBlackoutList) DeepCopy() *DisruptionBlackoutList {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBlackoutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionBlackoutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBlackoutSpec) DeepCopyInto(out *DisruptionBlackoutSpec) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]DisruptionBlackoutWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBlackoutSpec.
+func (in *DisruptionBlackoutSpec) DeepCopy() *DisruptionBlackoutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBlackoutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBlackoutWindow) DeepCopyInto(out *DisruptionBlackoutWindow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBlackoutWindow.
+func (in *DisruptionBlackoutWindow) DeepCopy() *DisruptionBlackoutWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBlackoutWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionCron) DeepCopyInto(out *DisruptionCron) {
 	*out = *in
@@ -293,6 +401,31 @@ func (in *DisruptionCronList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCronRun) DeepCopyInto(out *DisruptionCronRun) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(TargetsHealth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCronRun.
+func (in *DisruptionCronRun) DeepCopy() *DisruptionCronRun {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCronRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionCronSpec) DeepCopyInto(out *DisruptionCronSpec) {
 	*out = *in
@@ -321,6 +454,13 @@ func (in *DisruptionCronStatus) DeepCopyInto(out *DisruptionCronStatus) {
 		in, out := &in.TargetResourcePreviouslyMissing, &out.TargetResourcePreviouslyMissing
 		*out = (*in).DeepCopy()
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DisruptionCronRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCronStatus.
@@ -417,6 +557,21 @@ func (in *DisruptionPulse) DeepCopy() *DisruptionPulse {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionQuotas) DeepCopyInto(out *DisruptionQuotas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionQuotas.
+func (in *DisruptionQuotas) DeepCopy() *DisruptionQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionRollout) DeepCopyInto(out *DisruptionRollout) {
 	*out = *in
@@ -481,6 +636,11 @@ func (in *DisruptionRolloutSpec) DeepCopyInto(out *DisruptionRolloutSpec) {
 	*out = *in
 	out.TargetResource = in.TargetResource
 	in.DisruptionTemplate.DeepCopyInto(&out.DisruptionTemplate)
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = new(DisruptionRolloutTriggers)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionRolloutSpec.
@@ -534,6 +694,31 @@ func (in *DisruptionRolloutStatus) DeepCopy() *DisruptionRolloutStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionRolloutTriggers) DeepCopyInto(out *DisruptionRolloutTriggers) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationChanges != nil {
+		in, out := &in.AnnotationChanges, &out.AnnotationChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionRolloutTriggers.
+func (in *DisruptionRolloutTriggers) DeepCopy() *DisruptionRolloutTriggers {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionRolloutTriggers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 	*out = *in
@@ -622,6 +807,21 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 		*out = new(Reporting)
 		**out = **in
 	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(Guardrails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progression != nil {
+		in, out := &in.Progression, &out.Progression
+		*out = new(Progression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelectionStrategy != nil {
+		in, out := &in.SelectionStrategy, &out.SelectionStrategy
+		*out = new(SelectionStrategy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionSpec.
@@ -644,6 +844,21 @@ func (in *DisruptionStatus) DeepCopyInto(out *DisruptionStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progression != nil {
+		in, out := &in.Progression, &out.Progression
+		*out = new(ProgressionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(TargetsHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionStatus.
@@ -689,6 +904,175 @@ func (in *DisruptionTriggers) DeepCopy() *DisruptionTriggers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflow) DeepCopyInto(out *DisruptionWorkflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflow.
+func (in *DisruptionWorkflow) DeepCopy() *DisruptionWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionWorkflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflowList) DeepCopyInto(out *DisruptionWorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DisruptionWorkflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflowList.
+func (in *DisruptionWorkflowList) DeepCopy() *DisruptionWorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionWorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflowSpec) DeepCopyInto(out *DisruptionWorkflowSpec) {
+	*out = *in
+	if in.TargetResource != nil {
+		in, out := &in.TargetResource, &out.TargetResource
+		*out = new(TargetResourceSpec)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]DisruptionWorkflowStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflowSpec.
+func (in *DisruptionWorkflowSpec) DeepCopy() *DisruptionWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflowStatus) DeepCopyInto(out *DisruptionWorkflowStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]DisruptionWorkflowStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflowStatus.
+func (in *DisruptionWorkflowStatus) DeepCopy() *DisruptionWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflowStep) DeepCopyInto(out *DisruptionWorkflowStep) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetResource != nil {
+		in, out := &in.TargetResource, &out.TargetResource
+		*out = new(TargetResourceSpec)
+		**out = **in
+	}
+	in.DisruptionTemplate.DeepCopyInto(&out.DisruptionTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflowStep.
+func (in *DisruptionWorkflowStep) DeepCopy() *DisruptionWorkflowStep {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflowStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWorkflowStepStatus) DeepCopyInto(out *DisruptionWorkflowStepStatus) {
+	*out = *in
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWorkflowStepStatus.
+func (in *DisruptionWorkflowStepStatus) DeepCopy() *DisruptionWorkflowStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWorkflowStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointAlteration) DeepCopyInto(out *EndpointAlteration) {
 	*out = *in
@@ -724,6 +1108,144 @@ func (in *GRPCDisruptionSpec) DeepCopy() *GRPCDisruptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailCheck) DeepCopyInto(out *GuardrailCheck) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(GuardrailHTTPCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(GuardrailPrometheusCheck)
+		**out = **in
+	}
+	if in.TargetReadiness != nil {
+		in, out := &in.TargetReadiness, &out.TargetReadiness
+		*out = new(GuardrailTargetReadinessCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailCheck.
+func (in *GuardrailCheck) DeepCopy() *GuardrailCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailCheckStatus) DeepCopyInto(out *GuardrailCheckStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailCheckStatus.
+func (in *GuardrailCheckStatus) DeepCopy() *GuardrailCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailHTTPCheck) DeepCopyInto(out *GuardrailHTTPCheck) {
+	*out = *in
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailHTTPCheck.
+func (in *GuardrailHTTPCheck) DeepCopy() *GuardrailHTTPCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailHTTPCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailPrometheusCheck) DeepCopyInto(out *GuardrailPrometheusCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailPrometheusCheck.
+func (in *GuardrailPrometheusCheck) DeepCopy() *GuardrailPrometheusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailPrometheusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailTargetReadinessCheck) DeepCopyInto(out *GuardrailTargetReadinessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailTargetReadinessCheck.
+func (in *GuardrailTargetReadinessCheck) DeepCopy() *GuardrailTargetReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailTargetReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Guardrails) DeepCopyInto(out *Guardrails) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]GuardrailCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Guardrails.
+func (in *Guardrails) DeepCopy() *Guardrails {
+	if in == nil {
+		return nil
+	}
+	out := new(Guardrails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsStatus) DeepCopyInto(out *GuardrailsStatus) {
+	*out = *in
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]GuardrailCheckStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsStatus.
+func (in *GuardrailsStatus) DeepCopy() *GuardrailsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRecordPair) DeepCopyInto(out *HostRecordPair) {
 	*out = *in
@@ -785,6 +1307,24 @@ func (in *NetworkDisruptionCloudSpec) DeepCopyInto(out *NetworkDisruptionCloudSp
 			copy(*out, *in)
 		}
 	}
+	if in.AzureServiceList != nil {
+		in, out := &in.AzureServiceList, &out.AzureServiceList
+		*out = new([]NetworkDisruptionCloudServiceSpec)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]NetworkDisruptionCloudServiceSpec, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.CustomServiceList != nil {
+		in, out := &in.CustomServiceList, &out.CustomServiceList
+		*out = new([]NetworkDisruptionCloudServiceSpec)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]NetworkDisruptionCloudServiceSpec, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDisruptionCloudSpec.
@@ -812,6 +1352,48 @@ func (in *NetworkDisruptionHostSpec) DeepCopy() *NetworkDisruptionHostSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDisruptionPodPortSpec) DeepCopyInto(out *NetworkDisruptionPodPortSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDisruptionPodPortSpec.
+func (in *NetworkDisruptionPodPortSpec) DeepCopy() *NetworkDisruptionPodPortSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDisruptionPodPortSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDisruptionPodSpec) DeepCopyInto(out *NetworkDisruptionPodSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkDisruptionPodPortSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDisruptionPodSpec.
+func (in *NetworkDisruptionPodSpec) DeepCopy() *NetworkDisruptionPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDisruptionPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDisruptionServicePortSpec) DeepCopyInto(out *NetworkDisruptionServicePortSpec) {
 	*out = *in
@@ -867,6 +1449,13 @@ func (in *NetworkDisruptionSpec) DeepCopyInto(out *NetworkDisruptionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]NetworkDisruptionPodSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Cloud != nil {
 		in, out := &in.Cloud, &out.Cloud
 		*out = new(NetworkDisruptionCloudSpec)
@@ -882,6 +1471,11 @@ func (in *NetworkDisruptionSpec) DeepCopyInto(out *NetworkDisruptionSpec) {
 		*out = new(NetworkHTTPFilters)
 		**out = **in
 	}
+	if in.TCPFaults != nil {
+		in, out := &in.TCPFaults, &out.TCPFaults
+		*out = new(NetworkTCPFaults)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDisruptionSpec.
@@ -909,6 +1503,21 @@ func (in *NetworkHTTPFilters) DeepCopy() *NetworkHTTPFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTCPFaults) DeepCopyInto(out *NetworkTCPFaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTCPFaults.
+func (in *NetworkTCPFaults) DeepCopy() *NetworkTCPFaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTCPFaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFailureSpec) DeepCopyInto(out *NodeFailureSpec) {
 	*out = *in
@@ -939,6 +1548,45 @@ func (in *OpenatSyscallSpec) DeepCopy() *OpenatSyscallSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Progression) DeepCopyInto(out *Progression) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Progression.
+func (in *Progression) DeepCopy() *Progression {
+	if in == nil {
+		return nil
+	}
+	out := new(Progression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProgressionStatus) DeepCopyInto(out *ProgressionStatus) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProgressionStatus.
+func (in *ProgressionStatus) DeepCopy() *ProgressionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProgressionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reporting) DeepCopyInto(out *Reporting) {
 	*out = *in
@@ -954,6 +1602,36 @@ func (in *Reporting) DeepCopy() *Reporting {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectionStrategy) DeepCopyInto(out *SelectionStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionStrategy.
+func (in *SelectionStrategy) DeepCopy() *SelectionStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectionStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealthSignal) DeepCopyInto(out *TargetHealthSignal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealthSignal.
+func (in *TargetHealthSignal) DeepCopy() *TargetHealthSignal {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealthSignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetInjection) DeepCopyInto(out *TargetInjection) {
 	*out = *in
@@ -1006,6 +1684,26 @@ func (in *TargetResourceSpec) DeepCopy() *TargetResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetsHealth) DeepCopyInto(out *TargetsHealth) {
+	*out = *in
+	if in.UnhealthyTargets != nil {
+		in, out := &in.UnhealthyTargets, &out.UnhealthyTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetsHealth.
+func (in *TargetsHealth) DeepCopy() *TargetsHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetsHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnsafemodeSpec) DeepCopyInto(out *UnsafemodeSpec) {
 	*out = *in
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/audit/file"
+	"github.com/DataDog/chaos-controller/audit/http"
+	"github.com/DataDog/chaos-controller/audit/noop"
+	"github.com/DataDog/chaos-controller/audit/stdout"
+	"github.com/DataDog/chaos-controller/audit/types"
+	"go.uber.org/zap"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+// Sink describes an audit sink
+type Sink interface {
+	// GetSinkName returns the current sink name
+	GetSinkName() string
+	// Write durably records the given entry
+	Write(entry types.Entry) error
+	// Close flushes and closes the sink
+	Close() error
+}
+
+// GetSink returns a new initiated audit sink from the given SinkDriver
+// the file and HTTP configurations are only used by their respective drivers
+func GetSink(log *zap.SugaredLogger, driver types.SinkDriver, fileConfig file.Config, httpConfig http.Config) (Sink, error) {
+	switch driver {
+	case types.SinkDriverStdout:
+		return stdout.New(), nil
+	case types.SinkDriverFile:
+		return file.New(fileConfig)
+	case types.SinkDriverHTTP:
+		return http.New(httpConfig)
+	case types.SinkDriverNoop:
+		return noop.New(log), nil
+	default:
+		return nil, fmt.Errorf("unsupported audit sink: %s", driver)
+	}
+}
+
+// NewEntry returns the audit entry of the given action made by the given user on the given disruption
+// the targets are the ones of the disruption status, the controller entries can narrow them down to the targets they acted on
+func NewEntry(source types.Source, action types.Action, user authv1.UserInfo, dis v1beta1.Disruption, now time.Time) types.Entry {
+	entry := types.Entry{
+		Time:                now.UTC(),
+		Source:              source,
+		Action:              action,
+		User:                user.Username,
+		Groups:              user.Groups,
+		DisruptionName:      dis.Name,
+		DisruptionNamespace: dis.Namespace,
+		DisruptionUID:       string(dis.UID),
+		DryRun:              dis.Spec.DryRun,
+		UnsafeFlags:         UnsafeFlags(dis.Spec.Unsafemode),
+		Targets:             dis.Status.TargetInjections.GetTargetNames(),
+	}
+
+	sort.Strings(entry.Targets)
+
+	if hash, err := dis.Spec.Hash(); err == nil {
+		entry.DisruptionHash = hash
+	}
+
+	for _, kind := range dis.Spec.KindNames() {
+		entry.Kinds = append(entry.Kinds, string(kind))
+	}
+
+	if dis.Spec.Count != nil {
+		entry.Count = dis.Spec.Count.String()
+	}
+
+	return entry
+}
+
+// UnsafeFlags returns the safety nets overrides of the given unsafemode spec
+func UnsafeFlags(unsafemode *v1beta1.UnsafemodeSpec) []string {
+	if unsafemode == nil {
+		return nil
+	}
+
+	flags := []string{}
+
+	for flag, enabled := range map[string]bool{
+		"disableAll":                 unsafemode.DisableAll,
+		"disableCountTooLarge":       unsafemode.DisableCountTooLarge,
+		"disableNeitherHostNorPort":  unsafemode.DisableNeitherHostNorPort,
+		"disableSpecificContainDisk": unsafemode.DisableSpecificContainDisk,
+		"allowRootDiskFailure":       unsafemode.AllowRootDiskFailure,
+	} {
+		if enabled {
+			flags = append(flags, flag)
+		}
+	}
+
+	if unsafemode.Config != nil && unsafemode.Config.CountTooLarge != nil {
+		flags = append(flags, "config.countTooLarge")
+	}
+
+	sort.Strings(flags)
+
+	return flags
+}
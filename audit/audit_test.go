@@ -0,0 +1,239 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/audit"
+	"github.com/DataDog/chaos-controller/audit/file"
+	audithttp "github.com/DataDog/chaos-controller/audit/http"
+	"github.com/DataDog/chaos-controller/audit/stdout"
+	"github.com/DataDog/chaos-controller/audit/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Audit", func() {
+	var (
+		now        time.Time
+		disruption v1beta1.Disruption
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+		count := intstr.FromString("50%")
+		disruption = v1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "network-drop",
+				Namespace: "chaos-engineering",
+				UID:       "d4b5c0a1-3e2f-4b8c-9d7e-1a2b3c4d5e6f",
+			},
+			Spec: v1beta1.DisruptionSpec{
+				Count:    &count,
+				Duration: "1h",
+				Network: &v1beta1.NetworkDisruptionSpec{
+					Drop: 100,
+				},
+				Unsafemode: &v1beta1.UnsafemodeSpec{
+					DisableCountTooLarge: true,
+				},
+			},
+			Status: v1beta1.DisruptionStatus{
+				TargetInjections: v1beta1.TargetInjections{
+					"pod-2": {},
+					"pod-1": {},
+				},
+			},
+		}
+	})
+
+	Describe("NewEntry", func() {
+		It("describes the action made by the user on the disruption", func() {
+			// Arrange
+			user := authv1.UserInfo{Username: "jane.doe@example.com", Groups: []string{"system:authenticated"}}
+
+			// Action
+			entry := audit.NewEntry(types.SourceWebhook, types.ActionCreate, user, disruption, now)
+
+			// Assert
+			hash, err := disruption.Spec.Hash()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entry).To(Equal(types.Entry{
+				Time:                now,
+				Source:              types.SourceWebhook,
+				Action:              types.ActionCreate,
+				User:                "jane.doe@example.com",
+				Groups:              []string{"system:authenticated"},
+				DisruptionName:      "network-drop",
+				DisruptionNamespace: "chaos-engineering",
+				DisruptionUID:       "d4b5c0a1-3e2f-4b8c-9d7e-1a2b3c4d5e6f",
+				DisruptionHash:      hash,
+				Kinds:               []string{"network-disruption"},
+				Count:               "50%",
+				UnsafeFlags:         []string{"disableCountTooLarge"},
+				Targets:             []string{"pod-1", "pod-2"},
+			}))
+		})
+	})
+
+	Describe("UnsafeFlags", func() {
+		It("returns no flag without unsafemode", func() {
+			Expect(audit.UnsafeFlags(nil)).To(BeEmpty())
+		})
+
+		It("returns the sorted overridden safety nets", func() {
+			// Arrange
+			threshold := 80
+			unsafemode := &v1beta1.UnsafemodeSpec{
+				DisableNeitherHostNorPort: true,
+				AllowRootDiskFailure:      true,
+				Config: &v1beta1.Config{
+					CountTooLarge: &v1beta1.CountTooLargeConfig{
+						NamespaceThreshold: &threshold,
+					},
+				},
+			}
+
+			// Action
+			flags := audit.UnsafeFlags(unsafemode)
+
+			// Assert
+			Expect(flags).To(Equal([]string{"allowRootDiskFailure", "config.countTooLarge", "disableNeitherHostNorPort"}))
+		})
+	})
+
+	Describe("GetSink", func() {
+		It("fails with an unsupported driver", func() {
+			_, err := audit.GetSink(zap.NewNop().Sugar(), "syslog", file.Config{}, audithttp.Config{})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("fails with a file driver without path", func() {
+			_, err := audit.GetSink(zap.NewNop().Sugar(), types.SinkDriverFile, file.Config{}, audithttp.Config{})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("fails with an http driver without url", func() {
+			_, err := audit.GetSink(zap.NewNop().Sugar(), types.SinkDriverHTTP, file.Config{}, audithttp.Config{})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("sinks", func() {
+		var entry types.Entry
+
+		BeforeEach(func() {
+			entry = audit.NewEntry(types.SourceController, types.ActionInject, authv1.UserInfo{Username: "jane.doe@example.com"}, disruption, now)
+		})
+
+		It("writes the entries as JSON lines on the stdout sink", func() {
+			// Arrange
+			buffer := &bytes.Buffer{}
+			sink := stdout.NewWithWriter(buffer)
+
+			// Action
+			Expect(sink.Write(entry)).To(Succeed())
+			Expect(sink.Write(entry)).To(Succeed())
+
+			// Assert
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			Expect(lines).To(HaveLen(2))
+
+			written := types.Entry{}
+			Expect(json.Unmarshal([]byte(lines[0]), &written)).To(Succeed())
+			Expect(written).To(Equal(entry))
+		})
+
+		It("appends the entries as JSON lines to the file sink", func() {
+			// Arrange
+			path := filepath.Join(GinkgoT().TempDir(), "audit.log")
+			Expect(os.WriteFile(path, []byte("{}\n"), 0o600)).To(Succeed())
+
+			sink, err := audit.GetSink(zap.NewNop().Sugar(), types.SinkDriverFile, file.Config{Path: path}, audithttp.Config{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// Action
+			Expect(sink.Write(entry)).To(Succeed())
+			Expect(sink.Close()).To(Succeed())
+
+			// Assert
+			content, err := os.ReadFile(path)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			Expect(lines).To(HaveLen(2))
+
+			written := types.Entry{}
+			Expect(json.Unmarshal([]byte(lines[1]), &written)).To(Succeed())
+			Expect(written).To(Equal(entry))
+		})
+
+		It("posts the entries to the http sink endpoint", func() {
+			// Arrange
+			var (
+				body    []byte
+				headers http.Header
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+				body, _ = io.ReadAll(r.Body)
+
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			sink, err := audit.GetSink(zap.NewNop().Sugar(), types.SinkDriverHTTP, file.Config{}, audithttp.Config{
+				URL:     server.URL,
+				Headers: map[string]string{"Authorization": "Bearer token"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// Action
+			err = sink.Write(entry)
+
+			// Assert
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(headers.Get("Content-Type")).To(Equal("application/json"))
+			Expect(headers.Get("Authorization")).To(Equal("Bearer token"))
+
+			written := types.Entry{}
+			Expect(json.Unmarshal(body, &written)).To(Succeed())
+			Expect(written).To(Equal(entry))
+		})
+
+		It("fails when the http sink endpoint does not accept the entry", func() {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+
+			sink, err := audit.GetSink(zap.NewNop().Sugar(), types.SinkDriverHTTP, file.Config{}, audithttp.Config{URL: server.URL})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// Action
+			err = sink.Write(entry)
+
+			// Assert
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/DataDog/chaos-controller/audit/types"
+)
+
+// Config represents the file audit sink configuration
+type Config struct {
+	Path string `json:"path"`
+}
+
+// Sink describes a file audit sink, appending the entries as JSON lines
+type Sink struct {
+	lock *sync.Mutex
+	file *os.File
+}
+
+// New initiated file audit sink, the file is created if it does not exist
+func New(config Config) (Sink, error) {
+	if config.Path == "" {
+		return Sink{}, fmt.Errorf("the file audit sink requires a path")
+	}
+
+	file, err := os.OpenFile(filepath.Clean(config.Path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return Sink{}, fmt.Errorf("error opening the audit file: %w", err)
+	}
+
+	return Sink{
+		lock: &sync.Mutex{},
+		file: file,
+	}, nil
+}
+
+// GetSinkName returns the name of the sink
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverFile)
+}
+
+// Write appends the given entry to the file as a single JSON line
+func (s Sink) Write(entry types.Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling the audit entry: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing the audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the file
+func (s Sink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/DataDog/chaos-controller/audit/types"
+)
+
+// DefaultTimeout is the timeout of the requests sending the entries when none is configured
+const DefaultTimeout = 5 * time.Second
+
+// Config represents the HTTP audit sink configuration
+type Config struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Timeout time.Duration     `json:"timeout"`
+}
+
+// Sink describes an HTTP audit sink, sending each entry as a JSON document
+type Sink struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+// New initiated HTTP audit sink
+func New(config Config) (Sink, error) {
+	if _, err := url.ParseRequestURI(config.URL); err != nil {
+		return Sink{}, fmt.Errorf("the http audit sink requires a valid url: %w", err)
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return Sink{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		url:     config.URL,
+		headers: config.Headers,
+	}, nil
+}
+
+// GetSinkName returns the name of the sink
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverHTTP)
+}
+
+// Write sends the given entry to the configured url
+func (s Sink) Write(entry types.Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling the audit entry: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building the audit request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending the audit entry: %w", err)
+	}
+
+	if err := res.Body.Close(); err != nil {
+		return fmt.Errorf("error sending the audit entry: %w", err)
+	}
+
+	if res.StatusCode >= 300 || res.StatusCode < 200 {
+		return fmt.Errorf("receiving %d status code when sending the audit entry", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Close returns nil
+func (s Sink) Close() error {
+	return nil
+}
@@ -0,0 +1,38 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package noop
+
+import (
+	"github.com/DataDog/chaos-controller/audit/types"
+	"go.uber.org/zap"
+)
+
+// Sink describes a noop audit sink
+type Sink struct {
+	log *zap.SugaredLogger
+}
+
+// New initiated noop audit sink
+func New(log *zap.SugaredLogger) Sink {
+	return Sink{log: log}
+}
+
+// GetSinkName returns the name of the sink
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverNoop)
+}
+
+// Write does nothing but logging the entry in debug
+func (s Sink) Write(entry types.Entry) error {
+	s.log.Debugw("NOOP: audit entry", "action", entry.Action, "user", entry.User, "disruptionName", entry.DisruptionName, "disruptionNamespace", entry.DisruptionNamespace)
+
+	return nil
+}
+
+// Close returns nil
+func (s Sink) Close() error {
+	return nil
+}
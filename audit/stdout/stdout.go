@@ -0,0 +1,52 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package stdout
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/DataDog/chaos-controller/audit/types"
+)
+
+// Sink describes a stdout audit sink, writing the entries as JSON lines
+type Sink struct {
+	lock    *sync.Mutex
+	encoder *json.Encoder
+}
+
+// New initiated stdout audit sink
+func New() Sink {
+	return NewWithWriter(os.Stdout)
+}
+
+// NewWithWriter initiated audit sink writing the entries as JSON lines to the given writer
+func NewWithWriter(writer io.Writer) Sink {
+	return Sink{
+		lock:    &sync.Mutex{},
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// GetSinkName returns the name of the sink
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverStdout)
+}
+
+// Write writes the given entry as a single JSON line
+func (s Sink) Write(entry types.Entry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.encoder.Encode(entry)
+}
+
+// Close returns nil
+func (s Sink) Close() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package types
+
+import "time"
+
+// SinkDriver represents a sink driver to use
+type SinkDriver string
+
+const (
+	// SinkDriverStdout writes the entries as JSON lines on the standard output
+	SinkDriverStdout SinkDriver = "stdout"
+
+	// SinkDriverFile appends the entries as JSON lines to a file
+	SinkDriverFile SinkDriver = "file"
+
+	// SinkDriverHTTP sends each entry as a JSON document to an HTTP endpoint
+	SinkDriverHTTP SinkDriver = "http"
+
+	// SinkDriverNoop is a noop driver, disabling the audit
+	SinkDriverNoop SinkDriver = "noop"
+)
+
+// Source is the component which wrote an audit entry
+type Source string
+
+const (
+	// SourceWebhook entries are written by the admission webhook on requests made by users
+	SourceWebhook Source = "webhook"
+
+	// SourceController entries are written by the controller when it acts on the targets
+	SourceController Source = "controller"
+)
+
+// Action is the audited action
+type Action string
+
+const (
+	// ActionCreate is the creation of a disruption
+	ActionCreate Action = "create"
+
+	// ActionCountChange is the update of the count of targets of a disruption
+	ActionCountChange Action = "count_change"
+
+	// ActionPause is the pause of a disruption
+	ActionPause Action = "pause"
+
+	// ActionResume is the resume of a paused disruption
+	ActionResume Action = "resume"
+
+	// ActionDelete is the deletion of a disruption
+	ActionDelete Action = "delete"
+
+	// ActionInject is the creation of the chaos pods injecting a disruption in targets
+	ActionInject Action = "inject"
+
+	// ActionClean is the end of the cleanup of a disruption from all its targets
+	ActionClean Action = "clean"
+)
+
+// Entry is a structured audit log entry
+// Requested is true on the webhook entries, written on admission before the request is accepted, so the action may have been rejected
+type Entry struct {
+	Time                time.Time `json:"time"`
+	Source              Source    `json:"source"`
+	Action              Action    `json:"action"`
+	User                string    `json:"user"`
+	Groups              []string  `json:"groups,omitempty"`
+	Requested           bool      `json:"requested,omitempty"`
+	DisruptionName      string    `json:"disruptionName"`
+	DisruptionNamespace string    `json:"disruptionNamespace"`
+	DisruptionUID       string    `json:"disruptionUID,omitempty"`
+	DisruptionHash      string    `json:"disruptionHash,omitempty"`
+	Kinds               []string  `json:"kinds,omitempty"`
+	DryRun              bool      `json:"dryRun,omitempty"`
+	Count               string    `json:"count,omitempty"`
+	PreviousCount       string    `json:"previousCount,omitempty"`
+	UnsafeFlags         []string  `json:"unsafeFlags,omitempty"`
+	Targets             []string  `json:"targets,omitempty"`
+}
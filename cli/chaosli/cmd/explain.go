@@ -271,6 +271,29 @@ func explainNetworkFailure(spec v1beta1.DisruptionSpec) {
 		}
 	}
 
+	if len(network.Pods) != 0 {
+		fmt.Println("\t💥  will apply filters so that network failures apply to outgoing/ingoing traffic from/to the pods matching the following namespaces/selectors pairs:")
+	}
+
+	for _, data := range network.Pods {
+		fmt.Printf("\t\t🎯 Selector: %s\n", data.Selector.String())
+		fmt.Printf("\t\t\t⛵️ Namespace: %s\n", data.Namespace)
+
+		if len(data.Ports) > 0 {
+			fmt.Printf("\t\t\t⛵️ Affected ports:\n")
+
+			for _, port := range data.Ports {
+				toPrint := []string{strconv.Itoa(port.Port)}
+
+				if port.Protocol != "" {
+					toPrint = append(toPrint, port.Protocol)
+				}
+
+				fmt.Printf("\t\t\t\t⛵️ Port: (%s)\n", strings.Join(toPrint, "/"))
+			}
+		}
+	}
+
 	if network.Drop != 0 {
 		fmt.Printf("\t\t💣 applies a packet drop of %d percent.\n", network.Drop)
 	}
@@ -291,6 +314,20 @@ func explainNetworkFailure(spec v1beta1.DisruptionSpec) {
 		fmt.Printf("\t\t💣 applies a bandwidth limit of %d ms.\n", network.BandwidthLimit)
 	}
 
+	if network.TCPFaults != nil {
+		if network.TCPFaults.Reset {
+			fmt.Println("\t\t💣 resets established tcp connections.")
+		}
+
+		if network.TCPFaults.DropSYN {
+			fmt.Println("\t\t💣 drops packets opening new tcp connections.")
+		}
+
+		if network.TCPFaults.IdleTimeout != 0 {
+			fmt.Printf("\t\t💣 resets tcp connections idle for more than %d seconds.\n", network.TCPFaults.IdleTimeout)
+		}
+	}
+
 	if len(network.AllowedHosts) > 0 {
 		fmt.Println("\t💥  will apply filters so that the injected network failure excludes affecting traffic to/from the following host tuples:")
 		explainHosts(network.AllowedHosts)
@@ -31,6 +31,7 @@ import (
 	chaostypes "github.com/DataDog/chaos-controller/types"
 	"github.com/cenkalti/backoff"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,7 +93,13 @@ func init() {
 
 	// basic args
 	rootCmd.PersistentFlags().BoolVar(&disruptionArgs.DryRun, "dry-run", false, "Enable dry-run mode")
-	rootCmd.PersistentFlags().StringVar(&disruptionArgs.MetricsSink, "metrics-sink", "noop", "Metrics sink (datadog, or noop)")
+	rootCmd.PersistentFlags().StringVar(&disruptionArgs.MetricsSink, "metrics-sink", "noop", "Metrics sink (datadog, prometheus, or noop)")
+	rootCmd.PersistentFlags().StringVar(&disruptionArgs.TracerSink, "tracer-sink", "noop", "Tracer sink (datadog, otlp, or noop)")
+	rootCmd.PersistentFlags().StringVar(&disruptionArgs.SpanContext, "span-context", "", "Disruption span context (JSON encoded W3C trace context) the injector spans are attached to")
+	rootCmd.PersistentFlags().StringVar(&disruptionArgs.TracerOTLP.Protocol, "tracer-otlp-protocol", "grpc", "Protocol used by the otlp tracer sink to export spans (grpc or http)")
+	rootCmd.PersistentFlags().StringVar(&disruptionArgs.TracerOTLP.Endpoint, "tracer-otlp-endpoint", "", "Endpoint (host:port) the otlp tracer sink exports spans to")
+	rootCmd.PersistentFlags().BoolVar(&disruptionArgs.TracerOTLP.Insecure, "tracer-otlp-insecure", false, "Disable TLS when exporting spans with the otlp tracer sink")
+	rootCmd.PersistentFlags().Float64Var(&disruptionArgs.TracerOTLP.SamplingRatio, "tracer-otlp-sampling-ratio", 1, "Ratio of root spans sampled by the otlp tracer sink")
 	rootCmd.PersistentFlags().StringVar(&disruptionLevelRaw, "level", "", "Level of injection (either pod or node)")
 	rootCmd.PersistentFlags().StringSliceVar(&rawTargetContainers, "target-containers", []string{}, "Targeted containers")
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.TargetPodIP, "target-pod-ip", "", "Pod IP of targeted pod")
@@ -116,6 +123,7 @@ func init() {
 	_ = cobra.MarkFlagRequired(rootCmd.PersistentFlags(), "level")
 	cobra.OnInitialize(initLogger)
 	cobra.OnInitialize(initMetricsSink)
+	cobra.OnInitialize(initTracerSink)
 	cobra.OnInitialize(initConfig)
 	cobra.OnInitialize(initExitSignalsHandler)
 }
@@ -130,6 +138,15 @@ func main() {
 		}
 	}()
 
+	// handle tracer sink close on exit to flush the remaining spans
+	defer func() {
+		log.Infow("closing tracer sink client before exiting", "sink", ts.GetSinkName())
+
+		if err := ts.Stop(); err != nil {
+			log.Errorw("error closing tracer sink client", "error", err, "sink", ts.GetSinkName())
+		}
+	}()
+
 	// execute command
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1) //nolint:gocritic
@@ -337,13 +354,20 @@ func initPodWatch(resourceVersion string) (<-chan watch.Event, error) {
 
 // inject all the disruptions using the list of injectors
 // returns true if injection succeeded, false otherwise
-func inject(kind string, sendToMetrics bool, reinjection bool) bool {
+func inject(ctx context.Context, kind string, sendToMetrics bool, reinjection bool) bool {
 	errOnInject := false
 
+	ctx, span := startSpan(ctx, "inject", kind, attribute.Bool("reinjection", reinjection))
+
 	for _, inj := range injectors {
+		_, injectorSpan := startSpan(ctx, "inject "+string(inj.GetDisruptionKind()), kind)
+
 		// start injection, do not fatal on error so we keep the pod
 		// running, allowing the cleanup to happen
-		if err := inj.Inject(); err != nil {
+		err := inj.Inject()
+		endSpan(injectorSpan, err)
+
+		if err != nil {
 			errOnInject = true
 
 			if sendToMetrics {
@@ -374,18 +398,27 @@ func inject(kind string, sendToMetrics bool, reinjection bool) bool {
 
 	if errOnInject {
 		log.Error("an injector could not inject the disruption successfully, please look at the logs above for more details")
+		endSpan(span, fmt.Errorf("an injector could not inject the disruption successfully"))
+	} else {
+		endSpan(span, nil)
 	}
 
 	return !errOnInject
 }
 
 // reinject reinitialize conf, clean and inject all the disruptions
-func reinject(cmdName string) error {
-	var err error
+func reinject(cmdName string) (err error) {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
+	ctx, span := startSpan(traceCtx, "reinject", cmdName)
+	defer func() { endSpan(span, err) }()
 
-	// Clean all injections to reinject on an empty slate
-	if ok := clean(cmdName, true, true); !ok {
-		log.Errorw("couldn't clean targets before reinjection. Reinjecting anyway")
+	// Clean all injections to reinject on an empty slate, a paused disruption is already cleaned
+	if !paused {
+		if ok := clean(ctx, cmdName, true, true); !ok {
+			log.Errorw("couldn't clean targets before reinjection. Reinjecting anyway")
+		}
 	}
 
 	// We rebuild and update the configuration
@@ -413,8 +446,15 @@ func reinject(cmdName string) error {
 		injectors[i].UpdateConfig(conf)
 	}
 
+	// a paused disruption is injected with the updated configuration once resumed
+	if paused {
+		log.Infow("the disruption is paused, skipping reinjection")
+
+		return nil
+	}
+
 	// Reinject target
-	if ok := inject(cmdName, true, true); !ok {
+	if ok := inject(ctx, cmdName, true, true); !ok {
 		return fmt.Errorf("couldn't reinject target")
 	}
 
@@ -423,12 +463,19 @@ func reinject(cmdName string) error {
 
 // clean will remove or undo all the disruptions using the list of injectors
 // returns true if cleanup succeeded, false otherwise
-func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
+func clean(ctx context.Context, kind string, sendToMetrics bool, reinjectionClean bool) bool {
 	errOnClean := false
 
+	ctx, span := startSpan(ctx, "clean", kind, attribute.Bool("reinjection", reinjectionClean))
+
 	for _, inj := range injectors {
+		_, injectorSpan := startSpan(ctx, "clean "+string(inj.GetDisruptionKind()), kind)
+
 		// start cleanup which is retried up to 3 times using an exponential backoff algorithm
-		if err := backoff.RetryNotify(inj.Clean, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 3), retryNotifyHandler); err != nil {
+		err := backoff.RetryNotify(inj.Clean, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 3), retryNotifyHandler)
+		endSpan(injectorSpan, err)
+
+		if err != nil {
 			errOnClean = true
 
 			if sendToMetrics {
@@ -455,6 +502,9 @@ func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
 
 	if errOnClean {
 		log.Errorw("an injector could not clean the disruption successfully, please look at the logs above for more details")
+		endSpan(span, fmt.Errorf("an injector could not clean the disruption successfully"))
+	} else {
+		endSpan(span, nil)
 	}
 
 	return !errOnClean
@@ -462,7 +512,10 @@ func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
 
 // pulse pulse disruptions (injection and cleaning)
 // nolint: unparam,staticcheck
-func pulse(isInjected *bool, sleepDuration *time.Duration, action func(string, bool, bool) bool, cmdName string) (func(string, bool, bool) bool, error) {
+func pulse(isInjected *bool, sleepDuration *time.Duration, action func(context.Context, string, bool, bool) bool, cmdName string) (_ func(context.Context, string, bool, bool) bool, err error) {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
 	actionName := ""
 
 	if !*isInjected {
@@ -475,12 +528,19 @@ func pulse(isInjected *bool, sleepDuration *time.Duration, action func(string, b
 		*sleepDuration = disruptionArgs.PulseActiveDuration
 	}
 
-	if ok := action(cmdName, true, true); !ok {
-		return nil, fmt.Errorf("error on pulsing disruption mechanism when attempting to %s", actionName)
+	ctx, span := startSpan(traceCtx, "pulse", cmdName, attribute.String("pulse_action", actionName))
+	defer func() { endSpan(span, err) }()
+
+	// a paused disruption stays cleaned, only the pulse phase is tracked to know if it must be injected on resume
+	if !paused {
+		if ok := action(ctx, cmdName, true, true); !ok {
+			return nil, fmt.Errorf("error on pulsing disruption mechanism when attempting to %s", actionName)
+		}
 	}
 
 	newInjected := !*isInjected
 	*isInjected = newInjected
+	pulseDormant = !newInjected
 
 	return action, nil
 }
@@ -521,9 +581,38 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 
 	processManager := process.NewManager(disruptionArgs.DryRun)
 
-	log.Infow("injecting the disruption", "kind", cmd.Name())
+	injectSuccess := true
+
+	// the disruption can be paused from the controller, in which case the injector waits for it to be resumed before injecting
+	watchPaused := os.Getenv(env.InjectorPodName) != "" && !v1beta1.DisruptionHasNoSideEffects(cmd.Name())
+	if watchPaused {
+		var err error
+
+		if paused, _, err = getChaosPodPausedState(); err != nil {
+			log.Warnw("couldn't check if the disruption is paused, injecting it anyway", "error", err)
+		}
+	}
+
+	injectionLock.Lock()
 
-	injectSuccess := inject(cmd.Name(), true, false)
+	if paused {
+		log.Infow("the disruption is paused, it will be injected once resumed", "kind", cmd.Name())
+	} else {
+		log.Infow("injecting the disruption", "kind", cmd.Name())
+
+		injectSuccess = inject(traceCtx, cmd.Name(), true, false)
+	}
+
+	injectionLock.Unlock()
+
+	// the pause watcher is started once the initial injection is done so it can't inject the disruption concurrently,
+	// it applies the current paused state when starting so no change happening during the initial injection is missed
+	if watchPaused {
+		pauseWatcherDone := make(chan struct{})
+		defer close(pauseWatcherDone)
+
+		go watchPause(cmd.Name(), pauseWatcherDone)
+	}
 
 	// create and write readiness probe file if injection succeeded so the pod is marked as ready
 	if injectSuccess {
@@ -560,7 +649,7 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 	case disruptionArgs.Level == chaostypes.DisruptionLevelNode:
 		if disruptionArgs.PulseActiveDuration > 0 && disruptionArgs.PulseDormantDuration > 0 {
 			var (
-				action func(string, bool, bool) bool
+				action func(context.Context, string, bool, bool) bool
 				err    error
 			)
 
@@ -686,7 +775,7 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 
 	var channel <-chan watch.Event
 
-	var actionOnPulse func(string, bool, bool) bool
+	var actionOnPulse func(context.Context, string, bool, bool) bool
 
 	for {
 		if channel == nil {
@@ -811,7 +900,7 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 // cleanAndExit cleans the disruption with the configured injector and exits nicely
 func cleanAndExit(cmd *cobra.Command, args []string) {
 	// 1 or more injectors failed to clean, we exit
-	if ok := clean(cmd.Name(), true, false); !ok {
+	if ok := clean(traceCtx, cmd.Name(), true, false); !ok {
 		os.Exit(1)
 	}
 
@@ -21,6 +21,7 @@ var networkDisruptionCmd = &cobra.Command{
 		hosts, _ := cmd.Flags().GetStringSlice("hosts")
 		allowedHosts, _ := cmd.Flags().GetStringSlice("allowed-hosts")
 		services, _ := cmd.Flags().GetStringSlice("services")
+		pods, _ := cmd.Flags().GetStringArray("pods")
 		drop, _ := cmd.Flags().GetInt("drop")
 		duplicate, _ := cmd.Flags().GetInt("duplicate")
 		corrupt, _ := cmd.Flags().GetInt("corrupt")
@@ -30,6 +31,9 @@ var networkDisruptionCmd = &cobra.Command{
 		hostResolveInterval, _ := cmd.Flags().GetDuration("host-resolve-interval")
 		method, _ := cmd.Flags().GetString("method")
 		path, _ := cmd.Flags().GetString("path")
+		tcpReset, _ := cmd.Flags().GetBool("tcp-reset")
+		tcpDropSYN, _ := cmd.Flags().GetBool("tcp-drop-syn")
+		tcpIdleTimeout, _ := cmd.Flags().GetUint("tcp-idle-timeout")
 
 		// prepare injectors
 		for i, config := range configs {
@@ -55,10 +59,16 @@ var networkDisruptionCmd = &cobra.Command{
 					log.Fatalw("error parsing services", "error", err)
 				}
 
+				parsedPods, err := v1beta1.NetworkDisruptionPodSpecFromString(pods)
+				if err != nil {
+					log.Fatalw("error parsing pods", "error", err)
+				}
+
 				spec = v1beta1.NetworkDisruptionSpec{
 					Hosts:          parsedHosts,
 					AllowedHosts:   parsedAllowedHosts,
 					Services:       parsedServices,
+					Pods:           parsedPods,
 					Drop:           drop,
 					Duplicate:      duplicate,
 					Corrupt:        corrupt,
@@ -70,6 +80,14 @@ var networkDisruptionCmd = &cobra.Command{
 						Path:   path,
 					},
 				}
+
+				if tcpReset || tcpDropSYN || tcpIdleTimeout > 0 {
+					spec.TCPFaults = &v1beta1.NetworkTCPFaults{
+						Reset:       tcpReset,
+						DropSYN:     tcpDropSYN,
+						IdleTimeout: tcpIdleTimeout,
+					}
+				}
 			}
 
 			// generate injector
@@ -87,6 +105,7 @@ func init() {
 	networkDisruptionCmd.Flags().StringSlice("hosts", []string{}, "List of hosts (hostname, single IP or IP block) with port and protocol to apply disruptions to (format: <host>;<port>;<protocol>;<flow>;<connState>)")
 	networkDisruptionCmd.Flags().StringSlice("allowed-hosts", []string{}, "List of allowed hosts not being impacted by the disruption (hostname, single IP or IP block) with port and protocol to apply disruptions to (format: <host>;<port>;<protocol>;<flow>)")
 	networkDisruptionCmd.Flags().StringSlice("services", []string{}, "List of services to apply disruptions to (format: <name>;<namespace>;port-allowed;port-allowed;)")
+	networkDisruptionCmd.Flags().StringArray("pods", []string{}, "List of pods selectors to apply disruptions to (format: <namespace>;<key>=<value>,<key>=<value>;<port>-<protocol>;<port>-<protocol>)")
 	networkDisruptionCmd.Flags().Int("drop", 100, "Percentage to drop packets (100 is a total drop)")
 	networkDisruptionCmd.Flags().Int("duplicate", 100, "Percentage to duplicate packets (100 is duplicating each packet)")
 	networkDisruptionCmd.Flags().Int("corrupt", 100, "Percentage to corrupt packets (100 is a total corruption)")
@@ -96,4 +115,7 @@ func init() {
 	networkDisruptionCmd.Flags().Duration("host-resolve-interval", time.Minute, "Interval to resolve hostnames")
 	networkDisruptionCmd.Flags().String("method", "ALL", "Filter by http method")
 	networkDisruptionCmd.Flags().String("path", "/", "Filter by path and must not exceed 100 characters")
+	networkDisruptionCmd.Flags().Bool("tcp-reset", false, "Reset established tcp connections")
+	networkDisruptionCmd.Flags().Bool("tcp-drop-syn", false, "Drop tcp packets opening new connections only")
+	networkDisruptionCmd.Flags().Uint("tcp-idle-timeout", 0, "Reset tcp connections idle for more than the given number of seconds")
 }
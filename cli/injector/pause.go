@@ -0,0 +1,145 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/DataDog/chaos-controller/env"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var (
+	// injectionLock prevents the pause watcher from injecting or cleaning while the pulse or the reinjection mechanisms do
+	injectionLock sync.Mutex
+	// paused is true when the injections have been cleaned because the disruption is paused
+	paused bool
+	// pulseDormant is true when a pulsing disruption is in its dormant phase, so it must not be injected on resume
+	pulseDormant bool
+)
+
+// getChaosPodPausedState returns true if the chaos pod running this injector has the paused annotation, along with its resource version
+func getChaosPodPausedState() (bool, string, error) {
+	pod, err := clientset.CoreV1().Pods(disruptionArgs.ChaosNamespace).Get(context.Background(), os.Getenv(env.InjectorPodName), metav1.GetOptions{})
+	if err != nil {
+		return false, "", err
+	}
+
+	_, found := pod.Annotations[chaostypes.PausedAnnotation]
+
+	return found, pod.ResourceVersion, nil
+}
+
+// watchPause watches the chaos pod running this injector until the given channel is closed
+// it cleans the disruption when the paused annotation is added and injects it again once the annotation is removed
+func watchPause(kind string, done <-chan struct{}) {
+	var channel <-chan watch.Event
+
+	for {
+		if channel == nil {
+			var err error
+
+			if channel, err = initPauseWatch(kind); err != nil {
+				log.Warnw("couldn't watch the chaos pod to detect the disruption being paused, retrying", "error", err)
+
+				select {
+				case <-done:
+					return
+				case <-time.After(5 * time.Second):
+					continue
+				}
+			}
+		}
+
+		select {
+		case <-done:
+			return
+		case event, ok := <-channel:
+			if !ok {
+				channel = nil
+
+				continue
+			}
+
+			pod, ok := event.Object.(*v1.Pod)
+			if !ok || event.Type != watch.Modified {
+				continue
+			}
+
+			_, isPaused := pod.Annotations[chaostypes.PausedAnnotation]
+			if err := setPaused(kind, isPaused); err != nil {
+				log.Errorw("error applying the disruption paused state", "paused", isPaused, "error", err)
+			}
+		}
+	}
+}
+
+// initPauseWatch applies the current paused state of the chaos pod and starts watching it from there,
+// so no change is missed when the watch is (re)started
+func initPauseWatch(kind string) (<-chan watch.Event, error) {
+	isPaused, resourceVersion, err := getChaosPodPausedState()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setPaused(kind, isPaused); err != nil {
+		log.Errorw("error applying the disruption paused state", "paused", isPaused, "error", err)
+	}
+
+	podWatcher, err := clientset.CoreV1().Pods(disruptionArgs.ChaosNamespace).Watch(context.Background(), metav1.ListOptions{
+		FieldSelector:   "metadata.name=" + os.Getenv(env.InjectorPodName),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return podWatcher.ResultChan(), nil
+}
+
+// setPaused cleans the disruption when it gets paused and injects it again when it gets resumed
+func setPaused(kind string, isPaused bool) error {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
+	if paused == isPaused {
+		return nil
+	}
+
+	paused = isPaused
+
+	if isPaused {
+		log.Infow("the disruption has been paused, cleaning it")
+
+		if ok := clean(traceCtx, kind, true, true); !ok {
+			return fmt.Errorf("couldn't clean the disruption on pause")
+		}
+
+		return nil
+	}
+
+	// a pulsing disruption resumed during its dormant phase is injected by the pulse mechanism when the phase ends
+	if pulseDormant {
+		log.Infow("the disruption has been resumed, waiting for the pulse active phase to inject it again")
+
+		return nil
+	}
+
+	log.Infow("the disruption has been resumed, injecting it again")
+
+	if ok := inject(traceCtx, kind, true, true); !ok {
+		return fmt.Errorf("couldn't inject the disruption on resume")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/DataDog/chaos-controller/o11y/tracer"
+	tracertypes "github.com/DataDog/chaos-controller/o11y/tracer/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "chaos-injector"
+
+var (
+	ts tracer.Sink
+	// traceCtx holds the disruption span context given by the controller, injector phases spans are its children
+	traceCtx = context.Background()
+)
+
+// initTracerSink initializes a tracer sink depending on the given flags and extracts the disruption span context
+func initTracerSink() {
+	var err error
+
+	ts, err = tracer.GetSink(log, tracertypes.SinkDriver(disruptionArgs.TracerSink), disruptionArgs.TracerOTLP)
+	if err != nil {
+		log.Errorw("error while creating tracer sink, switching to noop sink", "error", err, "driver", disruptionArgs.TracerSink)
+
+		if ts, err = tracer.GetSink(log, tracertypes.SinkDriverNoop, disruptionArgs.TracerOTLP); err != nil {
+			log.Fatalw("error while creating noop tracer sink", "error", err)
+		}
+	}
+
+	otel.SetTracerProvider(ts.GetProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if disruptionArgs.SpanContext == "" {
+		return
+	}
+
+	carrier := propagation.MapCarrier{}
+
+	if err := json.Unmarshal([]byte(disruptionArgs.SpanContext), &carrier); err != nil {
+		log.Warnw("unable to unmarshal the disruption span context, injector spans won't be attached to the disruption trace", "error", err)
+
+		return
+	}
+
+	traceCtx = otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+}
+
+// startSpan starts a span for the given injector phase, labelled with the disruption and target
+func startSpan(ctx context.Context, name, kind string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes,
+		attribute.String("disruption_name", disruptionArgs.DisruptionName),
+		attribute.String("disruption_namespace", disruptionArgs.DisruptionNamespace),
+		attribute.String("disruption_kind", kind),
+		attribute.String("target_name", disruptionArgs.TargetName),
+		attribute.String("target_node_name", disruptionArgs.TargetNodeName),
+	)
+
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan ends the given span, marking it as failed if an error is given
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
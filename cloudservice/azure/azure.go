@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package azure
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/chaos-controller/cloudservice/types"
+)
+
+type CloudProviderIPRangeManager struct {
+}
+
+// AzureServiceTagProperties from the model of the service tags file from Azure
+type AzureServiceTagProperties struct {
+	AddressPrefixes []string `json:"addressPrefixes"`
+}
+
+// AzureServiceTag from the model of the service tags file from Azure
+type AzureServiceTag struct {
+	Name       string                    `json:"name"`
+	Properties AzureServiceTagProperties `json:"properties"`
+}
+
+// AzureServiceTags from the model of the service tags file from Azure
+type AzureServiceTags struct {
+	ChangeNumber int               `json:"changeNumber"`
+	Values       []AzureServiceTag `json:"values"`
+}
+
+func New() *CloudProviderIPRangeManager {
+	return &CloudProviderIPRangeManager{}
+}
+
+// IsNewVersion Check if the ip ranges pulled are newer than the one we already have
+func (s *CloudProviderIPRangeManager) IsNewVersion(newIPRanges []byte, oldVersion string) (bool, error) {
+	serviceTags := AzureServiceTags{}
+	if err := json.Unmarshal(newIPRanges, &serviceTags); err != nil {
+		return false, err
+	}
+
+	return strconv.Itoa(serviceTags.ChangeNumber) != oldVersion, nil
+}
+
+// ConvertToGenericIPRanges From an unmarshalled json service tags file from Azure to a generic ip range struct
+func (s *CloudProviderIPRangeManager) ConvertToGenericIPRanges(unparsedIPRanges []byte) (*types.CloudProviderIPRangeInfo, error) {
+	serviceTags := AzureServiceTags{}
+	if err := json.Unmarshal(unparsedIPRanges, &serviceTags); err != nil {
+		return nil, err
+	}
+
+	result := &types.CloudProviderIPRangeInfo{
+		Version:     strconv.Itoa(serviceTags.ChangeNumber),
+		IPRanges:    map[string][]string{},
+		ServiceList: []string{},
+	}
+
+	for _, serviceTag := range serviceTags.Values {
+		ipRanges := []string{}
+
+		// Remove IPv6 prefixes as only IPv4 is supported
+		for _, prefix := range serviceTag.Properties.AddressPrefixes {
+			if strings.Contains(prefix, ":") {
+				continue
+			}
+
+			ipRanges = append(ipRanges, prefix)
+		}
+
+		if len(ipRanges) == 0 {
+			continue
+		}
+
+		// service tags can be scoped to a region (e.g. Storage.WestUS), each of them being considered as a dedicated service
+		if _, ok := result.IPRanges[serviceTag.Name]; !ok {
+			result.ServiceList = append(result.ServiceList, serviceTag.Name)
+		}
+
+		result.IPRanges[serviceTag.Name] = append(result.IPRanges[serviceTag.Name], ipRanges...)
+	}
+
+	return result, nil
+}
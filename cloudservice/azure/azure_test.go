@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAzure(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CloudService Azure Suite")
+}
+
+var _ = Describe("Azure Parsing", func() {
+	serviceTagsFile := "{\"changeNumber\":254,\"cloud\":\"Public\",\"values\":[{\"name\":\"AzureStorage\",\"id\":\"AzureStorage\",\"properties\":{\"changeNumber\":10,\"region\":\"\",\"platform\":\"Azure\",\"systemService\":\"AzureStorage\",\"addressPrefixes\":[\"13.65.24.0/26\",\"13.66.128.0/17\",\"2603:1000:4::/47\"]}},{\"name\":\"Storage.WestUS\",\"id\":\"Storage.WestUS\",\"properties\":{\"changeNumber\":3,\"region\":\"westus\",\"platform\":\"Azure\",\"systemService\":\"AzureStorage\",\"addressPrefixes\":[\"13.93.0.0/17\"]}},{\"name\":\"AzureIPv6Only\",\"id\":\"AzureIPv6Only\",\"properties\":{\"changeNumber\":1,\"addressPrefixes\":[\"2603:1000:4::/47\"]}}]}"
+
+	Context("Parse Azure service tags file", func() {
+		It("should parse the service tags file", func() {
+			azureManager := New()
+
+			info, err := azureManager.ConvertToGenericIPRanges([]byte(serviceTagsFile))
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the right version string was parsed")
+			Expect(info.Version).To(Equal("254"))
+
+			By("Ensuring that we have the right info")
+			Expect(info.ServiceList).To(ConsistOf("AzureStorage", "Storage.WestUS"))
+			Expect(info.IPRanges["AzureStorage"]).To(ConsistOf("13.65.24.0/26", "13.66.128.0/17"))
+			Expect(info.IPRanges["Storage.WestUS"]).To(ConsistOf("13.93.0.0/17"))
+		})
+
+		It("should remove service tags without ipv4 prefixes", func() {
+			azureManager := New()
+
+			info, err := azureManager.ConvertToGenericIPRanges([]byte(serviceTagsFile))
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the ipv6 only service tag was removed")
+			Expect(info.IPRanges).ToNot(HaveKey("AzureIPv6Only"))
+		})
+	})
+
+	Context("Verify Azure New version of the file", func() {
+		It("Should indicate is a new version", func() {
+			azureManager := New()
+
+			isNewVersion, err := azureManager.IsNewVersion([]byte(serviceTagsFile), "253")
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the version is new")
+			Expect(isNewVersion).To(BeTrue())
+		})
+
+		It("Should indicate is not a new version", func() {
+			azureManager := New()
+
+			isNewVersion, err := azureManager.IsNewVersion([]byte(serviceTagsFile), "254")
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the version is not new")
+			Expect(isNewVersion).To(BeFalse())
+		})
+	})
+
+	Context("Verify Azure handle of errors", func() {
+		It("Should throw an error on empty service tags file", func() {
+			azureManager := New()
+
+			_, errConvert := azureManager.ConvertToGenericIPRanges([]byte(""))
+			_, errIsNewVersion := azureManager.IsNewVersion([]byte(""), "20")
+
+			By("Ensuring that an error was thrown on ConvertToGenericIPRanges")
+			Expect(errConvert).To(HaveOccurred())
+
+			By("Ensuring that an error was thrown on IsNewVersion")
+			Expect(errIsNewVersion).To(HaveOccurred())
+		})
+	})
+})
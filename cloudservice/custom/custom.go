@@ -0,0 +1,246 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package custom
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/chaos-controller/cloudservice/types"
+)
+
+const (
+	// DefaultService is the service assigned to every ip range when no service mapping nor default service is configured
+	DefaultService = "Custom"
+)
+
+// CloudProviderIPRangeManager parses any json ip ranges file using the configured JSONPath-like mapping
+type CloudProviderIPRangeManager struct {
+	mapping types.CustomCloudProviderMapping
+}
+
+func New(mapping types.CustomCloudProviderMapping) *CloudProviderIPRangeManager {
+	return &CloudProviderIPRangeManager{
+		mapping: mapping,
+	}
+}
+
+// IsNewVersion Check if the ip ranges pulled are newer than the one we already have
+func (s *CloudProviderIPRangeManager) IsNewVersion(newIPRanges []byte, oldVersion string) (bool, error) {
+	version, err := s.version(newIPRanges)
+	if err != nil {
+		return false, err
+	}
+
+	return version != oldVersion, nil
+}
+
+// ConvertToGenericIPRanges From an unmarshalled json ip range file to a generic ip range struct using the configured mapping
+func (s *CloudProviderIPRangeManager) ConvertToGenericIPRanges(unparsedIPRanges []byte) (*types.CloudProviderIPRangeInfo, error) {
+	var document interface{}
+	if err := json.Unmarshal(unparsedIPRanges, &document); err != nil {
+		return nil, err
+	}
+
+	version, err := s.version(unparsedIPRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.mapping.Prefixes == "" {
+		return nil, errors.New("the prefixes mapping of the custom cloud provider must be set")
+	}
+
+	entries, err := Lookup(document, s.mapping.Prefixes)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the prefixes of the custom ip ranges file: %w", err)
+	}
+
+	result := &types.CloudProviderIPRangeInfo{
+		Version:     version,
+		IPRanges:    map[string][]string{},
+		ServiceList: []string{},
+	}
+
+	defaultService := s.mapping.DefaultService
+	if defaultService == "" {
+		defaultService = DefaultService
+	}
+
+	for _, entry := range entries {
+		service := defaultService
+
+		if s.mapping.Service != "" {
+			services, err := lookupStrings(entry, s.mapping.Service)
+			if err != nil {
+				return nil, fmt.Errorf("error looking up the service of a custom ip ranges file entry: %w", err)
+			}
+
+			if len(services) > 0 && services[0] != "" {
+				service = services[0]
+			}
+		}
+
+		ipPrefixes := []string{}
+
+		// a prefixes entry can directly be the ip prefix when no ip prefix mapping is set
+		if s.mapping.IPPrefix == "" {
+			ipPrefixes, err = toStrings([]interface{}{entry})
+		} else {
+			ipPrefixes, err = lookupStrings(entry, s.mapping.IPPrefix)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error looking up the ip prefix of a custom ip ranges file entry: %w", err)
+		}
+
+		for _, ipPrefix := range ipPrefixes {
+			// Remove empty and IPv6 prefixes as only IPv4 is supported
+			if ipPrefix == "" || strings.Contains(ipPrefix, ":") {
+				continue
+			}
+
+			if _, ok := result.IPRanges[service]; !ok {
+				result.ServiceList = append(result.ServiceList, service)
+			}
+
+			result.IPRanges[service] = append(result.IPRanges[service], ipPrefix)
+		}
+	}
+
+	return result, nil
+}
+
+// version returns the version of the given ip ranges file using the version mapping,
+// or a hash of the whole file if no version mapping is set
+func (s *CloudProviderIPRangeManager) version(unparsedIPRanges []byte) (string, error) {
+	var document interface{}
+	if err := json.Unmarshal(unparsedIPRanges, &document); err != nil {
+		return "", err
+	}
+
+	if s.mapping.Version == "" {
+		return fmt.Sprintf("%x", sha256.Sum256(unparsedIPRanges)), nil
+	}
+
+	versions, err := lookupStrings(document, s.mapping.Version)
+	if err != nil {
+		return "", fmt.Errorf("error looking up the version of the custom ip ranges file: %w", err)
+	}
+
+	if len(versions) != 1 {
+		return "", fmt.Errorf("expected a single version in the custom ip ranges file, found %d", len(versions))
+	}
+
+	return versions[0], nil
+}
+
+// Lookup evaluates the given JSONPath-like expression on the given unmarshalled json document
+// Supported expressions are dot separated keys, optionally prefixed by $, where each key can be suffixed
+// by [*] to select all items of an array or by [n] to select the nth item of an array, e.g. $.prefixes[*].ip_prefix
+func Lookup(document interface{}, path string) ([]interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	values := []interface{}{document}
+
+	if path == "" {
+		return values, nil
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		key, index, hasIndex := strings.Cut(segment, "[")
+
+		// select the key in every current object
+		if key != "" {
+			next := []interface{}{}
+
+			for _, value := range values {
+				object, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("unable to select key %s in %s: not an object", key, path)
+				}
+
+				if field, ok := object[key]; ok {
+					next = append(next, field)
+				}
+			}
+
+			values = next
+		}
+
+		if !hasIndex {
+			continue
+		}
+
+		// select one or all the items of every current array
+		index = strings.TrimSuffix(index, "]")
+		next := []interface{}{}
+
+		for _, value := range values {
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unable to select index %s in %s: not an array", index, path)
+			}
+
+			if index == "*" {
+				next = append(next, array...)
+
+				continue
+			}
+
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected index %s in %s: %w", index, path, err)
+			}
+
+			if i >= 0 && i < len(array) {
+				next = append(next, array[i])
+			}
+		}
+
+		values = next
+	}
+
+	return values, nil
+}
+
+// lookupStrings evaluates the given expression and converts the selected values to strings, flattening arrays
+func lookupStrings(document interface{}, path string) ([]string, error) {
+	values, err := Lookup(document, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStrings(values)
+}
+
+// toStrings converts the given values to strings, flattening arrays
+func toStrings(values []interface{}) ([]string, error) {
+	result := []string{}
+
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			result = append(result, v)
+		case float64:
+			result = append(result, strconv.FormatFloat(v, 'f', -1, 64))
+		case []interface{}:
+			items, err := toStrings(v)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, items...)
+		default:
+			return nil, fmt.Errorf("unexpected value type %T, expected a string, a number or an array of them", value)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package custom
+
+import (
+	"testing"
+
+	"github.com/DataDog/chaos-controller/cloudservice/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCustom(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CloudService Custom Suite")
+}
+
+var _ = Describe("Custom Parsing", func() {
+	ipRangesFile := "{\"meta\":{\"version\":\"2023-05-01\"},\"prefixes\":[{\"ip_prefix\":\"10.0.0.0/24\",\"service\":\"billing\"},{\"ip_prefix\":[\"10.0.1.0/24\",\"10.0.2.0/24\",\"2001:db8::/32\"],\"service\":\"billing\"},{\"ip_prefix\":\"10.1.0.0/16\",\"service\":\"auth\"},{\"ip_prefix\":\"10.2.0.0/16\"}]}"
+	mapping := types.CustomCloudProviderMapping{
+		Version:  "$.meta.version",
+		Prefixes: "$.prefixes[*]",
+		Service:  "$.service",
+		IPPrefix: "$.ip_prefix",
+	}
+
+	Context("Parse custom ip ranges file", func() {
+		It("should parse the ip ranges file using the mapping", func() {
+			customManager := New(mapping)
+
+			info, err := customManager.ConvertToGenericIPRanges([]byte(ipRangesFile))
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the right version string was parsed")
+			Expect(info.Version).To(Equal("2023-05-01"))
+
+			By("Ensuring that we have the right info")
+			Expect(info.ServiceList).To(ConsistOf("billing", "auth", DefaultService))
+			Expect(info.IPRanges["billing"]).To(ConsistOf("10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"))
+			Expect(info.IPRanges["auth"]).To(ConsistOf("10.1.0.0/16"))
+			Expect(info.IPRanges[DefaultService]).To(ConsistOf("10.2.0.0/16"))
+		})
+
+		It("should use the configured default service", func() {
+			customMapping := mapping
+			customMapping.DefaultService = "internal"
+			customManager := New(customMapping)
+
+			info, err := customManager.ConvertToGenericIPRanges([]byte(ipRangesFile))
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that entries without service use the default service")
+			Expect(info.IPRanges["internal"]).To(ConsistOf("10.2.0.0/16"))
+		})
+
+		It("should parse a flat list of prefixes", func() {
+			customManager := New(types.CustomCloudProviderMapping{
+				Prefixes: "$.cidrs[*]",
+			})
+
+			info, err := customManager.ConvertToGenericIPRanges([]byte("{\"cidrs\":[\"192.168.0.0/16\",\"172.16.0.0/12\"]}"))
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that a hash of the file is used as version")
+			Expect(info.Version).To(HaveLen(64))
+
+			By("Ensuring that all prefixes belong to the default service")
+			Expect(info.ServiceList).To(ConsistOf(DefaultService))
+			Expect(info.IPRanges[DefaultService]).To(ConsistOf("192.168.0.0/16", "172.16.0.0/12"))
+		})
+	})
+
+	Context("Verify custom New version of the file", func() {
+		It("Should indicate is a new version", func() {
+			customManager := New(mapping)
+
+			isNewVersion, err := customManager.IsNewVersion([]byte(ipRangesFile), "2023-04-01")
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the version is new")
+			Expect(isNewVersion).To(BeTrue())
+		})
+
+		It("Should indicate is not a new version", func() {
+			customManager := New(mapping)
+
+			isNewVersion, err := customManager.IsNewVersion([]byte(ipRangesFile), "2023-05-01")
+
+			By("Ensuring that no error was thrown")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Ensuring that the version is not new")
+			Expect(isNewVersion).To(BeFalse())
+		})
+	})
+
+	Context("Verify custom handle of errors", func() {
+		It("Should throw an error on empty ip ranges file", func() {
+			customManager := New(mapping)
+
+			_, errConvert := customManager.ConvertToGenericIPRanges([]byte(""))
+			_, errIsNewVersion := customManager.IsNewVersion([]byte(""), "20")
+
+			By("Ensuring that an error was thrown on ConvertToGenericIPRanges")
+			Expect(errConvert).To(HaveOccurred())
+
+			By("Ensuring that an error was thrown on IsNewVersion")
+			Expect(errIsNewVersion).To(HaveOccurred())
+		})
+
+		It("Should throw an error when the prefixes mapping is missing", func() {
+			customManager := New(types.CustomCloudProviderMapping{})
+
+			_, err := customManager.ConvertToGenericIPRanges([]byte(ipRangesFile))
+
+			By("Ensuring that an error was thrown")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should throw an error when the mapping does not match the file", func() {
+			customManager := New(types.CustomCloudProviderMapping{
+				Prefixes: "$.meta.version[*]",
+			})
+
+			_, err := customManager.ConvertToGenericIPRanges([]byte(ipRangesFile))
+
+			By("Ensuring that an error was thrown")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
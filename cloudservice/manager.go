@@ -6,26 +6,45 @@
 package cloudservice
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/DataDog/chaos-controller/cloudservice/aws"
+	"github.com/DataDog/chaos-controller/cloudservice/azure"
+	"github.com/DataDog/chaos-controller/cloudservice/custom"
 	"github.com/DataDog/chaos-controller/cloudservice/datadog"
 	"github.com/DataDog/chaos-controller/cloudservice/gcp"
 	"github.com/DataDog/chaos-controller/cloudservice/types"
+	"github.com/DataDog/chaos-controller/network/cidr"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FileSourcePrefix prefix of an ip ranges url to read the file from the controller filesystem, e.g. file:///etc/ip-ranges/aws.json
+	FileSourcePrefix = "file://"
+	// ConfigMapSourcePrefix prefix of an ip ranges url to read the file from a ConfigMap key, e.g. configmap://chaos-engineering/ip-ranges/aws.json
+	ConfigMapSourcePrefix = "configmap://"
 )
 
 // CloudServicesProvidersManager Manager used to pull and parse any provider ip ranges per service
 type CloudServicesProvidersManager struct {
 	cloudProviders       map[types.CloudProviderName]*CloudServicesProvider
 	log                  *zap.SugaredLogger
+	reader               client.Reader
 	stopPeriodicPull     chan bool
 	periodicPullInterval time.Duration
+	mutex                sync.RWMutex
 }
 
 // CloudServicesProvider Data and ip ranges manager of one cloud provider
@@ -33,6 +52,7 @@ type CloudServicesProvider struct {
 	CloudProviderIPRangeManager CloudProviderIPRangeManager
 	IPRangeInfo                 *types.CloudProviderIPRangeInfo
 	Conf                        types.CloudProviderConfig
+	Status                      types.CloudProviderIPRangeStatus
 }
 
 // CloudProviderIPRangeManager Methods to verify and transform a specifid ip ranges list from a provider
@@ -41,10 +61,14 @@ type CloudProviderIPRangeManager interface {
 	ConvertToGenericIPRanges([]byte) (*types.CloudProviderIPRangeInfo, error)
 }
 
-func New(log *zap.SugaredLogger, config types.CloudProviderConfigs) (*CloudServicesProvidersManager, error) {
+// New creates the cloud providers manager and pulls the ip ranges of all enabled providers once,
+// the given reader is only used for the providers using a ConfigMap as source and can be nil otherwise
+func New(log *zap.SugaredLogger, config types.CloudProviderConfigs, reader client.Reader) (*CloudServicesProvidersManager, error) {
 	manager := &CloudServicesProvidersManager{
 		cloudProviders:       map[types.CloudProviderName]*CloudServicesProvider{},
 		log:                  log,
+		reader:               reader,
+		stopPeriodicPull:     make(chan bool),
 		periodicPullInterval: config.PullInterval,
 	}
 
@@ -71,6 +95,14 @@ func New(log *zap.SugaredLogger, config types.CloudProviderConfigs) (*CloudServi
 			provider.CloudProviderIPRangeManager = datadog.New()
 			provider.Conf.Enabled = config.Datadog.Enabled
 			provider.Conf.IPRangesURL = config.Datadog.IPRangesURL
+		case types.CloudProviderAzure:
+			provider.CloudProviderIPRangeManager = azure.New()
+			provider.Conf.Enabled = config.Azure.Enabled
+			provider.Conf.IPRangesURL = config.Azure.IPRangesURL
+		case types.CloudProviderCustom:
+			provider.CloudProviderIPRangeManager = custom.New(config.Custom.Mapping)
+			provider.Conf.Enabled = config.Custom.Enabled
+			provider.Conf.IPRangesURL = config.Custom.IPRangesURL
 		}
 
 		if !provider.Conf.Enabled {
@@ -92,6 +124,12 @@ func New(log *zap.SugaredLogger, config types.CloudProviderConfigs) (*CloudServi
 
 // StartPeriodicPull go routine pulling every interval all ip ranges of all cloud providers set up.
 func (s *CloudServicesProvidersManager) StartPeriodicPull() {
+	if s.periodicPullInterval <= 0 {
+		s.log.Infow("periodic pull of the cloud provider ip ranges is disabled", "interval", s.periodicPullInterval.String())
+
+		return
+	}
+
 	s.log.Infow("starting periodic pull and parsing of the cloud provider ip ranges", "interval", s.periodicPullInterval.String())
 
 	go func() {
@@ -112,6 +150,10 @@ func (s *CloudServicesProvidersManager) StartPeriodicPull() {
 
 // StopPeriodicPull stop the goroutine pulling all ip ranges of all cloud providers
 func (s *CloudServicesProvidersManager) StopPeriodicPull() {
+	if s.periodicPullInterval <= 0 {
+		return
+	}
+
 	s.log.Infow("closing periodic pull and parsing of the cloud provider ip ranges")
 
 	s.stopPeriodicPull <- true
@@ -140,6 +182,9 @@ func (s *CloudServicesProvidersManager) PullIPRanges() error {
 
 // GetServicesIPRanges with a given list of service names and cloud provider name, returns the list of ip ranges of those services
 func (s *CloudServicesProvidersManager) GetServicesIPRanges(cloudProviderName types.CloudProviderName, serviceNames []string) (map[string][]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	if s.cloudProviders[cloudProviderName] == nil {
 		return nil, fmt.Errorf("cloud provider %s is not configured or does not exist", cloudProviderName)
 	}
@@ -149,6 +194,10 @@ func (s *CloudServicesProvidersManager) GetServicesIPRanges(cloudProviderName ty
 	}
 
 	IPRangeInfo := s.cloudProviders[cloudProviderName].IPRangeInfo
+	if IPRangeInfo == nil {
+		return nil, fmt.Errorf("no ip ranges loaded yet for cloud provider %s", cloudProviderName)
+	}
+
 	IPRanges := map[string][]string{}
 
 	for _, serviceName := range serviceNames {
@@ -158,7 +207,7 @@ func (s *CloudServicesProvidersManager) GetServicesIPRanges(cloudProviderName ty
 		}
 
 		if _, ok := IPRangeInfo.IPRanges[serviceName]; !ok {
-			return nil, fmt.Errorf("service %s from %s does not exist, available services are: %s", serviceName, cloudProviderName, strings.Join(s.GetServiceList(cloudProviderName), ", "))
+			return nil, fmt.Errorf("service %s from %s does not exist, available services are: %s", serviceName, cloudProviderName, strings.Join(IPRangeInfo.ServiceList, ", "))
 		}
 
 		IPRanges[serviceName] = IPRangeInfo.IPRanges[serviceName]
@@ -169,6 +218,9 @@ func (s *CloudServicesProvidersManager) GetServicesIPRanges(cloudProviderName ty
 
 // GetServiceList return the list of services of a specific cloud provider. Mostly used in disruption creation validation
 func (s *CloudServicesProvidersManager) GetServiceList(cloudProviderName types.CloudProviderName) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	if s.cloudProviders[cloudProviderName] == nil || s.cloudProviders[cloudProviderName].IPRangeInfo == nil {
 		return nil
 	}
@@ -176,41 +228,125 @@ func (s *CloudServicesProvidersManager) GetServiceList(cloudProviderName types.C
 	return s.cloudProviders[cloudProviderName].IPRangeInfo.ServiceList
 }
 
+// GetStatus returns the status of the ip ranges loaded for every enabled cloud provider
+func (s *CloudServicesProvidersManager) GetStatus() map[types.CloudProviderName]types.CloudProviderIPRangeStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	status := map[types.CloudProviderName]types.CloudProviderIPRangeStatus{}
+
+	for cloudProviderName, provider := range s.cloudProviders {
+		status[cloudProviderName] = provider.Status
+	}
+
+	return status
+}
+
+// StatusHandler returns an http handler serving the status of the ip ranges loaded for every enabled cloud provider as json
+func (s *CloudServicesProvidersManager) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(s.GetStatus()); err != nil {
+			s.log.Errorw("error encoding the cloud providers status", "error", err)
+		}
+	})
+}
+
 // pullIPRangesPerCloudProvider pull ip ranges of one cloud provider
 func (s *CloudServicesProvidersManager) pullIPRangesPerCloudProvider(cloudProviderName types.CloudProviderName) error {
+	s.mutex.RLock()
 	provider := s.cloudProviders[cloudProviderName]
+	s.mutex.RUnlock()
+
 	if provider == nil {
 		return fmt.Errorf("cloud provider %s does not exist", cloudProviderName)
 	}
 
-	s.log.Debugw("pulling ip ranges from provider", "provider", cloudProviderName)
+	s.log.Debugw("pulling ip ranges from provider", "provider", cloudProviderName, "source", provider.Conf.IPRangesURL)
+
+	ipRangeInfo, err := s.loadIPRanges(provider)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	provider.Status.Source = provider.Conf.IPRangesURL
+	provider.Status.LastPullTime = time.Now()
+	provider.Status.LastPullError = ""
 
-	unparsedIPRange, err := s.requestIPRangesFromProvider(provider.Conf.IPRangesURL)
 	if err != nil {
+		provider.Status.LastPullError = err.Error()
+
 		return err
 	}
 
-	if provider.IPRangeInfo != nil {
-		isNewVersion, err := provider.CloudProviderIPRangeManager.IsNewVersion(unparsedIPRange, provider.IPRangeInfo.Version)
+	// the loaded ip ranges did not change
+	if ipRangeInfo == nil {
+		return nil
+	}
+
+	provider.IPRangeInfo = ipRangeInfo
+	provider.Status.Version = ipRangeInfo.Version
+	provider.Status.ServiceCount = len(ipRangeInfo.ServiceList)
+	provider.Status.LoadTime = provider.Status.LastPullTime
+
+	s.log.Infow("loaded new ip ranges version", "provider", cloudProviderName, "source", provider.Conf.IPRangesURL, "version", ipRangeInfo.Version)
+
+	return nil
+}
+
+// loadIPRanges reads and parses the ip ranges file of the given provider, returning nil if the version did not change
+func (s *CloudServicesProvidersManager) loadIPRanges(provider *CloudServicesProvider) (*types.CloudProviderIPRangeInfo, error) {
+	unparsedIPRange, err := s.requestIPRangesFromProvider(provider.Conf.IPRangesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	currentIPRangeInfo := provider.IPRangeInfo
+	s.mutex.RUnlock()
+
+	if currentIPRangeInfo != nil {
+		isNewVersion, err := provider.CloudProviderIPRangeManager.IsNewVersion(unparsedIPRange, currentIPRangeInfo.Version)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if !isNewVersion {
-			s.log.Debugw("no changes of ip ranges", "provider", cloudProviderName)
-			s.log.Debugw("finished pulling new version", "provider", cloudProviderName)
+			s.log.Debugw("no changes of ip ranges", "source", provider.Conf.IPRangesURL, "version", currentIPRangeInfo.Version)
 
-			return nil
+			return nil, nil
 		}
 	}
 
-	provider.IPRangeInfo, err = provider.CloudProviderIPRangeManager.ConvertToGenericIPRanges(unparsedIPRange)
+	ipRangeInfo, err := provider.CloudProviderIPRangeManager.ConvertToGenericIPRanges(unparsedIPRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// collapse overlapping and adjacent ip ranges of every service to reduce the number of filters needed to disrupt them
+	for service, ipRanges := range ipRangeInfo.IPRanges {
+		if ipRangeInfo.IPRanges[service], err = cidr.CollapseStrings(ipRanges); err != nil {
+			return nil, fmt.Errorf("error parsing the ip ranges of service %s: %w", service, err)
+		}
+	}
 
-	return err
+	return ipRangeInfo, nil
 }
 
-// requestIPRangesFromProvider launches a HTTP GET request to pull the ip range json file from a url
+// requestIPRangesFromProvider reads the ip range json file from its source:
+// - from the controller filesystem if the url uses the file:// scheme
+// - from a ConfigMap key if the url uses the configmap://<namespace>/<name>/<key> scheme
+// - by launching a HTTP GET request otherwise
 func (s *CloudServicesProvidersManager) requestIPRangesFromProvider(url string) ([]byte, error) {
+	if strings.HasPrefix(url, FileSourcePrefix) {
+		return os.ReadFile(strings.TrimPrefix(url, FileSourcePrefix))
+	}
+
+	if strings.HasPrefix(url, ConfigMapSourcePrefix) {
+		return s.readIPRangesFromConfigMap(strings.TrimPrefix(url, ConfigMapSourcePrefix))
+	}
+
 	client := http.Client{
 		Timeout: time.Second * 10,
 	}
@@ -231,3 +367,30 @@ func (s *CloudServicesProvidersManager) requestIPRangesFromProvider(url string)
 
 	return body, nil
 }
+
+// readIPRangesFromConfigMap reads the ip range json file from the given <namespace>/<name>/<key> ConfigMap reference
+func (s *CloudServicesProvidersManager) readIPRangesFromConfigMap(reference string) ([]byte, error) {
+	parts := strings.SplitN(reference, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid configmap source %s, expected %s<namespace>/<name>/<key>", reference, ConfigMapSourcePrefix)
+	}
+
+	if s.reader == nil {
+		return nil, fmt.Errorf("unable to read configmap source %s: no kubernetes client configured", reference)
+	}
+
+	configMap := corev1.ConfigMap{}
+	if err := s.reader.Get(context.Background(), k8stypes.NamespacedName{Namespace: parts[0], Name: parts[1]}, &configMap); err != nil {
+		return nil, fmt.Errorf("error getting the %s/%s configmap: %w", parts[0], parts[1], err)
+	}
+
+	if data, ok := configMap.Data[parts[2]]; ok {
+		return []byte(data), nil
+	}
+
+	if data, ok := configMap.BinaryData[parts[2]]; ok {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("key %s not found in the %s/%s configmap", parts[2], parts[0], parts[1])
+}
@@ -6,16 +6,22 @@
 package cloudservice
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/DataDog/chaos-controller/cloudservice/custom"
 	"github.com/DataDog/chaos-controller/cloudservice/gcp"
 	"github.com/DataDog/chaos-controller/cloudservice/types"
 	"github.com/DataDog/chaos-controller/log"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestManager(t *testing.T) {
@@ -50,7 +56,7 @@ var _ = Describe("New function", func() {
 		var err error
 
 		logger, _ := log.NewZapLogger()
-		manager, err = New(logger, configs)
+		manager, err = New(logger, configs, nil)
 
 		By("Ensuring that no error was thrown")
 		Expect(err).ToNot(HaveOccurred())
@@ -233,6 +239,126 @@ var _ = Describe("New function", func() {
 	})
 })
 
+var _ = Describe("Offline sources", func() {
+	var manager *CloudServicesProvidersManager
+	var source string
+
+	ipRangesFile := "{\"version\":\"1\",\"prefixes\":[{\"ip_prefix\":\"10.0.0.0/24\",\"service\":\"billing\"}]}"
+	newIPRangesFile := "{\"version\":\"2\",\"prefixes\":[{\"ip_prefix\":\"10.0.0.0/24\",\"service\":\"billing\"},{\"ip_prefix\":\"10.1.0.0/24\",\"service\":\"auth\"}]}"
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "chaos-engineering",
+			Name:      "ip-ranges",
+		},
+		Data: map[string]string{
+			"custom.json": ipRangesFile,
+		},
+	}
+
+	JustBeforeEach(func() {
+		logger, _ := log.NewZapLogger()
+
+		manager = &CloudServicesProvidersManager{
+			cloudProviders: map[types.CloudProviderName]*CloudServicesProvider{
+				types.CloudProviderCustom: {
+					CloudProviderIPRangeManager: custom.New(types.CustomCloudProviderMapping{
+						Version:  "$.version",
+						Prefixes: "$.prefixes[*]",
+						Service:  "$.service",
+						IPPrefix: "$.ip_prefix",
+					}),
+					Conf: types.CloudProviderConfig{
+						Enabled:     true,
+						IPRangesURL: source,
+					},
+				},
+			},
+			log:    logger,
+			reader: fake.NewClientBuilder().WithObjects(configMap.DeepCopy()).Build(),
+		}
+	})
+
+	Context("Pull ip ranges from a local file", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "custom.json")
+			Expect(os.WriteFile(path, []byte(ipRangesFile), 0o600)).To(Succeed())
+
+			source = FileSourcePrefix + path
+		})
+
+		It("should load the file and its new versions", func() {
+			By("Ensuring that the file was loaded")
+			Expect(manager.PullIPRanges()).To(Succeed())
+			Expect(manager.GetServiceList(types.CloudProviderCustom)).To(ConsistOf("billing"))
+			Expect(manager.GetStatus()[types.CloudProviderCustom].Version).To(Equal("1"))
+
+			By("Ensuring that a new version of the file is loaded")
+			Expect(os.WriteFile(path, []byte(newIPRangesFile), 0o600)).To(Succeed())
+			Expect(manager.PullIPRanges()).To(Succeed())
+			Expect(manager.GetServiceList(types.CloudProviderCustom)).To(ConsistOf("billing", "auth"))
+
+			status := manager.GetStatus()[types.CloudProviderCustom]
+			Expect(status.Version).To(Equal("2"))
+			Expect(status.ServiceCount).To(Equal(2))
+			Expect(status.LoadTime).ToNot(BeZero())
+			Expect(status.LastPullError).To(BeEmpty())
+		})
+
+		It("should keep the loaded version when the file disappears", func() {
+			Expect(manager.PullIPRanges()).To(Succeed())
+			Expect(os.Remove(path)).To(Succeed())
+
+			By("Ensuring that an error was thrown")
+			Expect(manager.PullIPRanges()).ToNot(Succeed())
+
+			By("Ensuring that the loaded version is kept and the error reported")
+			status := manager.GetStatus()[types.CloudProviderCustom]
+			Expect(status.Version).To(Equal("1"))
+			Expect(status.LastPullError).ToNot(BeEmpty())
+			Expect(manager.GetServiceList(types.CloudProviderCustom)).To(ConsistOf("billing"))
+		})
+	})
+
+	Context("Pull ip ranges from a configmap", func() {
+		BeforeEach(func() {
+			source = ConfigMapSourcePrefix + "chaos-engineering/ip-ranges/custom.json"
+		})
+
+		It("should load the configmap key", func() {
+			Expect(manager.PullIPRanges()).To(Succeed())
+
+			By("Ensuring that the configmap key was loaded")
+			ipRanges, err := manager.GetServicesIPRanges(types.CloudProviderCustom, []string{"billing"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipRanges).To(Equal(map[string][]string{"billing": {"10.0.0.0/24"}}))
+			Expect(manager.GetStatus()[types.CloudProviderCustom].Source).To(Equal(source))
+		})
+
+		Context("with a missing key", func() {
+			BeforeEach(func() {
+				source = ConfigMapSourcePrefix + "chaos-engineering/ip-ranges/aws.json"
+			})
+
+			It("should throw an error", func() {
+				Expect(manager.PullIPRanges()).ToNot(Succeed())
+				Expect(manager.GetServiceList(types.CloudProviderCustom)).To(BeNil())
+			})
+		})
+
+		Context("with an invalid reference", func() {
+			BeforeEach(func() {
+				source = ConfigMapSourcePrefix + "ip-ranges/custom.json"
+			})
+
+			It("should throw an error", func() {
+				Expect(manager.PullIPRanges()).ToNot(Succeed())
+			})
+		})
+	})
+})
+
 func NewCloudServiceMock(isNewVersionMockValue bool, isNewVersionError error, convertToGenericIPRangesVersion string, convertToGenericIPRangesServiceList []string, convertToGenericIPRanges map[string][]string, convertToGenericIPRangesError error) *CloudProviderIPRangeManagerMock {
 	cloudProviderIPRangeMock := NewCloudProviderIPRangeManagerMock(GinkgoT())
 
@@ -13,10 +13,12 @@ const (
 	CloudProviderDatadog CloudProviderName = "Datadog"
 	CloudProviderGCP     CloudProviderName = "GCP"
 	CloudProviderAWS     CloudProviderName = "AWS"
+	CloudProviderAzure   CloudProviderName = "Azure"
+	CloudProviderCustom  CloudProviderName = "Custom"
 )
 
 var (
-	AllCloudProviders = []CloudProviderName{CloudProviderAWS, CloudProviderGCP, CloudProviderDatadog}
+	AllCloudProviders = []CloudProviderName{CloudProviderAWS, CloudProviderGCP, CloudProviderDatadog, CloudProviderAzure, CloudProviderCustom}
 )
 
 // CloudProviderIPRangeInfo information related to the ip ranges pulled from a cloud provider
@@ -26,17 +28,45 @@ type CloudProviderIPRangeInfo struct {
 	ServiceList []string // Makes the process of getting the services names easier
 }
 
+// CloudProviderIPRangeStatus status of the ip ranges loaded for a cloud provider
+type CloudProviderIPRangeStatus struct {
+	Source        string    `json:"source"`                  // url the ip ranges file is read from
+	Version       string    `json:"version,omitempty"`       // version of the loaded ip ranges file, empty if none was loaded yet
+	ServiceCount  int       `json:"serviceCount"`            // number of services of the loaded ip ranges file
+	LoadTime      time.Time `json:"loadTime,omitempty"`      // time the loaded version was parsed
+	LastPullTime  time.Time `json:"lastPullTime,omitempty"`  // time of the last pull attempt
+	LastPullError string    `json:"lastPullError,omitempty"` // error of the last pull attempt, empty if it succeeded
+}
+
 // CloudProviderConfig Single configuration for any cloud provider
 type CloudProviderConfig struct {
 	Enabled     bool   `json:"enabled"`
 	IPRangesURL string `json:"ipRangesURL"`
 }
 
+// CustomCloudProviderConfig configuration of the custom cloud provider, parsing any json ip ranges file with the given mapping
+type CustomCloudProviderConfig struct {
+	Enabled     bool                       `json:"enabled"`
+	IPRangesURL string                     `json:"ipRangesURL"`
+	Mapping     CustomCloudProviderMapping `json:"mapping"`
+}
+
+// CustomCloudProviderMapping JSONPath-like expressions (e.g. $.prefixes[*].ip_prefix) locating the data in a custom ip ranges file
+type CustomCloudProviderMapping struct {
+	Version        string `json:"version"`        // path to the version of the file, a hash of the file is used if empty
+	Prefixes       string `json:"prefixes"`       // path to the list of prefixes entries
+	Service        string `json:"service"`        // path to the service name, relative to a prefixes entry
+	IPPrefix       string `json:"ipPrefix"`       // path to the ip prefix (or list of ip prefixes), relative to a prefixes entry
+	DefaultService string `json:"defaultService"` // service name used when no service is found for an entry
+}
+
 // CloudProviderConfigs all cloud provider configurations for the manager
 type CloudProviderConfigs struct {
-	DisableAll   bool                `json:"disableAll"`
-	PullInterval time.Duration       `json:"pullInterval"`
-	AWS          CloudProviderConfig `json:"aws"`
-	GCP          CloudProviderConfig `json:"gcp"`
-	Datadog      CloudProviderConfig `json:"datadog"`
+	DisableAll   bool                      `json:"disableAll"`
+	PullInterval time.Duration             `json:"pullInterval"`
+	AWS          CloudProviderConfig       `json:"aws"`
+	GCP          CloudProviderConfig       `json:"gcp"`
+	Datadog      CloudProviderConfig       `json:"datadog"`
+	Azure        CloudProviderConfig       `json:"azure"`
+	Custom       CustomCloudProviderConfig `json:"custom"`
 }
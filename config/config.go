@@ -9,8 +9,16 @@ import (
 	"os"
 	"time"
 
+	auditfile "github.com/DataDog/chaos-controller/audit/file"
+	audithttp "github.com/DataDog/chaos-controller/audit/http"
 	cloudtypes "github.com/DataDog/chaos-controller/cloudservice/types"
 	"github.com/DataDog/chaos-controller/eventnotifier"
+	"github.com/DataDog/chaos-controller/eventnotifier/opsgenie"
+	"github.com/DataDog/chaos-controller/eventnotifier/slack"
+	"github.com/DataDog/chaos-controller/eventnotifier/smtp"
+	"github.com/DataDog/chaos-controller/o11y/profiler/pprof"
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
+	"github.com/DataDog/chaos-controller/report"
 	"go.uber.org/zap"
 
 	"github.com/fsnotify/fsnotify"
@@ -25,23 +33,32 @@ type config struct {
 }
 
 type controllerConfig struct {
-	MetricsBindAddr          string                          `json:"metricsBindAddr"`
-	MetricsSink              string                          `json:"metricsSink"`
-	ExpiredDisruptionGCDelay time.Duration                   `json:"expiredDisruptionGCDelay"`
-	DefaultDuration          time.Duration                   `json:"defaultDuration"`
-	DeleteOnly               bool                            `json:"deleteOnly"`
-	EnableSafeguards         bool                            `json:"enableSafeguards"`
-	EnableObserver           bool                            `json:"enableObserver"`
-	LeaderElection           bool                            `json:"leaderElection"`
-	Webhook                  controllerWebhookConfig         `json:"webhook"`
-	Notifiers                eventnotifier.NotifiersConfig   `json:"notifiersConfig"`
-	CloudProviders           cloudtypes.CloudProviderConfigs `json:"cloudProviders"`
-	UserInfoHook             bool                            `json:"userInfoHook"`
-	SafeMode                 safeModeConfig                  `json:"safeMode"`
-	ProfilerSink             string                          `json:"profilerSink"`
-	TracerSink               string                          `json:"tracerSink"`
-	DisruptionCronEnabled    bool                            `json:"disruptionCronEnabled"`
-	DisruptionRolloutEnabled bool                            `json:"disruptionRolloutEnabled"`
+	MetricsBindAddr           string                          `json:"metricsBindAddr"`
+	MetricsSink               string                          `json:"metricsSink"`
+	ExpiredDisruptionGCDelay  time.Duration                   `json:"expiredDisruptionGCDelay"`
+	DefaultDuration           time.Duration                   `json:"defaultDuration"`
+	DeleteOnly                bool                            `json:"deleteOnly"`
+	EnableSafeguards          bool                            `json:"enableSafeguards"`
+	EnableObserver            bool                            `json:"enableObserver"`
+	LeaderElection            bool                            `json:"leaderElection"`
+	Webhook                   controllerWebhookConfig         `json:"webhook"`
+	Notifiers                 eventnotifier.NotifiersConfig   `json:"notifiersConfig"`
+	CloudProviders            cloudtypes.CloudProviderConfigs `json:"cloudProviders"`
+	UserInfoHook              bool                            `json:"userInfoHook"`
+	SafeMode                  safeModeConfig                  `json:"safeMode"`
+	ProfilerSink              string                          `json:"profilerSink"`
+	ProfilerPprof             pprof.Config                    `json:"profilerPprof"`
+	TracerSink                string                          `json:"tracerSink"`
+	TracerOTLP                otlp.Config                     `json:"tracerOTLP"`
+	AuditSink                 string                          `json:"auditSink"`
+	AuditFile                 auditfile.Config                `json:"auditFile"`
+	AuditHTTP                 audithttp.Config                `json:"auditHTTP"`
+	DisruptionCronEnabled     bool                            `json:"disruptionCronEnabled"`
+	DisruptionRolloutEnabled  bool                            `json:"disruptionRolloutEnabled"`
+	DisruptionWorkflowEnabled bool                            `json:"disruptionWorkflowEnabled"`
+	DisruptionBlackoutEnabled bool                            `json:"disruptionBlackoutEnabled"`
+	Guardrails                guardrailsConfig                `json:"guardrails"`
+	Reports                   report.Config                   `json:"reports"`
 }
 
 type controllerWebhookConfig struct {
@@ -51,10 +68,23 @@ type controllerWebhookConfig struct {
 }
 
 type safeModeConfig struct {
-	Environment        string `json:"environment"`
-	Enable             bool   `json:"enable"`
-	NamespaceThreshold int    `json:"namespaceThreshold"`
-	ClusterThreshold   int    `json:"clusterThreshold"`
+	Environment        string               `json:"environment"`
+	Enable             bool                 `json:"enable"`
+	NamespaceThreshold int                  `json:"namespaceThreshold"`
+	ClusterThreshold   int                  `json:"clusterThreshold"`
+	Quotas             safeModeQuotasConfig `json:"quotas"`
+}
+
+type safeModeQuotasConfig struct {
+	MaxDisruptionsPerNamespace     int `json:"maxDisruptionsPerNamespace"`
+	MaxDisruptionsPerCluster       int `json:"maxDisruptionsPerCluster"`
+	MaxDisruptionsPerKind          int `json:"maxDisruptionsPerKind"`
+	MaxWorkloadDisruptedPercentage int `json:"maxWorkloadDisruptedPercentage"`
+}
+
+type guardrailsConfig struct {
+	PrometheusEndpoint string   `json:"prometheusEndpoint"`
+	AllowedHosts       []string `json:"allowedHosts"`
 }
 
 type injectorConfig struct {
@@ -172,6 +202,30 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.BoolVar(&cfg.Controller.Notifiers.Slack.Threads, "notifiers-slack-threads", true, "Post the notifications of a disruption in a thread per slack destination instead of one message per notification (defaulted to true)")
+
+	if err := viper.BindPFlag("controller.notifiers.slack.threads", mainFS.Lookup("notifiers-slack-threads")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.Slack.Interactions.Enabled, "notifiers-slack-interactions-enabled", false, "Enabler toggle for the slack interactive messages, adding a stop button to the disruption threads (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.slack.interactions.enabled", mainFS.Lookup("notifiers-slack-interactions-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Slack.Interactions.Addr, "notifiers-slack-interactions-addr", slack.DefaultInteractionsAddr, "Address the slack interactive messages callbacks are served on (defaulted to :8090)")
+
+	if err := viper.BindPFlag("controller.notifiers.slack.interactions.addr", mainFS.Lookup("notifiers-slack-interactions-addr")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Slack.Interactions.SigningSecretFilepath, "notifiers-slack-interactions-signing-secret-filepath", "", "File path of the slack app signing secret used to verify the interactive messages callbacks (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.slack.interactions.signingSecretFilepath", mainFS.Lookup("notifiers-slack-interactions-signing-secret-filepath")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.BoolVar(&cfg.Controller.Notifiers.Datadog.Enabled, "notifiers-datadog-enabled", false, "Enabler toggle for the Datadog notifier (defaulted to false)")
 
 	if err := viper.BindPFlag("controller.notifiers.datadog.enabled", mainFS.Lookup("notifiers-datadog-enabled")); err != nil {
@@ -202,6 +256,132 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.StringVar(&cfg.Controller.Notifiers.HTTP.PayloadTemplate, "notifiers-http-payload-template", "", "Go template rendering the body of the notification sent by the HTTP notifier (defaulted to the JSON event)")
+
+	if err := viper.BindPFlag("controller.notifiers.http.payloadTemplate", mainFS.Lookup("notifiers-http-payload-template")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.HTTP.PayloadTemplateFilepath, "notifiers-http-payload-template-filepath", "", "Filepath to the Go template rendering the body of the notification sent by the HTTP notifier (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.http.payloadTemplateFilepath", mainFS.Lookup("notifiers-http-payload-template-filepath")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.HTTP.CloudEvents, "notifiers-http-cloudevents", false, "Wrap the notification sent by the HTTP notifier in a CloudEvents 1.0 structured event (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.http.cloudEvents", mainFS.Lookup("notifiers-http-cloudevents")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.HTTP.SigningSecretFilepath, "notifiers-http-signing-secret-filepath", "", "Filepath to the secret used to sign the body of the notification sent by the HTTP notifier with HMAC SHA256 (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.http.signingSecretFilepath", mainFS.Lookup("notifiers-http-signing-secret-filepath")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.IntVar(&cfg.Controller.Notifiers.HTTP.MaxRetries, "notifiers-http-max-retries", 3, "Maximum number of retries of a notification sent by the HTTP notifier on network or server errors (defaulted to 3)")
+
+	if err := viper.BindPFlag("controller.notifiers.http.maxRetries", mainFS.Lookup("notifiers-http-max-retries")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.Teams.Enabled, "notifiers-teams-enabled", false, "Enabler toggle for the Microsoft Teams notifier (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.teams.enabled", mainFS.Lookup("notifiers-teams-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Teams.WebhookURLFilepath, "notifiers-teams-webhook-url-filepath", "", "File path of the incoming webhook URL of the Teams channel to post notifications to (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.teams.webhookURLFilepath", mainFS.Lookup("notifiers-teams-webhook-url-filepath")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.Opsgenie.Enabled, "notifiers-opsgenie-enabled", false, "Enabler toggle for the Opsgenie notifier (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.enabled", mainFS.Lookup("notifiers-opsgenie-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Opsgenie.APIKeyFilepath, "notifiers-opsgenie-api-key-filepath", "", "File path of the API key of the Opsgenie notifier (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.apiKeyFilepath", mainFS.Lookup("notifiers-opsgenie-api-key-filepath")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Opsgenie.APIURL, "notifiers-opsgenie-api-url", opsgenie.DefaultAPIURL, "URL of the Opsgenie API, use https://api.eu.opsgenie.com for EU accounts (defaulted to https://api.opsgenie.com)")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.apiURL", mainFS.Lookup("notifiers-opsgenie-api-url")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.Opsgenie.Priority, "notifiers-opsgenie-priority", opsgenie.DefaultPriority, "Priority of the alerts created by the Opsgenie notifier, from P1 to P5 (defaulted to P3)")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.priority", mainFS.Lookup("notifiers-opsgenie-priority")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringArrayVar(&cfg.Controller.Notifiers.Opsgenie.Responders, "notifiers-opsgenie-responders", []string{}, "Opsgenie teams responding to the alerts created by the Opsgenie notifier (defaulted to empty list)")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.responders", mainFS.Lookup("notifiers-opsgenie-responders")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringArrayVar(&cfg.Controller.Notifiers.Opsgenie.Tags, "notifiers-opsgenie-tags", []string{}, "Tags added to the alerts created by the Opsgenie notifier (defaulted to empty list)")
+
+	if err := viper.BindPFlag("controller.notifiers.opsgenie.tags", mainFS.Lookup("notifiers-opsgenie-tags")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.SMTP.Enabled, "notifiers-smtp-enabled", false, "Enabler toggle for the SMTP (email) notifier (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.enabled", mainFS.Lookup("notifiers-smtp-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.SMTP.Host, "notifiers-smtp-host", "", "Host of the SMTP server used by the SMTP notifier (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.host", mainFS.Lookup("notifiers-smtp-host")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.IntVar(&cfg.Controller.Notifiers.SMTP.Port, "notifiers-smtp-port", smtp.DefaultPort, "Port of the SMTP server used by the SMTP notifier (defaulted to 587)")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.port", mainFS.Lookup("notifiers-smtp-port")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.SMTP.Username, "notifiers-smtp-username", "", "Username to authenticate against the SMTP server, authentication is disabled when empty (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.username", mainFS.Lookup("notifiers-smtp-username")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.SMTP.PasswordFilepath, "notifiers-smtp-password-filepath", "", "File path of the password to authenticate against the SMTP server (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.passwordFilepath", mainFS.Lookup("notifiers-smtp-password-filepath")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Notifiers.SMTP.From, "notifiers-smtp-from", "", "Sender address of the emails sent by the SMTP notifier (defaulted to \"\")")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.from", mainFS.Lookup("notifiers-smtp-from")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringArrayVar(&cfg.Controller.Notifiers.SMTP.To, "notifiers-smtp-to", []string{}, "Recipient addresses of the emails sent by the SMTP notifier (defaulted to empty list)")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.to", mainFS.Lookup("notifiers-smtp-to")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.Notifiers.SMTP.NotifyAuthor, "notifiers-smtp-notify-author", false, "Also send the emails to the disruption author when their username is an email address (defaulted to false)")
+
+	if err := viper.BindPFlag("controller.notifiers.smtp.notifyAuthor", mainFS.Lookup("notifiers-smtp-notify-author")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.StringToStringVar(&cfg.Injector.Annotations, "injector-annotations", map[string]string{}, "Annotations added to the generated injector pods")
 
 	if err := viper.BindPFlag("injector.annotations", mainFS.Lookup("injector-annotations")); err != nil {
@@ -331,6 +511,34 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.IntVar(&cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerNamespace, "safemode-quotas-max-disruptions-per-namespace", 0,
+		"Maximum number of disruptions running at once in a namespace (0 means no limit)")
+
+	if err := viper.BindPFlag("controller.safemode.quotas.maxDisruptionsPerNamespace", mainFS.Lookup("safemode-quotas-max-disruptions-per-namespace")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.IntVar(&cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerCluster, "safemode-quotas-max-disruptions-per-cluster", 0,
+		"Maximum number of disruptions running at once in the cluster (0 means no limit)")
+
+	if err := viper.BindPFlag("controller.safemode.quotas.maxDisruptionsPerCluster", mainFS.Lookup("safemode-quotas-max-disruptions-per-cluster")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.IntVar(&cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerKind, "safemode-quotas-max-disruptions-per-kind", 0,
+		"Maximum number of disruptions of the same kind running at once in the cluster (0 means no limit)")
+
+	if err := viper.BindPFlag("controller.safemode.quotas.maxDisruptionsPerKind", mainFS.Lookup("safemode-quotas-max-disruptions-per-kind")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.IntVar(&cfg.Controller.SafeMode.Quotas.MaxWorkloadDisruptedPercentage, "safemode-quotas-max-workload-disrupted-percentage", 0,
+		"Maximum percentage of the pods of a workload targeted by all the running disruptions at once (0 means no limit)")
+
+	if err := viper.BindPFlag("controller.safemode.quotas.maxWorkloadDisruptedPercentage", mainFS.Lookup("safemode-quotas-max-workload-disrupted-percentage")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.BoolVar(&cfg.Controller.CloudProviders.DisableAll, "cloud-providers-disable-all", false, "Disable all cloud providers disruptions (defaults to false, overrides all individual cloud providers configuration)")
 
 	if err := viper.BindPFlag("controller.cloudProviders.disableAll", mainFS.Lookup("cloud-providers-disable-all")); err != nil {
@@ -379,24 +587,156 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
-	mainFS.StringVar(&cfg.Controller.MetricsSink, "metrics-sink", "noop", "metrics sink (datadog, or noop)")
+	mainFS.BoolVar(&cfg.Controller.CloudProviders.Azure.Enabled, "cloud-providers-azure-enabled", false, "Enable Azure cloud provider disruptions (defaults to false, is overridden by --cloud-providers-disable-all)")
+
+	if err := viper.BindPFlag("controller.cloudProviders.azure.enabled", mainFS.Lookup("cloud-providers-azure-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Azure.IPRangesURL, "cloud-providers-azure-iprangesurl", "", "Configure the cloud provider URL to the IP ranges file used by the disruption")
+
+	if err := viper.BindPFlag("controller.cloudProviders.azure.ipRangesURL", mainFS.Lookup("cloud-providers-azure-iprangesurl")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.CloudProviders.Custom.Enabled, "cloud-providers-custom-enabled", false, "Enable the custom cloud provider disruptions (defaults to false, is overridden by --cloud-providers-disable-all)")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.enabled", mainFS.Lookup("cloud-providers-custom-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.IPRangesURL, "cloud-providers-custom-iprangesurl", "", "Configure the custom cloud provider URL (or file:// path) to the IP ranges file used by the disruption")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.ipRangesURL", mainFS.Lookup("cloud-providers-custom-iprangesurl")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.Mapping.Version, "cloud-providers-custom-mapping-version", "", "JSONPath-like expression to the version of the custom IP ranges file (a hash of the file is used if empty)")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.mapping.version", mainFS.Lookup("cloud-providers-custom-mapping-version")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.Mapping.Prefixes, "cloud-providers-custom-mapping-prefixes", "", "JSONPath-like expression to the list of prefixes entries of the custom IP ranges file")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.mapping.prefixes", mainFS.Lookup("cloud-providers-custom-mapping-prefixes")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.Mapping.Service, "cloud-providers-custom-mapping-service", "", "JSONPath-like expression to the service name, relative to a prefixes entry of the custom IP ranges file")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.mapping.service", mainFS.Lookup("cloud-providers-custom-mapping-service")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.Mapping.IPPrefix, "cloud-providers-custom-mapping-ipprefix", "", "JSONPath-like expression to the IP prefix, relative to a prefixes entry of the custom IP ranges file")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.mapping.ipPrefix", mainFS.Lookup("cloud-providers-custom-mapping-ipprefix")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.CloudProviders.Custom.Mapping.DefaultService, "cloud-providers-custom-mapping-defaultservice", "", "Service name used for the custom IP ranges file entries without service")
+
+	if err := viper.BindPFlag("controller.cloudProviders.custom.mapping.defaultService", mainFS.Lookup("cloud-providers-custom-mapping-defaultservice")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.MetricsSink, "metrics-sink", "noop", "metrics sink (datadog, prometheus, or noop)")
 
 	if err := viper.BindPFlag("controller.metricsSink", mainFS.Lookup("metrics-sink")); err != nil {
 		return cfg, err
 	}
 
-	mainFS.StringVar(&cfg.Controller.ProfilerSink, "profiler-sink", "noop", "profiler sink (datadog, or noop)")
+	mainFS.StringVar(&cfg.Controller.ProfilerSink, "profiler-sink", "noop", "profiler sink (datadog, pprof, or noop)")
 
 	if err := viper.BindPFlag("controller.profilerSink", mainFS.Lookup("profiler-sink")); err != nil {
 		return cfg, err
 	}
 
-	mainFS.StringVar(&cfg.Controller.TracerSink, "tracer-sink", "noop", "tracer sink (datadog, or noop)")
+	mainFS.StringVar(&cfg.Controller.ProfilerPprof.Addr, "profiler-pprof-addr", pprof.DefaultAddr, "Address the pprof profiler sink serves the profiles on")
+
+	if err := viper.BindPFlag("controller.profilerPprof.addr", mainFS.Lookup("profiler-pprof-addr")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.TracerSink, "tracer-sink", "noop", "tracer sink (datadog, otlp, or noop)")
 
 	if err := viper.BindPFlag("controller.tracerSink", mainFS.Lookup("tracer-sink")); err != nil {
 		return cfg, err
 	}
 
+	mainFS.BoolVar(&cfg.Controller.Reports.Enabled, "reports-enabled", false, "Store a report of each finished disruption (spec, user, targets timeline, events) in a ConfigMap")
+
+	if err := viper.BindPFlag("controller.reports.enabled", mainFS.Lookup("reports-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Reports.Namespace, "reports-namespace", "", "Namespace the disruption reports ConfigMaps are created in, defaults to the chaos namespace")
+
+	if err := viper.BindPFlag("controller.reports.namespace", mainFS.Lookup("reports-namespace")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.TracerOTLP.Protocol, "tracer-otlp-protocol", otlp.ProtocolGRPC, "Protocol used by the otlp tracer sink to export spans (grpc or http)")
+
+	if err := viper.BindPFlag("controller.tracerOTLP.protocol", mainFS.Lookup("tracer-otlp-protocol")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.TracerOTLP.Endpoint, "tracer-otlp-endpoint", "", "Endpoint (host:port) the otlp tracer sink exports spans to, defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable")
+
+	if err := viper.BindPFlag("controller.tracerOTLP.endpoint", mainFS.Lookup("tracer-otlp-endpoint")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.TracerOTLP.Insecure, "tracer-otlp-insecure", false, "Disable TLS when exporting spans with the otlp tracer sink")
+
+	if err := viper.BindPFlag("controller.tracerOTLP.insecure", mainFS.Lookup("tracer-otlp-insecure")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringToStringVar(&cfg.Controller.TracerOTLP.Headers, "tracer-otlp-headers", map[string]string{}, "Headers sent along with the spans exported by the otlp tracer sink")
+
+	if err := viper.BindPFlag("controller.tracerOTLP.headers", mainFS.Lookup("tracer-otlp-headers")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.Float64Var(&cfg.Controller.TracerOTLP.SamplingRatio, "tracer-otlp-sampling-ratio", 1, "Ratio of root spans sampled by the otlp tracer sink, between 0 and 1")
+
+	if err := viper.BindPFlag("controller.tracerOTLP.samplingRatio", mainFS.Lookup("tracer-otlp-sampling-ratio")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.AuditSink, "audit-sink", "noop", "audit sink recording the actions taken on disruptions and their targets (stdout, file, http, or noop)")
+
+	if err := viper.BindPFlag("controller.auditSink", mainFS.Lookup("audit-sink")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.AuditFile.Path, "audit-file-path", "", "Path of the file the file audit sink appends the entries to")
+
+	if err := viper.BindPFlag("controller.auditFile.path", mainFS.Lookup("audit-file-path")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.AuditHTTP.URL, "audit-http-url", "", "URL the http audit sink sends the entries to")
+
+	if err := viper.BindPFlag("controller.auditHTTP.url", mainFS.Lookup("audit-http-url")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringToStringVar(&cfg.Controller.AuditHTTP.Headers, "audit-http-headers", map[string]string{}, "Headers sent along with the entries by the http audit sink")
+
+	if err := viper.BindPFlag("controller.auditHTTP.headers", mainFS.Lookup("audit-http-headers")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.DurationVar(&cfg.Controller.AuditHTTP.Timeout, "audit-http-timeout", audithttp.DefaultTimeout, "Timeout of the requests sending the entries with the http audit sink")
+
+	if err := viper.BindPFlag("controller.auditHTTP.timeout", mainFS.Lookup("audit-http-timeout")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.BoolVar(&cfg.Controller.DisruptionCronEnabled, "disruption-cron-enabled", false, "Enable the DisruptionCron CRD and its controller")
 
 	if err := viper.BindPFlag("controller.disruptionCronEnabled", mainFS.Lookup("disruption-cron-enabled")); err != nil {
@@ -409,6 +749,30 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.BoolVar(&cfg.Controller.DisruptionWorkflowEnabled, "disruption-workflow-enabled", false, "Enable the DisruptionWorkflow CRD and its controller")
+
+	if err := viper.BindPFlag("controller.disruptionWorkflowEnabled", mainFS.Lookup("disruption-workflow-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.BoolVar(&cfg.Controller.DisruptionBlackoutEnabled, "disruption-blackout-enabled", false, "Enable the DisruptionBlackout CRD, denying disruptions and skipping DisruptionCron runs during blackout windows")
+
+	if err := viper.BindPFlag("controller.disruptionBlackoutEnabled", mainFS.Lookup("disruption-blackout-enabled")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Controller.Guardrails.PrometheusEndpoint, "guardrails-prometheus-endpoint", "", "Prometheus endpoint queried by the disruptions guardrails prometheus checks (e.g. http://prometheus.monitoring:9090)")
+
+	if err := viper.BindPFlag("controller.guardrails.prometheusEndpoint", mainFS.Lookup("guardrails-prometheus-endpoint")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringSliceVar(&cfg.Controller.Guardrails.AllowedHosts, "guardrails-allowed-hosts", []string{}, "List of hosts the disruptions guardrails http checks are allowed to query")
+
+	if err := viper.BindPFlag("controller.guardrails.allowedHosts", mainFS.Lookup("guardrails-allowed-hosts")); err != nil {
+		return cfg, err
+	}
+
 	err := preConfigFS.Parse(osArgs)
 	if err != nil {
 		return cfg, fmt.Errorf("unable to retrieve configuration parse from provided flag: %w", err)
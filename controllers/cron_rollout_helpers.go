@@ -8,17 +8,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,9 +33,33 @@ const (
 	ScheduledAtAnnotation          = chaosv1beta1.GroupName + "/scheduled-at"
 	DisruptionCronNameLabel        = chaosv1beta1.GroupName + "/disruption-cron-name"
 	DisruptionRolloutNameLabel     = chaosv1beta1.GroupName + "/disruption-rollout-name"
+	DisruptionWorkflowNameLabel    = chaosv1beta1.GroupName + "/disruption-workflow-name"
+	DisruptionWorkflowStepLabel    = chaosv1beta1.GroupName + "/disruption-workflow-step"
 	TargetResourceMissingThreshold = time.Hour * 24
 )
 
+// runSkipReason is the reason why a DisruptionCron or a DisruptionRollout run did not create its disruption
+type runSkipReason string
+
+const (
+	runSkipReasonSuspended         runSkipReason = "suspended"
+	runSkipReasonTargetMissing     runSkipReason = "target_missing"
+	runSkipReasonTooLate           runSkipReason = "too_late"
+	runSkipReasonBlackout          runSkipReason = "blackout"
+	runSkipReasonConcurrentRun     runSkipReason = "concurrent_run"
+	runSkipReasonRolloutIncomplete runSkipReason = "rollout_incomplete"
+)
+
+// runSkipReasonMessages completes the message of the events recorded when a run is skipped
+var runSkipReasonMessages = map[runSkipReason]string{
+	runSkipReasonSuspended:         "it is suspended",
+	runSkipReasonTargetMissing:     "the target resource is missing",
+	runSkipReasonTooLate:           "the delayed start tolerance has been exceeded",
+	runSkipReasonBlackout:          "a blackout window is active",
+	runSkipReasonConcurrentRun:     "a prior disruption is still running",
+	runSkipReasonRolloutIncomplete: "the target resource rollout is not complete",
+}
+
 // GetChildDisruptions retrieves disruptions associated with a resource by its label.
 // Most of the time, this will return an empty list as disruptions are typically short-lived objects.
 func GetChildDisruptions(ctx context.Context, cl client.Client, log *zap.SugaredLogger, namespace, labelKey, labelVal string) (*chaosv1beta1.DisruptionList, error) {
@@ -44,16 +74,36 @@ func GetChildDisruptions(ctx context.Context, cl client.Client, log *zap.Sugared
 	return disruptions, nil
 }
 
-// GetTargetResource retrieves the specified target resource (Deployment or StatefulSet).
+// GetTargetResource retrieves the specified target resource (Deployment, StatefulSet, DaemonSet, ReplicaSet, Job,
+// or any other resource looked up by its kind and apiVersion).
 // It returns the target resource object and any error encountered during retrieval.
 func GetTargetResource(ctx context.Context, cl client.Client, targetResource *chaosv1beta1.TargetResourceSpec, namespace string) (client.Object, error) {
 	var targetObj client.Object
 
-	switch targetResource.Kind {
-	case "deployment":
-		targetObj = &appsv1.Deployment{}
-	case "statefulset":
-		targetObj = &appsv1.StatefulSet{}
+	if targetResource.IsGeneric() {
+		gv, err := schema.ParseGroupVersion(targetResource.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target resource apiVersion %s: %w", targetResource.APIVersion, err)
+		}
+
+		unstructuredObj := &unstructured.Unstructured{}
+		unstructuredObj.SetGroupVersionKind(gv.WithKind(targetResource.Kind))
+		targetObj = unstructuredObj
+	} else {
+		switch targetResource.Kind {
+		case "deployment":
+			targetObj = &appsv1.Deployment{}
+		case "statefulset":
+			targetObj = &appsv1.StatefulSet{}
+		case "daemonset":
+			targetObj = &appsv1.DaemonSet{}
+		case "replicaset":
+			targetObj = &appsv1.ReplicaSet{}
+		case "job":
+			targetObj = &batchv1.Job{}
+		default:
+			return nil, fmt.Errorf("unsupported target resource kind %s", targetResource.Kind)
+		}
 	}
 
 	err := cl.Get(ctx, types.NamespacedName{
@@ -78,12 +128,14 @@ func CheckTargetResourceExists(ctx context.Context, cl client.Client, targetReso
 	return true, nil
 }
 
-// GetSelectors retrieves the labels of the specified target resource (Deployment or StatefulSet).
-// Returns a set of labels to be used as Disruption selectors and an error if retrieval fails.
-func GetSelectors(ctx context.Context, cl client.Client, targetResource *chaosv1beta1.TargetResourceSpec, namespace string) (labels.Set, error) {
+// GetSelectors retrieves the labels selecting the pods of the specified target resource.
+// Natively supported resources are selected with their pod template labels, while other resources are selected with their spec.selector field.
+// Returns a set of labels to be used as Disruption selectors, the label selector requirements to be used as Disruption advanced selectors,
+// and an error if retrieval fails.
+func GetSelectors(ctx context.Context, cl client.Client, targetResource *chaosv1beta1.TargetResourceSpec, namespace string) (labels.Set, []metav1.LabelSelectorRequirement, error) {
 	targetObj, err := GetTargetResource(ctx, cl, targetResource, namespace)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// retrieve pod template spec from targeted resource
@@ -93,8 +145,16 @@ func GetSelectors(ctx context.Context, cl client.Client, targetResource *chaosv1
 		podSpec = o.Spec.Template
 	case *appsv1.StatefulSet:
 		podSpec = o.Spec.Template
+	case *appsv1.DaemonSet:
+		podSpec = o.Spec.Template
+	case *appsv1.ReplicaSet:
+		podSpec = o.Spec.Template
+	case *batchv1.Job:
+		podSpec = o.Spec.Template
+	case *unstructured.Unstructured:
+		return getUnstructuredSelectors(o)
 	default:
-		return nil, errors.New("error getting target resource pod template labels")
+		return nil, nil, errors.New("error getting target resource pod template labels")
 	}
 
 	labels := podSpec.GetLabels()
@@ -102,7 +162,118 @@ func GetSelectors(ctx context.Context, cl client.Client, targetResource *chaosv1
 		labels = make(map[string]string)
 	}
 
-	return labels, nil
+	return labels, nil, nil
+}
+
+// IsRolloutComplete returns true if the given Deployment, StatefulSet or DaemonSet has rolled out its latest version on all of its replicas,
+// following the same rules as kubectl rollout status. It returns the reason why the rollout is not complete, if so.
+// Other resources are always considered as rolled out.
+func IsRolloutComplete(targetObj client.Object) (bool, string) {
+	switch o := targetObj.(type) {
+	case *appsv1.Deployment:
+		if o.Generation > o.Status.ObservedGeneration {
+			return false, "waiting for the deployment spec update to be observed"
+		}
+
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+
+		if o.Status.UpdatedReplicas < replicas {
+			return false, fmt.Sprintf("%d out of %d new replicas have been updated", o.Status.UpdatedReplicas, replicas)
+		}
+
+		if o.Status.Replicas > o.Status.UpdatedReplicas {
+			return false, fmt.Sprintf("%d old replicas are pending termination", o.Status.Replicas-o.Status.UpdatedReplicas)
+		}
+
+		if o.Status.AvailableReplicas < o.Status.UpdatedReplicas {
+			return false, fmt.Sprintf("%d of %d updated replicas are available", o.Status.AvailableReplicas, o.Status.UpdatedReplicas)
+		}
+	case *appsv1.StatefulSet:
+		if o.Status.ObservedGeneration == 0 || o.Generation > o.Status.ObservedGeneration {
+			return false, "waiting for the statefulset spec update to be observed"
+		}
+
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+
+		if o.Status.ReadyReplicas < replicas {
+			return false, fmt.Sprintf("%d of %d pods are ready", o.Status.ReadyReplicas, replicas)
+		}
+
+		if o.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType && o.Spec.UpdateStrategy.RollingUpdate != nil && o.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+			if o.Status.UpdatedReplicas < replicas-*o.Spec.UpdateStrategy.RollingUpdate.Partition {
+				return false, fmt.Sprintf("%d pods of the partition have been updated", o.Status.UpdatedReplicas)
+			}
+
+			return true, ""
+		}
+
+		if o.Status.UpdateRevision != o.Status.CurrentRevision {
+			return false, fmt.Sprintf("%d pods at revision %s", o.Status.UpdatedReplicas, o.Status.UpdateRevision)
+		}
+	case *appsv1.DaemonSet:
+		if o.Generation > o.Status.ObservedGeneration {
+			return false, "waiting for the daemonset spec update to be observed"
+		}
+
+		if o.Status.UpdatedNumberScheduled < o.Status.DesiredNumberScheduled {
+			return false, fmt.Sprintf("%d out of %d new pods have been updated", o.Status.UpdatedNumberScheduled, o.Status.DesiredNumberScheduled)
+		}
+
+		if o.Status.NumberAvailable < o.Status.DesiredNumberScheduled {
+			return false, fmt.Sprintf("%d of %d updated pods are available", o.Status.NumberAvailable, o.Status.DesiredNumberScheduled)
+		}
+	}
+
+	return true, ""
+}
+
+// getUnstructuredSelectors retrieves the spec.selector field of the given resource.
+// The selector can either be a label selector (matchLabels and matchExpressions) or a plain map of labels.
+func getUnstructuredSelectors(obj *unstructured.Unstructured) (labels.Set, []metav1.LabelSelectorRequirement, error) {
+	rawSelector, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting %s %s spec.selector field: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	if !found || len(rawSelector) == 0 {
+		return nil, nil, fmt.Errorf("%s %s has no spec.selector field to select its pods", obj.GetKind(), obj.GetName())
+	}
+
+	_, hasMatchLabels := rawSelector["matchLabels"]
+	_, hasMatchExpressions := rawSelector["matchExpressions"]
+
+	if !hasMatchLabels && !hasMatchExpressions {
+		selector := labels.Set{}
+
+		for k, v := range rawSelector {
+			value, ok := v.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s %s spec.selector field is neither a label selector nor a map of labels", obj.GetKind(), obj.GetName())
+			}
+
+			selector[k] = value
+		}
+
+		return selector, nil, nil
+	}
+
+	selector := metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, &selector); err != nil {
+		return nil, nil, fmt.Errorf("error parsing %s %s spec.selector field: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	matchLabels := labels.Set(selector.MatchLabels)
+	if matchLabels == nil {
+		matchLabels = labels.Set{}
+	}
+
+	return matchLabels, selector.MatchExpressions, nil
 }
 
 // createBaseDisruption generates a basic Disruption object using the provided owner and disruptionSpec.
@@ -135,11 +306,13 @@ func setDisruptionAnnotations(disruption *chaosv1beta1.Disruption, owner metav1.
 // Returns an error if fetching selectors from the target resource fails.
 func overwriteDisruptionSelectors(ctx context.Context, cl client.Client, disruption *chaosv1beta1.Disruption, targetResource *chaosv1beta1.TargetResourceSpec, namespace string) error {
 	// Get selectors from target resource
-	selectors, err := GetSelectors(ctx, cl, targetResource, namespace)
+	selectors, advancedSelectors, err := GetSelectors(ctx, cl, targetResource, namespace)
 	if err != nil {
 		return err
 	}
 
+	disruption.Spec.AdvancedSelector = append(disruption.Spec.AdvancedSelector, advancedSelectors...)
+
 	if disruption.Spec.Selector == nil {
 		disruption.Spec.Selector = make(map[string]string)
 	}
@@ -152,7 +325,7 @@ func overwriteDisruptionSelectors(ctx context.Context, cl client.Client, disrupt
 }
 
 // CreateDisruptionFromTemplate constructs a Disruption object based on the provided owner, disruptionSpec, and targetResource.
-// The function sets annotations, overwrites selectors, and associates the Disruption with its owner.
+// The function sets annotations, overwrites selectors (unless targetResource is nil), and associates the Disruption with its owner.
 // It returns the constructed Disruption or an error if any step fails.
 func CreateDisruptionFromTemplate(ctx context.Context, cl client.Client, scheme *runtime.Scheme, owner metav1.Object, targetResource *chaosv1beta1.TargetResourceSpec, disruptionSpec *chaosv1beta1.DisruptionSpec, scheduledTime time.Time) (*chaosv1beta1.Disruption, error) {
 	disruption := createBaseDisruption(owner, disruptionSpec)
@@ -162,8 +335,10 @@ func CreateDisruptionFromTemplate(ctx context.Context, cl client.Client, scheme
 
 	setDisruptionAnnotations(disruption, owner, scheduledTime)
 
-	if err := overwriteDisruptionSelectors(ctx, cl, disruption, targetResource, owner.GetNamespace()); err != nil {
-		return nil, err
+	if targetResource != nil {
+		if err := overwriteDisruptionSelectors(ctx, cl, disruption, targetResource, owner.GetNamespace()); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := ctrl.SetControllerReference(owner, disruption, scheme); err != nil {
@@ -219,6 +394,8 @@ func generateDisruptionName(owner metav1.Object) string {
 		return fmt.Sprintf("disruption-cron-%s", typedOwner.GetName())
 	case *chaosv1beta1.DisruptionRollout:
 		return fmt.Sprintf("disruption-rollout-%s", typedOwner.GetName())
+	case *chaosv1beta1.DisruptionWorkflow:
+		return fmt.Sprintf("disruption-workflow-%s", typedOwner.GetName())
 	}
 
 	return ""
@@ -232,7 +409,111 @@ func getOwnerNameLabel(owner metav1.Object) string {
 		return DisruptionCronNameLabel
 	case *chaosv1beta1.DisruptionRollout:
 		return DisruptionRolloutNameLabel
+	case *chaosv1beta1.DisruptionWorkflow:
+		return DisruptionWorkflowNameLabel
 	}
 
 	return ""
 }
+
+// skippedRuns remembers the last skipped run of each DisruptionCron or DisruptionRollout,
+// so a run postponed over several reconcile loops for the same reason is only reported once
+type skippedRuns struct {
+	lock sync.Mutex
+	runs map[types.NamespacedName]skippedRun
+}
+
+type skippedRun struct {
+	scheduledTime time.Time
+	reason        runSkipReason
+}
+
+// add records the skipped run of the given owner, it returns false if the run has already been skipped for the same reason
+func (s *skippedRuns) add(owner types.NamespacedName, scheduledTime time.Time, reason runSkipReason) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.runs == nil {
+		s.runs = map[types.NamespacedName]skippedRun{}
+	}
+
+	run := skippedRun{scheduledTime: scheduledTime, reason: reason}
+	if last, ok := s.runs[owner]; ok && last.scheduledTime.Equal(scheduledTime) && last.reason == reason {
+		return false
+	}
+
+	s.runs[owner] = run
+
+	return true
+}
+
+// remove forgets the skipped run of the given owner
+func (s *skippedRuns) remove(owner types.NamespacedName) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.runs, owner)
+}
+
+// runReporter reports the decisions taken on the runs of a DisruptionCron or a DisruptionRollout,
+// as events recorded on it and as metrics tagged with its controller, name and namespace
+type runReporter struct {
+	owner       client.Object
+	recorder    record.EventRecorder
+	metricsSink metrics.Sink
+	skippedRuns *skippedRuns
+	log         *zap.SugaredLogger
+}
+
+// scheduled reports the creation of the disruption of the given run
+func (r runReporter) scheduled(disruptionName string, scheduledTime time.Time) {
+	r.skippedRuns.remove(client.ObjectKeyFromObject(r.owner))
+	r.recordEvent(chaosv1beta1.EventRunScheduled, disruptionName, scheduledTime.Format(time.RFC3339))
+	r.handleMetricSinkError(r.metricsSink.MetricRunScheduled(r.tags()))
+}
+
+// skipped reports the given run as skipped for the given reason, unless it has already been reported
+func (r runReporter) skipped(scheduledTime time.Time, reason runSkipReason) {
+	if !r.skippedRuns.add(client.ObjectKeyFromObject(r.owner), scheduledTime, reason) {
+		return
+	}
+
+	r.recordEvent(chaosv1beta1.EventRunSkipped, scheduledTime.Format(time.RFC3339), runSkipReasonMessages[reason])
+	r.handleMetricSinkError(r.metricsSink.MetricRunSkipped(string(reason), r.tags()))
+}
+
+// failed reports the failure to create the disruption of the given run
+func (r runReporter) failed(scheduledTime time.Time, err error) {
+	r.recordEvent(chaosv1beta1.EventRunFailed, scheduledTime.Format(time.RFC3339), err.Error())
+	r.handleMetricSinkError(r.metricsSink.MetricRunFailed(r.tags()))
+}
+
+// replacing reports the deletion of the prior disruptions to start the given run
+func (r runReporter) replacing(scheduledTime time.Time) {
+	r.recordEvent(chaosv1beta1.EventRunReplacing, scheduledTime.Format(time.RFC3339))
+}
+
+func (r runReporter) recordEvent(eventReason chaosv1beta1.DisruptionEventReason, args ...interface{}) {
+	event := chaosv1beta1.Events[eventReason]
+
+	r.recorder.Event(r.owner, event.Type, string(event.Reason), fmt.Sprintf(event.OnDisruptionTemplateMessage, args...))
+}
+
+func (r runReporter) tags() []string {
+	controller := ""
+
+	switch r.owner.(type) {
+	case *chaosv1beta1.DisruptionCron:
+		controller = "disruption-cron"
+	case *chaosv1beta1.DisruptionRollout:
+		controller = "disruption-rollout"
+	}
+
+	return []string{"controller:" + controller, "name:" + r.owner.GetName(), "namespace:" + r.owner.GetNamespace()}
+}
+
+func (r runReporter) handleMetricSinkError(err error) {
+	if err != nil {
+		r.log.Errorw("error sending a metric", "error", err)
+	}
+}
@@ -0,0 +1,303 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GetSelectors", func() {
+	const namespace = "chaos-demo"
+
+	podTemplate := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "demo"},
+		},
+	}
+	objectMeta := metav1.ObjectMeta{Name: "demo", Namespace: namespace}
+
+	newRollout := func(selector map[string]interface{}) *unstructured.Unstructured {
+		rollout := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata": map[string]interface{}{
+				"name":      "demo",
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{},
+		}}
+
+		if selector != nil {
+			Expect(unstructured.SetNestedMap(rollout.Object, selector, "spec", "selector")).To(Succeed())
+		}
+
+		return rollout
+	}
+
+	DescribeTable("with valid target resources",
+		func(obj client.Object, targetResource chaosv1beta1.TargetResourceSpec, expectedSelector labels.Set, expectedAdvancedSelector []metav1.LabelSelectorRequirement) {
+			// Arrange
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).Build()
+
+			// Action
+			selector, advancedSelector, err := GetSelectors(context.Background(), cl, &targetResource, namespace)
+
+			// Assert
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(selector).To(Equal(expectedSelector))
+			Expect(advancedSelector).To(Equal(expectedAdvancedSelector))
+		},
+		Entry("with a daemonset",
+			&appsv1.DaemonSet{ObjectMeta: objectMeta, Spec: appsv1.DaemonSetSpec{Template: podTemplate}},
+			chaosv1beta1.TargetResourceSpec{Kind: "daemonset", Name: "demo"},
+			labels.Set{"app": "demo"},
+			nil,
+		),
+		Entry("with a replicaset",
+			&appsv1.ReplicaSet{ObjectMeta: objectMeta, Spec: appsv1.ReplicaSetSpec{Template: podTemplate}},
+			chaosv1beta1.TargetResourceSpec{Kind: "replicaset", Name: "demo"},
+			labels.Set{"app": "demo"},
+			nil,
+		),
+		Entry("with a job",
+			&batchv1.Job{ObjectMeta: objectMeta, Spec: batchv1.JobSpec{Template: podTemplate}},
+			chaosv1beta1.TargetResourceSpec{Kind: "job", Name: "demo"},
+			labels.Set{"app": "demo"},
+			nil,
+		),
+		Entry("with a generic resource having a label selector",
+			newRollout(map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app": "demo"},
+				"matchExpressions": []interface{}{
+					map[string]interface{}{"key": "track", "operator": "In", "values": []interface{}{"stable", "canary"}},
+				},
+			}),
+			chaosv1beta1.TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1"},
+			labels.Set{"app": "demo"},
+			[]metav1.LabelSelectorRequirement{{Key: "track", Operator: metav1.LabelSelectorOpIn, Values: []string{"stable", "canary"}}},
+		),
+		Entry("with a generic resource having a map of labels as selector",
+			newRollout(map[string]interface{}{"app": "demo"}),
+			chaosv1beta1.TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1"},
+			labels.Set{"app": "demo"},
+			nil,
+		),
+	)
+
+	It("should fail with a generic resource without selector", func() {
+		// Arrange
+		cl := fake.NewClientBuilder().WithObjects(newRollout(nil)).Build()
+		targetResource := chaosv1beta1.TargetResourceSpec{Kind: "Rollout", Name: "demo", APIVersion: "argoproj.io/v1alpha1"}
+
+		// Action
+		_, _, err := GetSelectors(context.Background(), cl, &targetResource, namespace)
+
+		// Assert
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("has no spec.selector field"))
+	})
+})
+
+var _ = Describe("IsRolloutComplete", func() {
+	replicas := int32(3)
+	partition := int32(2)
+
+	DescribeTable("",
+		func(targetObj client.Object, expectedComplete bool) {
+			// Action
+			complete, reason := IsRolloutComplete(targetObj)
+
+			// Assert
+			Expect(complete).To(Equal(expectedComplete))
+
+			if expectedComplete {
+				Expect(reason).To(BeEmpty())
+			} else {
+				Expect(reason).ToNot(BeEmpty())
+			}
+		},
+		Entry("with a rolled out deployment",
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			},
+			true,
+		),
+		Entry("with a deployment whose spec update is not observed yet",
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			},
+			false,
+		),
+		Entry("with a deployment having old replicas",
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3},
+			},
+			false,
+		),
+		Entry("with a deployment having unavailable updated replicas",
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2},
+			},
+			false,
+		),
+		Entry("with a rolled out statefulset",
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "v2", UpdateRevision: "v2"},
+			},
+			true,
+		),
+		Entry("with a statefulset being rolled out",
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"},
+			},
+			false,
+		),
+		Entry("with a statefulset partition rolled out",
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: &replicas,
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+					},
+				},
+				Status: appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"},
+			},
+			true,
+		),
+		Entry("with a rolled out daemonset",
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			},
+			true,
+		),
+		Entry("with a daemonset being rolled out",
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+			},
+			false,
+		),
+		Entry("with a job",
+			&batchv1.Job{},
+			true,
+		),
+	)
+})
+
+var _ = Describe("runReporter", func() {
+	var (
+		scheduledTime time.Time
+		recorder      *record.FakeRecorder
+		metricsSink   *metrics.SinkMock
+		reporter      runReporter
+		tags          []string
+	)
+
+	BeforeEach(func() {
+		scheduledTime = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+		recorder = record.NewFakeRecorder(10)
+		metricsSink = metrics.NewSinkMock(GinkgoT())
+		reporter = runReporter{
+			owner:       &chaosv1beta1.DisruptionCron{ObjectMeta: metav1.ObjectMeta{Name: "network-drop", Namespace: "chaos-demo"}},
+			recorder:    recorder,
+			metricsSink: metricsSink,
+			skippedRuns: &skippedRuns{},
+			log:         zap.NewNop().Sugar(),
+		}
+		tags = []string{"controller:disruption-cron", "name:network-drop", "namespace:chaos-demo"}
+	})
+
+	It("should report a skipped run once per reason", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunSkipped("concurrent_run", tags).Return(nil).Once()
+		metricsSink.EXPECT().MetricRunSkipped("blackout", tags).Return(nil).Once()
+
+		// Action
+		reporter.skipped(scheduledTime, runSkipReasonConcurrentRun)
+		reporter.skipped(scheduledTime, runSkipReasonConcurrentRun)
+		reporter.skipped(scheduledTime, runSkipReasonBlackout)
+
+		// Assert
+		Expect(recorder.Events).To(Receive(Equal("Warning RunSkipped Skipped the run scheduled at 2023-06-01T12:00:00Z as a prior disruption is still running")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("a blackout window is active")))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should report the next run skipped for the same reason", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunSkipped("concurrent_run", tags).Return(nil).Twice()
+
+		// Action
+		reporter.skipped(scheduledTime, runSkipReasonConcurrentRun)
+		reporter.skipped(scheduledTime.Add(time.Hour), runSkipReasonConcurrentRun)
+
+		// Assert
+		Expect(recorder.Events).To(HaveLen(2))
+	})
+
+	It("should report a scheduled run and forget the skipped one", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunSkipped("concurrent_run", tags).Return(nil).Twice()
+		metricsSink.EXPECT().MetricRunScheduled(tags).Return(nil).Once()
+
+		// Action
+		reporter.skipped(scheduledTime, runSkipReasonConcurrentRun)
+		reporter.scheduled("disruption-cron-network-drop", scheduledTime)
+		reporter.skipped(scheduledTime, runSkipReasonConcurrentRun)
+
+		// Assert
+		Expect(recorder.Events).To(Receive(ContainSubstring("RunSkipped")))
+		Expect(recorder.Events).To(Receive(Equal("Normal RunScheduled Created disruption disruption-cron-network-drop for the run scheduled at 2023-06-01T12:00:00Z")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("RunSkipped")))
+	})
+
+	It("should report a failed run", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunFailed(tags).Return(nil).Once()
+
+		// Action
+		reporter.failed(scheduledTime, errors.New("admission webhook denied the request"))
+
+		// Assert
+		Expect(recorder.Events).To(Receive(Equal("Warning RunFailed Failed to create the disruption of the run scheduled at 2023-06-01T12:00:00Z: admission webhook denied the request")))
+	})
+})
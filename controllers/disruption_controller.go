@@ -5,15 +5,20 @@
 
 package controllers
 
-// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions;disruptioncrons;disruptionrollouts,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions/status;disruptioncrons/status;disruptionrollouts/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions/finalizers;disruptioncrons/finalizers;disruptionrollouts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions;disruptioncrons;disruptionrollouts;disruptionworkflows,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptionblackouts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions/status;disruptioncrons/status;disruptionrollouts/status;disruptionworkflows/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptions/finalizers;disruptioncrons/finalizers;disruptionrollouts/finalizers;disruptionworkflows/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
-// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
 
 import (
 	"context"
@@ -25,9 +30,16 @@ import (
 	"time"
 
 	chaosapi "github.com/DataDog/chaos-controller/api"
+	"github.com/DataDog/chaos-controller/audit"
+	audittypes "github.com/DataDog/chaos-controller/audit/types"
 	"github.com/DataDog/chaos-controller/cloudservice"
+	"github.com/DataDog/chaos-controller/guardrails"
 	"github.com/DataDog/chaos-controller/o11y/metrics"
+	metricsprometheus "github.com/DataDog/chaos-controller/o11y/metrics/prometheus"
+	metricstypes "github.com/DataDog/chaos-controller/o11y/metrics/types"
 	"github.com/DataDog/chaos-controller/o11y/tracer"
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
+	"github.com/DataDog/chaos-controller/report"
 	"github.com/DataDog/chaos-controller/safemode"
 	"github.com/DataDog/chaos-controller/targetselector"
 	chaostypes "github.com/DataDog/chaos-controller/types"
@@ -69,6 +81,7 @@ type DisruptionReconciler struct {
 	Recorder                              record.EventRecorder
 	MetricsSink                           metrics.Sink
 	TracerSink                            tracer.Sink
+	TracerOTLP                            otlp.Config // OTLP exporter configuration passed to the injector when the otlp tracer sink is used
 	TargetSelector                        targetselector.TargetSelector
 	InjectorAnnotations                   map[string]string
 	InjectorLabels                        map[string]string
@@ -88,6 +101,11 @@ type DisruptionReconciler struct {
 	EnableObserver                        bool          // Enable Observer on targets update with dynamic targeting
 	CloudServicesProvidersManager         *cloudservice.CloudServicesProvidersManager
 	DisruptionsWatchersManager            watchers.DisruptionsWatchersManager
+	GuardrailsChecker                     guardrails.Checker
+	Quotas                                chaosv1beta1.DisruptionQuotas // Limits of disruptions running concurrently, enforced before creating chaos pods
+	Reports                               report.Config                 // Store a report of each finished disruption in a ConfigMap
+	AuditSink                             audit.Sink                    // Record the actions taken on the targets
+	strategyLimitedSelections             map[types.UID]string          // last targets selection limited by the selection strategy reported for each disruption
 }
 
 type CtxTuple struct {
@@ -206,9 +224,18 @@ func (r *DisruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			// we reach this code when all the cleanup pods have succeeded
 			// we can remove the finalizer and let the resource being garbage collected
 			r.log.Infow("all chaos pods are cleaned up; removing disruption finalizer")
+
+			if r.Reports.Enabled {
+				if err := r.storeReport(instance); err != nil {
+					r.log.Errorw("error storing the disruption report", "error", err)
+				}
+			}
+
 			r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionFinished, "", "")
+			r.auditAction(instance, audittypes.ActionClean, nil)
 
 			r.DisruptionsWatchersManager.RemoveAllWatchers(instance)
+			delete(r.strategyLimitedSelections, instance.UID)
 			controllerutil.RemoveFinalizer(instance, chaostypes.DisruptionFinalizer)
 
 			if err := r.Client.Update(context.Background(), instance); err != nil {
@@ -310,6 +337,11 @@ func (r *DisruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return ctrl.Result{}, fmt.Errorf("error creating chaos pods to start the disruption: %w", err)
 		}
 
+		// propagate the paused state of the disruption to its chaos pods
+		if err := r.handlePause(instance); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error propagating disruption paused state: %w", err)
+		}
+
 		// send injection duration metric representing the time it took to fully inject the disruption until its creation
 		r.handleMetricSinkError(r.MetricsSink.MetricInjectDuration(time.Since(instance.ObjectMeta.CreationTimestamp.Time), []string{"disruptionName:" + instance.Name, "namespace:" + instance.Namespace}))
 
@@ -318,7 +350,23 @@ func (r *DisruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		err := r.updateInjectionStatus(instance)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("error updating disruption injection status: %w", err)
-		} else if instance.Status.InjectionStatus.NotFullyInjected() {
+		}
+
+		// evaluate guardrails and stop the disruption early if one of them is breached
+		if breached, err := r.handleGuardrails(ctx, instance); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error evaluating disruption guardrails: %w", err)
+		} else if breached {
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		// escalate to the next progression step if possible, new targets will be selected on the next reconcile loop
+		if progressed, err := r.handleProgression(ctx, instance); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error handling disruption progression: %w", err)
+		} else if progressed {
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		if instance.Status.InjectionStatus.NotFullyInjected() {
 			// requeue after 15-20 seconds, as default 1ms is too quick here
 			requeueAfter := time.Duration(randSource.Intn(5)+15) * time.Second //nolint:gosec
 			r.log.Infow("disruption is not fully injected yet, requeuing", "injectionStatus", instance.Status.InjectionStatus)
@@ -329,13 +377,13 @@ func (r *DisruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			}, nil
 		}
 
-		disruptionEndAt := calculateRemainingDuration(*instance) + time.Second
-
-		r.log.Infow("requeuing disruption to check once expired", "requeueDelay", disruptionEndAt)
+		// requeue once expired, or earlier to evaluate guardrails again or to escalate to the next progression step
+		requeueAfter, reason := injectedRequeueDelay(instance, time.Now(), r.GuardrailsChecker != nil)
+		r.log.Infow("requeuing disruption "+reason, "requeueDelay", requeueAfter)
 
 		return ctrl.Result{
 				Requeue:      true,
-				RequeueAfter: disruptionEndAt,
+				RequeueAfter: requeueAfter,
 			},
 			r.Client.Update(context.Background(), instance)
 	}
@@ -436,6 +484,16 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 		} else {
 			r.log.Debugf("not injected yet because not all pods are ready %d/%d", len(injectorTargetsCount), instance.Status.DesiredTargetsCount)
 		}
+
+		// chaos pods of a paused disruption are still running but have cleaned their injections
+		if instance.Spec.Paused {
+			switch status {
+			case chaostypes.DisruptionInjectionStatusInjected:
+				status = chaostypes.DisruptionInjectionStatusPausedInjected
+			case chaostypes.DisruptionInjectionStatusPartiallyInjected:
+				status = chaostypes.DisruptionInjectionStatusPausedPartiallyInjected
+			}
+		}
 	}
 
 	// update instance status
@@ -458,6 +516,287 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 	return nil
 }
 
+// handleGuardrails evaluates the guardrails of the given instance once their evaluation interval has elapsed
+// it returns true if a guardrail has been breached, in which case the disruption is deleted to be cleaned early
+func (r *DisruptionReconciler) handleGuardrails(ctx context.Context, instance *chaosv1beta1.Disruption) (bool, error) {
+	if instance.Spec.Guardrails == nil || r.GuardrailsChecker == nil {
+		return false, nil
+	}
+
+	status := instance.Status.Guardrails
+	if status == nil {
+		status = &chaosv1beta1.GuardrailsStatus{}
+	}
+
+	breachMessage := ""
+
+	// a previous reconcile loop already detected a breach but may have failed to delete the disruption
+	if status.BreachedCheck == "" {
+		if !status.LastEvaluationTime.IsZero() && time.Since(status.LastEvaluationTime.Time) < instance.Spec.Guardrails.GetInterval() {
+			return false, nil
+		}
+
+		previousFailures := map[string]int{}
+		for _, checkStatus := range status.Checks {
+			previousFailures[checkStatus.Name] = checkStatus.ConsecutiveFailures
+		}
+
+		checksStatus := make([]chaosv1beta1.GuardrailCheckStatus, 0, len(instance.Spec.Guardrails.Checks))
+
+		for _, check := range instance.Spec.Guardrails.Checks {
+			checkStatus := chaosv1beta1.GuardrailCheckStatus{
+				Name:                check.Name,
+				ConsecutiveFailures: previousFailures[check.Name],
+			}
+
+			result, err := r.GuardrailsChecker.Check(ctx, instance, check)
+
+			switch {
+			case err != nil:
+				// a check which can't be evaluated is not considered as breached
+				r.log.Warnw("error evaluating guardrail check", "check", check.Name, "error", err)
+
+				checkStatus.Message = fmt.Sprintf("error evaluating the check: %s", err)
+			case result.Breached:
+				checkStatus.ConsecutiveFailures++
+				checkStatus.Message = result.Message
+
+				if status.BreachedCheck == "" && checkStatus.ConsecutiveFailures >= check.GetFailureThreshold() {
+					status.BreachedCheck = check.Name
+					breachMessage = result.Message
+				}
+			default:
+				checkStatus.ConsecutiveFailures = 0
+				checkStatus.Message = result.Message
+			}
+
+			checksStatus = append(checksStatus, checkStatus)
+		}
+
+		status.Checks = checksStatus
+		status.LastEvaluationTime = metav1.Now()
+		instance.Status.Guardrails = status
+
+		if err := r.Client.Status().Update(context.Background(), instance); err != nil {
+			return false, fmt.Errorf("error updating guardrails status: %w", err)
+		}
+
+		if status.BreachedCheck == "" {
+			return false, nil
+		}
+
+		r.log.Warnw("guardrail breached, the disruption will now be deleted", "check", status.BreachedCheck, "reason", breachMessage)
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionGuardrailBreached, fmt.Sprintf("%s (%s)", status.BreachedCheck, breachMessage), "")
+	}
+
+	if err := r.Client.Delete(context.Background(), instance); err != nil {
+		return true, fmt.Errorf("error deleting disruption after guardrail %s was breached: %w", status.BreachedCheck, err)
+	}
+
+	return true, nil
+}
+
+// handlePause sets or removes the paused annotation on the chaos pods of the given instance depending on its paused state
+// the injectors clean the disruption when the annotation is set and inject it again once it's removed
+func (r *DisruptionReconciler) handlePause(instance *chaosv1beta1.Disruption) error {
+	chaosPods, err := r.getChaosPods(instance, nil)
+	if err != nil {
+		return fmt.Errorf("error getting chaos pods: %w", err)
+	}
+
+	updatedPods := 0
+
+	for _, chaosPod := range chaosPods {
+		chaosPod := chaosPod
+
+		// a chaos pod being deleted is already cleaning the disruption
+		if !chaosPod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		_, paused := chaosPod.Annotations[chaostypes.PausedAnnotation]
+		if paused == instance.Spec.Paused {
+			continue
+		}
+
+		if instance.Spec.Paused {
+			if chaosPod.Annotations == nil {
+				chaosPod.Annotations = map[string]string{}
+			}
+
+			chaosPod.Annotations[chaostypes.PausedAnnotation] = "true"
+		} else {
+			delete(chaosPod.Annotations, chaostypes.PausedAnnotation)
+		}
+
+		if err := r.Client.Update(context.Background(), &chaosPod); err != nil {
+			return fmt.Errorf("error updating chaos pod %s paused annotation: %w", chaosPod.Name, err)
+		}
+
+		updatedPods++
+	}
+
+	if updatedPods == 0 {
+		return nil
+	}
+
+	if instance.Spec.Paused {
+		r.log.Infow("disruption paused, chaos pods will clean their injections", "chaosPods", updatedPods)
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionPaused, "", "")
+	} else {
+		r.log.Infow("disruption resumed, chaos pods will inject again", "chaosPods", updatedPods)
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionResumed, "", "")
+	}
+
+	return nil
+}
+
+// handleProgression escalates the given instance to the next step of its progression once the current step
+// has been fully injected for the progression interval, as long as the guardrails checks and the targets health stay green
+// it returns true if the disruption escalated, in which case new targets must be selected
+func (r *DisruptionReconciler) handleProgression(ctx context.Context, instance *chaosv1beta1.Disruption) (bool, error) {
+	progression := instance.Spec.Progression
+	if progression == nil {
+		return false, nil
+	}
+
+	status := instance.Status.Progression
+	if status == nil {
+		status = &chaosv1beta1.ProgressionStatus{}
+	}
+
+	if progression.IsLastStep(status.CurrentStep) || instance.Status.InjectionStatus != chaostypes.DisruptionInjectionStatusInjected {
+		return false, nil
+	}
+
+	// the interval of the initial step starts once the disruption is fully injected for the first time
+	if status.LastStepTime == nil {
+		now := metav1.Now()
+		status.LastStepTime = &now
+		instance.Status.Progression = status
+
+		return false, r.Client.Status().Update(context.Background(), instance)
+	}
+
+	if time.Since(status.LastStepTime.Time) < progression.Interval.Duration() {
+		return false, nil
+	}
+
+	holdReason, err := r.getProgressionHoldReason(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+
+	if holdReason != "" {
+		// only notify once per hold reason to avoid flooding events on every reconcile loop
+		if status.Message != holdReason {
+			r.log.Infow("progression is on hold", "step", status.CurrentStep, "reason", holdReason)
+			r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionProgressionOnHold, holdReason, "")
+
+			status.Message = holdReason
+			instance.Status.Progression = status
+
+			return false, r.Client.Status().Update(context.Background(), instance)
+		}
+
+		return false, nil
+	}
+
+	now := metav1.Now()
+	status.CurrentStep++
+	status.LastStepTime = &now
+	status.Message = ""
+	instance.Status.Progression = status
+
+	count := instance.GetTargetsCount()
+
+	r.log.Infow("escalating disruption to the next progression step", "step", status.CurrentStep, "count", count.String())
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionProgressed, fmt.Sprintf("step %d (count %s)", status.CurrentStep, count.String()), "")
+
+	if err := r.Client.Status().Update(context.Background(), instance); err != nil {
+		return false, fmt.Errorf("error updating progression status: %w", err)
+	}
+
+	return true, nil
+}
+
+// getProgressionHoldReason returns the reason why the progression of the given instance can't escalate, if any
+// the progression holds while any guardrail check is failing or while the targets readiness is below the expected minimum
+func (r *DisruptionReconciler) getProgressionHoldReason(ctx context.Context, instance *chaosv1beta1.Disruption) (string, error) {
+	if instance.Status.Guardrails != nil {
+		for _, check := range instance.Status.Guardrails.Checks {
+			if check.ConsecutiveFailures > 0 {
+				return fmt.Sprintf("guardrail check %s is failing: %s", check.Name, check.Message), nil
+			}
+		}
+	}
+
+	if instance.Spec.Progression.MinReadyPercentage == 0 || r.GuardrailsChecker == nil {
+		return "", nil
+	}
+
+	result, err := r.GuardrailsChecker.Check(ctx, instance, chaosv1beta1.GuardrailCheck{
+		Name: "progression",
+		TargetReadiness: &chaosv1beta1.GuardrailTargetReadinessCheck{
+			MinReadyPercentage: instance.Spec.Progression.MinReadyPercentage,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error checking targets readiness: %w", err)
+	}
+
+	if result.Breached {
+		return result.Message, nil
+	}
+
+	return "", nil
+}
+
+// injectedRequeueDelay returns the delay before reconciling the given injected instance again, along with the reason of the requeue
+// it is the earliest of the instance expiration, its next guardrails evaluation and its next progression step
+func injectedRequeueDelay(instance *chaosv1beta1.Disruption, now time.Time, guardrailsEnabled bool) (time.Duration, string) {
+	requeueAfter := calculateRemainingDuration(*instance) + time.Second
+	reason := "to check once expired"
+
+	if guardrailsEnabled && instance.Spec.Guardrails != nil && instance.Spec.Guardrails.GetInterval() < requeueAfter {
+		requeueAfter = instance.Spec.Guardrails.GetInterval()
+		reason = "to evaluate guardrails"
+	}
+
+	if nextStepIn, ok := progressionNextStepDelay(instance, now); ok && nextStepIn < requeueAfter {
+		requeueAfter = nextStepIn
+		reason = "to escalate to the next progression step"
+	}
+
+	return requeueAfter, reason
+}
+
+// progressionNextStepDelay returns the delay before the given instance can escalate to the next step of its progression
+// it returns false if the disruption has no further step to escalate to
+func progressionNextStepDelay(instance *chaosv1beta1.Disruption, now time.Time) (time.Duration, bool) {
+	progression := instance.Spec.Progression
+	if progression == nil {
+		return 0, false
+	}
+
+	status := instance.Status.Progression
+	if status == nil || status.LastStepTime == nil {
+		return progression.Interval.Duration(), true
+	}
+
+	if progression.IsLastStep(status.CurrentStep) {
+		return 0, false
+	}
+
+	delay := status.LastStepTime.Add(progression.Interval.Duration()).Sub(now)
+	if delay <= 0 {
+		// the step is due but is on hold, check again after another interval
+		delay = progression.Interval.Duration()
+	}
+
+	return delay, true
+}
+
 // startInjection creates non-existing chaos pod for the given disruption
 func (r *DisruptionReconciler) startInjection(instance *chaosv1beta1.Disruption) error {
 	// chaosPodsMap is used to check if a target's chaos pods already exist or not
@@ -485,6 +824,15 @@ func (r *DisruptionReconciler) startInjection(instance *chaosv1beta1.Disruption)
 		r.log.Infow("starting targets injection", "targets", instance.Status.TargetInjections)
 	}
 
+	// re-check the quotas before creating new chaos pods, as other disruptions may have started since this one was admitted
+	if r.hasTargetsToInject(instance, chaosPodsMap) {
+		if exceeded, err := r.handleQuotas(instance); err != nil {
+			return fmt.Errorf("error checking disruption quotas: %w", err)
+		} else if exceeded {
+			return nil
+		}
+	}
+
 	// iterate through target + existing disruption kind -- to ensure all chaos pods exist
 	for targetName, injection := range instance.Status.TargetInjections {
 		for _, disKind := range chaostypes.DisruptionKindNames {
@@ -516,6 +864,41 @@ func (r *DisruptionReconciler) startInjection(instance *chaosv1beta1.Disruption)
 	return nil
 }
 
+// hasTargetsToInject returns true if at least one target of the given instance is missing a chaos pod
+func (r *DisruptionReconciler) hasTargetsToInject(instance *chaosv1beta1.Disruption, chaosPodsMap map[string]map[string]bool) bool {
+	kindsCount := len(instance.Spec.KindNames())
+
+	for targetName := range instance.Status.TargetInjections {
+		if len(chaosPodsMap[targetName]) < kindsCount {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleQuotas checks the concurrent disruptions quotas for the given instance and its targets
+// it returns true and records an event if the quotas are exceeded, in which case no chaos pods must be created
+func (r *DisruptionReconciler) handleQuotas(instance *chaosv1beta1.Disruption) (bool, error) {
+	if instance.Spec.Unsafemode != nil && instance.Spec.Unsafemode.DisableAll {
+		return false, nil
+	}
+
+	reasons, err := r.Quotas.Check(context.Background(), r.Client, instance, instance.Status.TargetInjections.GetTargetNames())
+	if err != nil {
+		return false, err
+	}
+
+	if len(reasons) == 0 {
+		return false, nil
+	}
+
+	r.log.Infow("disruption exceeds the concurrent disruptions quotas, not creating chaos pods", "reasons", reasons)
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionQuotaExceeded, strings.Join(reasons, ", "), "")
+
+	return true, nil
+}
+
 // createChaosPods attempts to create all the chaos pods for a given target. If a given chaos pod already exists, it is not recreated.
 func (r *DisruptionReconciler) createChaosPods(instance *chaosv1beta1.Disruption, target string) error {
 	var err error
@@ -569,6 +952,8 @@ func (r *DisruptionReconciler) createChaosPods(instance *chaosv1beta1.Disruption
 		return nil
 	}
 
+	created := false
+
 	// create injection pods
 	for _, targetChaosPod := range targetChaosPods {
 		// check if an injection pod already exists for the given (instance, namespace, disruption kind) tuple
@@ -602,6 +987,8 @@ func (r *DisruptionReconciler) createChaosPods(instance *chaosv1beta1.Disruption
 			r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionChaosPodCreated, instance.Name, target)
 			r.recordEventOnTarget(instance, target, chaosv1beta1.EventDisrupted, targetChaosPod.Name, instance.Name)
 			r.handleMetricSinkError(r.MetricsSink.MetricPodsCreated(target, instance.Name, instance.Namespace, true))
+
+			created = true
 		case 1:
 			r.log.Debugw("an injection pod is already existing for the selected target", "target", target, "chaosPod", found[0].Name)
 		default:
@@ -614,6 +1001,10 @@ func (r *DisruptionReconciler) createChaosPods(instance *chaosv1beta1.Disruption
 		}
 	}
 
+	if created {
+		r.auditAction(instance, audittypes.ActionInject, []string{target})
+	}
+
 	return nil
 }
 
@@ -897,10 +1288,13 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 
 	instance.Status.RemoveDeadTargets(matchingTargets)
 
-	// instance.Spec.Count is a string that either represents a percentage or a value, we do the translation here
-	targetsCount, err := getScaledValueFromIntOrPercent(instance.Spec.Count, len(matchingTargets), true)
+	// the count is a string that either represents a percentage or a value, we do the translation here
+	// it is the count of the current progression step if the disruption has a progression
+	count := instance.GetTargetsCount()
+
+	targetsCount, err := getScaledValueFromIntOrPercent(count, len(matchingTargets), true)
 	if err != nil {
-		targetsCount = instance.Spec.Count.IntValue()
+		targetsCount = count.IntValue()
 	}
 
 	// filter matching targets to only get eligible ones
@@ -927,7 +1321,12 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 	cTargetsCount := len(instance.Status.TargetInjections)
 	dTargetsCount := targetsCount
 
-	if cTargetsCount < dTargetsCount {
+	if cTargetsCount < dTargetsCount && instance.Spec.SelectionStrategy != nil {
+		// not enough targets: pick more targets from eligibleTargets following the selection strategy
+		if err := r.addStrategyTargets(instance, dTargetsCount-cTargetsCount, eligibleTargets); err != nil {
+			return fmt.Errorf("error picking targets following the selection strategy: %w", err)
+		}
+	} else if cTargetsCount < dTargetsCount {
 		// not enough targets: pick more targets from eligibleTargets
 		instance.Status.AddTargets(dTargetsCount-cTargetsCount, eligibleTargets)
 	} else if cTargetsCount > dTargetsCount {
@@ -943,6 +1342,42 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 	return r.Client.Status().Update(context.Background(), instance)
 }
 
+// addStrategyTargets adds up to newTargetsCount targets from the eligible ones to the instance, following its selection strategy
+// a selection limited by the strategy is reported once, and again only if the picked or missing targets counts change
+func (r *DisruptionReconciler) addStrategyTargets(instance *chaosv1beta1.Disruption, newTargetsCount int, eligibleTargets chaosv1beta1.TargetInjections) error {
+	pickedTargets, err := targetselector.PickTargets(r.Client, instance, eligibleTargets.GetTargetNames(), newTargetsCount)
+	if err != nil {
+		return err
+	}
+
+	if len(pickedTargets) < newTargetsCount {
+		r.log.Infow("not enough eligible targets satisfy the selection strategy", "pickedTargets", pickedTargets, "newTargetsCount", newTargetsCount)
+
+		selection := fmt.Sprintf("%d of the %d missing targets", len(pickedTargets), newTargetsCount)
+		if r.strategyLimitedSelections[instance.UID] != selection {
+			r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionSelectionStrategyLimited, selection, "")
+
+			if r.strategyLimitedSelections == nil {
+				r.strategyLimitedSelections = map[types.UID]string{}
+			}
+
+			r.strategyLimitedSelections[instance.UID] = selection
+		}
+	} else {
+		delete(r.strategyLimitedSelections, instance.UID)
+	}
+
+	if instance.Status.TargetInjections == nil {
+		instance.Status.TargetInjections = chaosv1beta1.TargetInjections{}
+	}
+
+	for _, target := range pickedTargets {
+		instance.Status.TargetInjections[target] = eligibleTargets[target]
+	}
+
+	return nil
+}
+
 // getMatchingTargets fetches all existing target fitting the disruption's selector
 func (r *DisruptionReconciler) getSelectorMatchingTargets(instance *chaosv1beta1.Disruption) ([]string, int, error) {
 	healthyMatchingTargets := []string{}
@@ -1197,6 +1632,29 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		podLabels[k] = v
 	}
 
+	podAnnotations := make(map[string]string)
+	for k, v := range r.InjectorAnnotations {
+		podAnnotations[k] = v
+	}
+
+	// chaos pods created while the disruption is paused must not inject until it's resumed
+	if instance.Spec.Paused {
+		podAnnotations[chaostypes.PausedAnnotation] = "true"
+	}
+
+	// expose the injector scrape endpoint when metrics are sent to prometheus
+	if r.MetricsSink != nil && r.MetricsSink.GetSinkName() == string(metricstypes.SinkDriverPrometheus) {
+		podAnnotations["prometheus.io/scrape"] = "true"
+		podAnnotations["prometheus.io/port"] = fmt.Sprint(metricsprometheus.InjectorScrapePort)
+		podAnnotations["prometheus.io/path"] = metricsprometheus.InjectorScrapePath
+
+		podSpec.Containers[0].Ports = append(podSpec.Containers[0].Ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: metricsprometheus.InjectorScrapePort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
 	podLabels[chaostypes.TargetLabel] = targetName                      // target name label
 	podLabels[chaostypes.DisruptionKindLabel] = string(kind)            // disruption kind label
 	podLabels[chaostypes.DisruptionNameLabel] = instance.Name           // disruption name label, used to determine ownership
@@ -1207,7 +1665,7 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("chaos-%s-", instance.Name), // generate the pod name automatically with a prefix
 			Namespace:    r.ChaosNamespace,                        // chaos pods need to be in the same namespace as their service account to run
-			Annotations:  r.InjectorAnnotations,                   // add extra annotations passed to the controller
+			Annotations:  podAnnotations,                          // add extra annotations passed to the controller
 			Labels:       podLabels,                               // add default and extra podLabels passed to the controller
 		},
 		Spec: podSpec,
@@ -1243,6 +1701,78 @@ func (r *DisruptionReconciler) recordEventOnDisruption(instance *chaosv1beta1.Di
 	}
 }
 
+// auditAction writes an audit entry of the given action taken by the controller on behalf of the disruption author
+// the entry targets default to the disruption targets when none are given
+func (r *DisruptionReconciler) auditAction(instance *chaosv1beta1.Disruption, action audittypes.Action, targets []string) {
+	if r.AuditSink == nil {
+		return
+	}
+
+	// disruptions created while the user info webhook was disabled have no author
+	userInfo, _ := instance.UserInfo()
+
+	entry := audit.NewEntry(audittypes.SourceController, action, userInfo, *instance, time.Now())
+	if targets != nil {
+		entry.Targets = targets
+	}
+
+	if err := r.AuditSink.Write(entry); err != nil {
+		r.log.Errorw("error writing audit entry", "error", err, "action", action, "sink", r.AuditSink.GetSinkName())
+	}
+}
+
+// storeReport assembles the report of the given finished disruption and stores it in a ConfigMap
+func (r *DisruptionReconciler) storeReport(instance *chaosv1beta1.Disruption) error {
+	disruptionEvents, err := watchers.GetEventsFromCurrentDisruption(r.Reader, chaosv1beta1.DisruptionKind, instance.ObjectMeta, instance.CreationTimestamp.Time)
+	if err != nil {
+		return fmt.Errorf("error listing disruption events: %w", err)
+	}
+
+	// targets are pods in the disruption namespace or nodes
+	targetKind, targetNamespace := "Pod", instance.Namespace
+	if instance.Spec.Level == chaostypes.DisruptionLevelNode {
+		targetKind, targetNamespace = "Node", ""
+	}
+
+	targetsEvents := map[string][]corev1.Event{}
+
+	for _, targetName := range instance.Status.TargetInjections.GetTargetNames() {
+		targetEvents, err := watchers.GetEventsFromCurrentDisruption(r.Reader, targetKind, metav1.ObjectMeta{Name: targetName, Namespace: targetNamespace}, instance.CreationTimestamp.Time)
+		if err != nil {
+			r.log.Warnw("error listing target events, the report won't contain them", "target", targetName, "error", err)
+
+			continue
+		}
+
+		targetsEvents[targetName] = targetEvents
+	}
+
+	namespace := r.Reports.Namespace
+	if namespace == "" {
+		namespace = r.ChaosNamespace
+	}
+
+	configMap, err := report.New(*instance, disruptionEvents, targetsEvents, time.Now()).ConfigMap(namespace)
+	if err != nil {
+		return err
+	}
+
+	// the report may already exist if the finalizer removal failed after it was stored
+	if err := r.Client.Create(context.Background(), configMap); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("error creating the report ConfigMap: %w", err)
+		}
+
+		if err := r.Client.Update(context.Background(), configMap); err != nil {
+			return fmt.Errorf("error updating the report ConfigMap: %w", err)
+		}
+	}
+
+	r.log.Infow("disruption report stored", "reportConfigMap", configMap.Namespace+"/"+configMap.Name)
+
+	return nil
+}
+
 func (r *DisruptionReconciler) emitKindCountMetrics(instance *chaosv1beta1.Disruption) {
 	for _, kind := range instance.Spec.KindNames() {
 		r.handleMetricSinkError(r.MetricsSink.MetricDisruptionsCount(kind, []string{"disruptionName:" + instance.Name, "namespace:" + instance.Namespace}))
@@ -1315,12 +1845,18 @@ func (r *DisruptionReconciler) generateChaosPods(instance *chaosv1beta1.Disrupti
 			PulseDormantDuration: pulseDormantDuration,
 			NotInjectedBefore:    notInjectedBefore,
 			MetricsSink:          r.MetricsSink.GetSinkName(),
+			TracerOTLP:           r.TracerOTLP,
+			SpanContext:          instance.MarshaledSpanContext(),
 			AllowedHosts:         allowedHosts,
 			DNSServer:            r.InjectorDNSDisruptionDNSServer,
 			KubeDNS:              r.InjectorDNSDisruptionKubeDNS,
 			ChaosNamespace:       r.ChaosNamespace,
 		}
 
+		if r.TracerSink != nil {
+			xargs.TracerSink = r.TracerSink.GetSinkName()
+		}
+
 		// generate args for pod
 		args := xargs.CreateCmdArgs(subspec.GenerateArgs())
 
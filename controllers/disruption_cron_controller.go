@@ -11,19 +11,30 @@ import (
 	"time"
 
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
 	"github.com/robfig/cron"
 	"go.uber.org/zap"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// disruptionCronReplaceRequeueDelay is the delay before checking again whether the replaced disruptions are gone
+const disruptionCronReplaceRequeueDelay = 5 * time.Second
+
 type DisruptionCronReconciler struct {
-	Client  client.Client
-	Scheme  *runtime.Scheme
-	BaseLog *zap.SugaredLogger
-	log     *zap.SugaredLogger
+	Client          client.Client
+	Scheme          *runtime.Scheme
+	BaseLog         *zap.SugaredLogger
+	BlackoutEnabled bool
+	Recorder        record.EventRecorder
+	MetricsSink     metrics.Sink
+	log             *zap.SugaredLogger
+	skippedRuns     skippedRuns
 }
 
 func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -34,6 +45,10 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Fetch DisruptionCron instance
 	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.skippedRuns.remove(req.NamespacedName)
+		}
+
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -42,6 +57,12 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	if err := instance.Spec.TargetResource.Validate(); err != nil {
+		r.log.Errorw("invalid target resource", "err", err)
+		// Don't requeue until an update to the spec is received
+		return ctrl.Result{}, nil
+	}
+
 	// Update the DisruptionCron status based on the presence of the target resource
 	// If the target resource has been missing for longer than the TargetResourceMissingThreshold, delete the instance
 	targetResourceExists, instanceDeleted, err := r.updateTargetResourcePreviouslyMissing(ctx, instance)
@@ -61,6 +82,14 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	// Record the runs of the DisruptionCron in its status history
+	if updateDisruptionCronHistory(instance, disruptions, time.Now()) {
+		if err := r.Client.Status().Update(ctx, instance); err != nil {
+			r.log.Errorw("unable to update History of DisruptionCron status", "err", err)
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Update the DisruptionCron status with the time when the last disruption was successfully scheduled
 	if err := r.updateLastScheduleTime(ctx, instance, disruptions); err != nil {
 		r.log.Errorw("unable to update LastScheduleTime of DisruptionCron status", "err", err)
@@ -84,21 +113,35 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Run a new disruption if the following conditions are met:
 	// 1. It's on schedule
-	// 2. The target resource is available
-	// 3. It's not blocked by another disruption already running
+	// 2. It's not suspended
+	// 3. The target resource is available
 	// 4. It's not past the deadline
+	// 5. It's not during a blackout window
+	// 6. It's not blocked by another disruption already running, unless it replaces it
 	if missedRun.IsZero() {
 		r.log.Infow(fmt.Sprintf("no missed runs detected, scheduling next check in %s", requeueTime))
 		return scheduledResult, nil
 	}
 
-	if !targetResourceExists {
-		r.log.Infow(fmt.Sprintf("target resource is missing, scheduling next check in %s", requeueTime))
+	if instance.Spec.Suspend {
+		r.log.Infow(fmt.Sprintf("DisruptionCron is suspended, skipping the run scheduled at %s, scheduling next check in %s", missedRun, requeueTime))
+		r.runReporter(instance).skipped(missedRun, runSkipReasonSuspended)
+
+		// mark the run as scheduled so it won't be started once the DisruptionCron is resumed
+		instance.Status.LastScheduleTime = &metav1.Time{Time: missedRun}
+
+		if err := r.Client.Status().Update(ctx, instance); err != nil {
+			r.log.Warnw("unable to update LastScheduleTime of DisruptionCron status", "err", err)
+			return ctrl.Result{}, err
+		}
+
 		return scheduledResult, nil
 	}
 
-	if len(disruptions.Items) > 0 {
-		r.log.Infow(fmt.Sprintf("cannot start a new disruption as a prior one is still running, scheduling next check in %s", requeueTime), "numActiveDisruptions", len(disruptions.Items))
+	if !targetResourceExists {
+		r.log.Infow(fmt.Sprintf("target resource is missing, scheduling next check in %s", requeueTime))
+		r.runReporter(instance).skipped(missedRun, runSkipReasonTargetMissing)
+
 		return scheduledResult, nil
 	}
 
@@ -109,9 +152,54 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	if tooLate {
 		r.log.Infow(fmt.Sprintf("missed schedule to start a disruption at %s, scheduling next check in %s", missedRun, requeueTime))
+		r.runReporter(instance).skipped(missedRun, runSkipReasonTooLate)
+
 		return scheduledResult, nil
 	}
 
+	if r.BlackoutEnabled {
+		blackout, err := chaosv1beta1.GetActiveBlackout(ctx, r.Client, r.log, r.Recorder, instance.Namespace, time.Now())
+		if err != nil {
+			r.log.Errorw("unable to check disruption blackouts", "err", err)
+			return ctrl.Result{}, err
+		}
+
+		if blackout != nil {
+			r.log.Infow(fmt.Sprintf("skipping the run scheduled at %s during blackout, scheduling next check in %s", missedRun, requeueTime), "blackout", blackout.Name, "reason", blackout.Spec.Reason)
+			r.runReporter(instance).skipped(missedRun, runSkipReasonBlackout)
+
+			// mark the run as scheduled so it won't be started once the blackout window is over
+			instance.Status.LastScheduleTime = &metav1.Time{Time: missedRun}
+
+			if err := r.Client.Status().Update(ctx, instance); err != nil {
+				r.log.Warnw("unable to update LastScheduleTime of DisruptionCron status", "err", err)
+				return ctrl.Result{}, err
+			}
+
+			return scheduledResult, nil
+		}
+	}
+
+	if len(disruptions.Items) > 0 {
+		if instance.Spec.GetConcurrencyPolicy() != chaosv1beta1.DisruptionCronConcurrencyPolicyReplace {
+			r.log.Infow(fmt.Sprintf("cannot start a new disruption as a prior one is still running, scheduling next check in %s", requeueTime), "numActiveDisruptions", len(disruptions.Items))
+			r.runReporter(instance).skipped(missedRun, runSkipReasonConcurrentRun)
+
+			return scheduledResult, nil
+		}
+
+		// the disruption name acts as a lock, so the new run can only start once the replaced disruptions are gone
+		if err := r.deleteDisruptions(ctx, disruptions); err != nil {
+			r.log.Errorw("unable to delete the disruptions to replace", "err", err)
+			return ctrl.Result{}, err
+		}
+
+		r.log.Infow(fmt.Sprintf("replacing the prior disruptions still running, scheduling next check in %s", disruptionCronReplaceRequeueDelay), "numActiveDisruptions", len(disruptions.Items))
+		r.runReporter(instance).replacing(missedRun)
+
+		return ctrl.Result{RequeueAfter: disruptionCronReplaceRequeueDelay}, nil
+	}
+
 	r.log.Infow("processing current run", "currentRun", missedRun.Format(time.UnixDate))
 
 	// Create disruption for current run
@@ -119,16 +207,21 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	if err != nil {
 		r.log.Warnw("unable to construct disruption from template", "err", err)
+		r.runReporter(instance).failed(missedRun, err)
+
 		// Don't requeue until update to the spec is received
 		return scheduledResult, nil
 	}
 
 	if err := r.Client.Create(ctx, disruption); err != nil {
 		r.log.Warnw("unable to create Disruption for DisruptionCron", "disruption", disruption, "err", err)
+		r.runReporter(instance).failed(missedRun, err)
+
 		return ctrl.Result{}, err
 	}
 
 	r.log.Infow("created Disruption for DisruptionCron run", "disruptionName", disruption.Name)
+	r.runReporter(instance).scheduled(disruption.Name, missedRun)
 
 	// ------------------------------------------------------------------ //
 	// If this process restarts at this point (after posting a disruption, but
@@ -147,11 +240,24 @@ func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return scheduledResult, nil
 }
 
+// runReporter returns the reporter of the decisions taken on the runs of the given DisruptionCron
+func (r *DisruptionCronReconciler) runReporter(instance *chaosv1beta1.DisruptionCron) runReporter {
+	return runReporter{
+		owner:       instance,
+		recorder:    r.Recorder,
+		metricsSink: r.MetricsSink,
+		skippedRuns: &r.skippedRuns,
+		log:         r.log,
+	}
+}
+
 // updateLastScheduleTime updates the LastScheduleTime in the status of a DisruptionCron instance
 // based on the most recent schedule time among the given disruptions.
 func (r *DisruptionCronReconciler) updateLastScheduleTime(ctx context.Context, instance *chaosv1beta1.DisruptionCron, disruptions *chaosv1beta1.DisruptionList) error {
 	mostRecentScheduleTime := GetMostRecentScheduleTime(r.log, disruptions) // find the last run so we can update the status
-	if mostRecentScheduleTime != nil {
+
+	// never move the LastScheduleTime backward, it can be more recent than the running disruptions when runs have been skipped
+	if mostRecentScheduleTime != nil && (instance.Status.LastScheduleTime == nil || mostRecentScheduleTime.After(instance.Status.LastScheduleTime.Time)) {
 		instance.Status.LastScheduleTime = &metav1.Time{Time: *mostRecentScheduleTime}
 		return r.Client.Status().Update(ctx, instance)
 	}
@@ -159,6 +265,97 @@ func (r *DisruptionCronReconciler) updateLastScheduleTime(ctx context.Context, i
 	return nil // No need to update if mostRecentScheduleTime is nil
 }
 
+// deleteDisruptions deletes the given disruptions, unless they are already being deleted
+func (r *DisruptionCronReconciler) deleteDisruptions(ctx context.Context, disruptions *chaosv1beta1.DisruptionList) error {
+	for i := range disruptions.Items {
+		disruption := &disruptions.Items[i]
+
+		if !disruption.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, disruption); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete disruption %s: %w", disruption.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// updateDisruptionCronHistory records the child disruptions in the history of the DisruptionCron and tracks their injection status until they are over.
+// Runs are identified by the name and the creation time of their disruption since every run reuses the same disruption name.
+// The history is trimmed to the history limit and the function returns true if it has been modified.
+func updateDisruptionCronHistory(instance *chaosv1beta1.DisruptionCron, disruptions *chaosv1beta1.DisruptionList, now time.Time) bool {
+	updated := false
+	observed := make([]bool, len(instance.Status.History))
+
+	for _, disruption := range disruptions.Items {
+		index := -1
+
+		for i, run := range instance.Status.History {
+			if run.DisruptionName == disruption.Name && run.StartTime.Equal(&disruption.CreationTimestamp) {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			instance.Status.History = append(instance.Status.History, chaosv1beta1.DisruptionCronRun{
+				DisruptionName: disruption.Name,
+				StartTime:      disruption.CreationTimestamp,
+			})
+			observed = append(observed, true)
+			index = len(instance.Status.History) - 1
+			updated = true
+		}
+
+		observed[index] = true
+		run := &instance.Status.History[index]
+
+		// the health keeps being tracked after the end of the run as the targets recover while the disruption is being cleaned
+		if !apiequality.Semantic.DeepEqual(run.Health, disruption.Status.Health) {
+			run.Health = disruption.Status.Health.DeepCopy()
+			updated = true
+		}
+
+		if run.EndTime != nil {
+			continue
+		}
+
+		if run.InjectionStatus != disruption.Status.InjectionStatus {
+			run.InjectionStatus = disruption.Status.InjectionStatus
+			updated = true
+		}
+
+		// the run is over once its disruption is being deleted or expired
+		remainingDuration := calculateRemainingDuration(disruption)
+		if !disruption.DeletionTimestamp.IsZero() || disruption.Status.InjectionStatus.Previously() || remainingDuration <= 0 {
+			endTime := now
+			if remainingDuration <= 0 {
+				endTime = now.Add(remainingDuration)
+			}
+
+			run.EndTime = &metav1.Time{Time: endTime}
+			updated = true
+		}
+	}
+
+	// runs whose disruption is gone are over
+	for i := range instance.Status.History {
+		if !observed[i] && instance.Status.History[i].EndTime == nil {
+			instance.Status.History[i].EndTime = &metav1.Time{Time: now}
+			updated = true
+		}
+	}
+
+	if limit := instance.Spec.GetHistoryLimit(); len(instance.Status.History) > limit {
+		instance.Status.History = instance.Status.History[len(instance.Status.History)-limit:]
+		updated = true
+	}
+
+	return updated
+}
+
 // updateTargetResourcePreviouslyMissing updates the status when the target resource was previously missing.
 // The function returns three values:
 // - bool: Indicates whether the target resource is currently found.
@@ -241,11 +438,20 @@ func (r *DisruptionCronReconciler) getNextSchedule(instance *chaosv1beta1.Disrup
 		return time.Time{}, time.Time{}, err
 	}
 
+	location, err := instance.Spec.GetLocation()
+	if err != nil {
+		r.log.Errorw("Unknown time zone", "timeZone", instance.Spec.TimeZone, "err", err)
+		return time.Time{}, time.Time{}, err
+	}
+
+	// the schedule is evaluated in the location of the given times
+	now = now.In(location)
+
 	var earliestTime time.Time
 	if instance.Status.LastScheduleTime != nil {
-		earliestTime = instance.Status.LastScheduleTime.Time
+		earliestTime = instance.Status.LastScheduleTime.Time.In(location)
 	} else {
-		earliestTime = instance.ObjectMeta.CreationTimestamp.Time
+		earliestTime = instance.ObjectMeta.CreationTimestamp.Time.In(location)
 	}
 
 	if instance.Spec.DelayedStartTolerance.Duration() > 0 {
@@ -278,5 +484,6 @@ func (r *DisruptionCronReconciler) getNextSchedule(instance *chaosv1beta1.Disrup
 func (r *DisruptionCronReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&chaosv1beta1.DisruptionCron{}).
+		Owns(&chaosv1beta1.Disruption{}).
 		Complete(r)
 }
@@ -0,0 +1,326 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionCron helpers", func() {
+	Describe("updateDisruptionCronHistory", func() {
+		var (
+			now         time.Time
+			instance    *chaosv1beta1.DisruptionCron
+			disruptions *chaosv1beta1.DisruptionList
+		)
+
+		newDisruption := func(creationTime time.Time, injectionStatus chaostypes.DisruptionInjectionStatus) chaosv1beta1.Disruption {
+			return chaosv1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "disruption-cron-network-drop",
+					CreationTimestamp: metav1.NewTime(creationTime),
+				},
+				Spec: chaosv1beta1.DisruptionSpec{
+					Duration: "1h",
+				},
+				Status: chaosv1beta1.DisruptionStatus{
+					InjectionStatus: injectionStatus,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			instance = &chaosv1beta1.DisruptionCron{}
+			disruptions = &chaosv1beta1.DisruptionList{}
+		})
+
+		It("should record a new run and track its injection status", func() {
+			// Arrange
+			disruptions.Items = append(disruptions.Items, newDisruption(now, chaostypes.DisruptionInjectionStatusInjected))
+
+			// Action
+			updated := updateDisruptionCronHistory(instance, disruptions, now)
+
+			// Assert
+			Expect(updated).To(BeTrue())
+			Expect(instance.Status.History).To(HaveLen(1))
+			Expect(instance.Status.History[0].DisruptionName).To(Equal("disruption-cron-network-drop"))
+			Expect(instance.Status.History[0].StartTime.Time).To(Equal(now))
+			Expect(instance.Status.History[0].EndTime).To(BeNil())
+			Expect(instance.Status.History[0].InjectionStatus).To(Equal(chaostypes.DisruptionInjectionStatusInjected))
+
+			By("not updating the history when nothing changed")
+			Expect(updateDisruptionCronHistory(instance, disruptions, now)).To(BeFalse())
+		})
+
+		It("should end the runs whose disruption is expired or gone", func() {
+			// Arrange
+			instance.Status.History = []chaosv1beta1.DisruptionCronRun{
+				{
+					DisruptionName:  "disruption-cron-network-drop",
+					StartTime:       metav1.NewTime(now.Add(-3 * time.Hour)),
+					InjectionStatus: chaostypes.DisruptionInjectionStatusInjected,
+				},
+				{
+					DisruptionName:  "disruption-cron-network-drop",
+					StartTime:       metav1.NewTime(now.Add(-2 * time.Hour)),
+					InjectionStatus: chaostypes.DisruptionInjectionStatusInjected,
+				},
+			}
+			disruptions.Items = append(disruptions.Items, newDisruption(now.Add(-2*time.Hour), chaostypes.DisruptionInjectionStatusPreviouslyInjected))
+
+			// Action
+			updated := updateDisruptionCronHistory(instance, disruptions, now)
+
+			// Assert
+			Expect(updated).To(BeTrue())
+			Expect(instance.Status.History).To(HaveLen(2))
+			Expect(instance.Status.History[0].EndTime).ToNot(BeNil())
+			Expect(instance.Status.History[0].EndTime.Time).To(Equal(now))
+			Expect(instance.Status.History[1].EndTime).ToNot(BeNil())
+			Expect(instance.Status.History[1].EndTime.Time).To(BeTemporally("~", now.Add(-time.Hour), time.Minute))
+			Expect(instance.Status.History[1].InjectionStatus).To(Equal(chaostypes.DisruptionInjectionStatusPreviouslyInjected))
+		})
+
+		It("should keep tracking the targets health of ended runs whose disruption is being cleaned", func() {
+			// Arrange
+			disruption := newDisruption(now.Add(-2*time.Hour), chaostypes.DisruptionInjectionStatusPreviouslyInjected)
+			disruption.Status.Health = &chaosv1beta1.TargetsHealth{
+				ContainerRestarts: 2,
+				TimeToRecover:     "45s",
+			}
+			disruptions.Items = append(disruptions.Items, disruption)
+
+			instance.Status.History = []chaosv1beta1.DisruptionCronRun{
+				{
+					DisruptionName:  "disruption-cron-network-drop",
+					StartTime:       metav1.NewTime(now.Add(-2 * time.Hour)),
+					EndTime:         &metav1.Time{Time: now.Add(-time.Hour)},
+					InjectionStatus: chaostypes.DisruptionInjectionStatusPreviouslyInjected,
+					Health:          &chaosv1beta1.TargetsHealth{ContainerRestarts: 2, UnhealthyTargets: []string{"pod-1"}},
+				},
+			}
+
+			// Action
+			updated := updateDisruptionCronHistory(instance, disruptions, now)
+
+			// Assert
+			Expect(updated).To(BeTrue())
+			Expect(instance.Status.History[0].Health).To(Equal(disruption.Status.Health))
+
+			By("not updating the history when the health did not change")
+			Expect(updateDisruptionCronHistory(instance, disruptions, now)).To(BeFalse())
+		})
+
+		It("should keep the most recent runs only", func() {
+			// Arrange
+			instance.Spec.HistoryLimit = 2
+
+			for i := 3; i > 0; i-- {
+				instance.Status.History = append(instance.Status.History, chaosv1beta1.DisruptionCronRun{
+					DisruptionName: "disruption-cron-network-drop",
+					StartTime:      metav1.NewTime(now.Add(-time.Duration(i) * time.Hour)),
+					EndTime:        &metav1.Time{Time: now.Add(-time.Duration(i)*time.Hour + time.Minute)},
+				})
+			}
+
+			disruptions.Items = append(disruptions.Items, newDisruption(now, chaostypes.DisruptionInjectionStatusNotInjected))
+
+			// Action
+			updated := updateDisruptionCronHistory(instance, disruptions, now)
+
+			// Assert
+			Expect(updated).To(BeTrue())
+			Expect(instance.Status.History).To(HaveLen(2))
+			Expect(instance.Status.History[0].StartTime.Time).To(Equal(now.Add(-time.Hour)))
+			Expect(instance.Status.History[1].StartTime.Time).To(Equal(now))
+		})
+	})
+
+	Describe("getNextSchedule", func() {
+		r := &DisruptionCronReconciler{log: zap.NewNop().Sugar()}
+
+		It("should evaluate the schedule in the given time zone", func() {
+			// Arrange
+			location, err := time.LoadLocation("America/New_York")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			now := time.Date(2023, 6, 1, 16, 30, 0, 0, time.UTC) // 12:30 in New York
+			instance := &chaosv1beta1.DisruptionCron{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+				},
+				Spec: chaosv1beta1.DisruptionCronSpec{
+					Schedule: "0 12 * * *",
+					TimeZone: "America/New_York",
+				},
+			}
+
+			// Action
+			missedRun, nextRun, err := r.getNextSchedule(instance, now)
+
+			// Assert
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(missedRun.Equal(time.Date(2023, 6, 1, 12, 0, 0, 0, location))).To(BeTrue())
+			Expect(nextRun.Equal(time.Date(2023, 6, 2, 12, 0, 0, 0, location))).To(BeTrue())
+		})
+
+		It("should fail with an unknown time zone", func() {
+			// Arrange
+			instance := &chaosv1beta1.DisruptionCron{
+				Spec: chaosv1beta1.DisruptionCronSpec{
+					Schedule: "0 12 * * *",
+					TimeZone: "Mars/Olympus_Mons",
+				},
+			}
+
+			// Action
+			_, _, err := r.getNextSchedule(instance, time.Now())
+
+			// Assert
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("DisruptionCron run decisions", func() {
+	var (
+		instance    *chaosv1beta1.DisruptionCron
+		objects     []client.Object
+		recorder    *record.FakeRecorder
+		metricsSink *metrics.SinkMock
+		reconciler  *DisruptionCronReconciler
+		tags        []string
+	)
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.DisruptionCron{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "network-drop",
+				Namespace:         "chaos-demo",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+			Spec: chaosv1beta1.DisruptionCronSpec{
+				Schedule: "0 * * * *",
+				TargetResource: chaosv1beta1.TargetResourceSpec{
+					Kind: "deployment",
+					Name: "demo-curl",
+				},
+				DisruptionTemplate: chaosv1beta1.DisruptionSpec{
+					Count:    &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					Duration: "1h",
+					Network:  &chaosv1beta1.NetworkDisruptionSpec{Drop: 100},
+				},
+			},
+		}
+		objects = []client.Object{
+			instance,
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "demo-curl", Namespace: "chaos-demo"},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo-curl"}},
+				},
+			},
+		}
+		tags = []string{"controller:disruption-cron", "name:network-drop", "namespace:chaos-demo"}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		metricsSink = metrics.NewSinkMock(GinkgoT())
+		reconciler = &DisruptionCronReconciler{
+			Client:      fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			Scheme:      scheme,
+			BaseLog:     zap.NewNop().Sugar(),
+			Recorder:    recorder,
+			MetricsSink: metricsSink,
+		}
+	})
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	It("should report the scheduled run", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunScheduled(tags).Return(nil).Once()
+
+		// Action
+		reconcile()
+
+		// Assert
+		Expect(recorder.Events).To(Receive(ContainSubstring("Normal RunScheduled Created disruption disruption-cron-network-drop")))
+	})
+
+	When("a prior disruption is still running", func() {
+		BeforeEach(func() {
+			objects = append(objects, &chaosv1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "disruption-cron-network-drop",
+					Namespace:         "chaos-demo",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+					Labels:            map[string]string{DisruptionCronNameLabel: "network-drop"},
+				},
+				Spec: chaosv1beta1.DisruptionSpec{
+					Duration: "1h",
+				},
+			})
+		})
+
+		It("should report the skipped run once", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("concurrent_run", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("Warning RunSkipped Skipped the run scheduled at")))
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("the DisruptionCron is suspended", func() {
+		BeforeEach(func() {
+			instance.Spec.Suspend = true
+		})
+
+		It("should report the skipped run", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("suspended", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("as it is suspended")))
+		})
+	})
+})
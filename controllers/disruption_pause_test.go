@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Disruption pause", func() {
+	var (
+		instance   *chaosv1beta1.Disruption
+		chaosPod   *corev1.Pod
+		reconciler *DisruptionReconciler
+		recorder   *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "paused",
+				Namespace: "chaos-demo",
+			},
+		}
+		chaosPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "chaos-paused-abcde",
+				Namespace: "chaos-engineering",
+				Labels: map[string]string{
+					chaostypes.DisruptionNameLabel:      instance.Name,
+					chaostypes.DisruptionNamespaceLabel: instance.Namespace,
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &DisruptionReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, chaosPod).Build(),
+			Recorder:       recorder,
+			ChaosNamespace: "chaos-engineering",
+			log:            zap.NewNop().Sugar(),
+		}
+	})
+
+	getChaosPod := func() *corev1.Pod {
+		pod := &corev1.Pod{}
+		Expect(reconciler.Client.Get(context.Background(), client.ObjectKeyFromObject(chaosPod), pod)).To(Succeed())
+
+		return pod
+	}
+
+	When("the disruption is paused", func() {
+		BeforeEach(func() {
+			instance.Spec.Paused = true
+		})
+
+		It("should annotate the chaos pods", func() {
+			Expect(reconciler.handlePause(instance)).To(Succeed())
+
+			Expect(getChaosPod().Annotations).To(HaveKeyWithValue(chaostypes.PausedAnnotation, "true"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Paused")))
+		})
+
+		When("chaos pods are already annotated", func() {
+			BeforeEach(func() {
+				chaosPod.Annotations = map[string]string{chaostypes.PausedAnnotation: "true"}
+			})
+
+			It("should not notify again", func() {
+				Expect(reconciler.handlePause(instance)).To(Succeed())
+
+				Expect(recorder.Events).ToNot(Receive())
+			})
+		})
+	})
+
+	When("the disruption is resumed", func() {
+		BeforeEach(func() {
+			chaosPod.Annotations = map[string]string{chaostypes.PausedAnnotation: "true"}
+		})
+
+		It("should remove the annotation from the chaos pods", func() {
+			Expect(reconciler.handlePause(instance)).To(Succeed())
+
+			Expect(getChaosPod().Annotations).ToNot(HaveKey(chaostypes.PausedAnnotation))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Resumed")))
+		})
+	})
+
+	It("should create paused chaos pods when the disruption is paused", func() {
+		instance.Spec.Paused = true
+
+		pod := reconciler.generatePod(instance, "target", "node", nil, chaostypes.DisruptionKindNetworkDisruption)
+
+		Expect(pod.Annotations).To(HaveKeyWithValue(chaostypes.PausedAnnotation, "true"))
+	})
+})
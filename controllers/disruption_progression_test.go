@@ -0,0 +1,219 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/guardrails"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Disruption progression", func() {
+	var (
+		instance *chaosv1beta1.Disruption
+		count    intstr.IntOrString
+	)
+
+	BeforeEach(func() {
+		count = intstr.FromInt(1)
+		instance = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "progressive",
+				Namespace: "chaos-demo",
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Count:    &count,
+				Duration: "1h",
+				Progression: &chaosv1beta1.Progression{
+					Steps:    []intstr.IntOrString{intstr.FromString("10%"), intstr.FromString("25%")},
+					Interval: "1m",
+				},
+			},
+			Status: chaosv1beta1.DisruptionStatus{
+				InjectionStatus: chaostypes.DisruptionInjectionStatusInjected,
+			},
+		}
+	})
+
+	Describe("progressionNextStepDelay", func() {
+		now := time.Now()
+
+		It("should not requeue without progression", func() {
+			instance.Spec.Progression = nil
+
+			_, ok := progressionNextStepDelay(instance, now)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not requeue once the last step is reached", func() {
+			instance.Status.Progression = &chaosv1beta1.ProgressionStatus{CurrentStep: 2, LastStepTime: &metav1.Time{Time: now}}
+
+			_, ok := progressionNextStepDelay(instance, now)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should requeue once the interval has elapsed since the last step", func() {
+			instance.Status.Progression = &chaosv1beta1.ProgressionStatus{CurrentStep: 1, LastStepTime: &metav1.Time{Time: now.Add(-20 * time.Second)}}
+
+			delay, ok := progressionNextStepDelay(instance, now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(40 * time.Second))
+		})
+
+		It("should requeue after another interval when the step is due but on hold", func() {
+			instance.Status.Progression = &chaosv1beta1.ProgressionStatus{CurrentStep: 1, LastStepTime: &metav1.Time{Time: now.Add(-2 * time.Minute)}}
+
+			delay, ok := progressionNextStepDelay(instance, now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(time.Minute))
+		})
+	})
+
+	Describe("injectedRequeueDelay", func() {
+		BeforeEach(func() {
+			instance.CreationTimestamp = metav1.NewTime(time.Now())
+			instance.Spec.Guardrails = &chaosv1beta1.Guardrails{Interval: "30s"}
+		})
+
+		It("should requeue at the earliest of the guardrails evaluation and the next progression step", func() {
+			now := time.Now()
+			instance.Status.Progression = &chaosv1beta1.ProgressionStatus{CurrentStep: 1, LastStepTime: &metav1.Time{Time: now.Add(-50 * time.Second)}}
+
+			delay, reason := injectedRequeueDelay(instance, now, true)
+			Expect(delay).To(Equal(10 * time.Second))
+			Expect(reason).To(ContainSubstring("progression"))
+
+			instance.Status.Progression.LastStepTime = &metav1.Time{Time: now}
+
+			delay, reason = injectedRequeueDelay(instance, now, true)
+			Expect(delay).To(Equal(30 * time.Second))
+			Expect(reason).To(ContainSubstring("guardrails"))
+		})
+
+		It("should requeue once expired without guardrails nor progression", func() {
+			instance.Spec.Progression = nil
+
+			delay, reason := injectedRequeueDelay(instance, time.Now(), false)
+			Expect(delay).To(BeNumerically(">", 59*time.Minute))
+			Expect(reason).To(ContainSubstring("expired"))
+		})
+	})
+
+	Describe("handleProgression", func() {
+		var (
+			checker    *guardrails.CheckerMock
+			reconciler *DisruptionReconciler
+			recorder   *record.FakeRecorder
+		)
+
+		JustBeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+			recorder = record.NewFakeRecorder(10)
+			checker = guardrails.NewCheckerMock(GinkgoT())
+			reconciler = &DisruptionReconciler{
+				Client:            fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build(),
+				Recorder:          recorder,
+				GuardrailsChecker: checker,
+				log:               zap.NewNop().Sugar(),
+			}
+		})
+
+		It("should start the interval of the initial step once fully injected", func(ctx SpecContext) {
+			progressed, err := reconciler.handleProgression(ctx, instance)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progressed).To(BeFalse())
+			Expect(instance.Status.Progression.CurrentStep).To(Equal(0))
+			Expect(instance.Status.Progression.LastStepTime).ToNot(BeNil())
+		})
+
+		It("should not escalate while the disruption is not fully injected", func(ctx SpecContext) {
+			instance.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusPartiallyInjected
+
+			progressed, err := reconciler.handleProgression(ctx, instance)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progressed).To(BeFalse())
+			Expect(instance.Status.Progression).To(BeNil())
+		})
+
+		When("the interval has elapsed", func() {
+			BeforeEach(func() {
+				instance.Status.Progression = &chaosv1beta1.ProgressionStatus{LastStepTime: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}}
+			})
+
+			It("should escalate to the next step", func(ctx SpecContext) {
+				progressed, err := reconciler.handleProgression(ctx, instance)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progressed).To(BeTrue())
+				Expect(instance.Status.Progression.CurrentStep).To(Equal(1))
+				Expect(instance.GetTargetsCount().String()).To(Equal("10%"))
+				Expect(recorder.Events).To(Receive(ContainSubstring("Progressed")))
+			})
+
+			It("should hold while a guardrail check is failing", func(ctx SpecContext) {
+				instance.Status.Guardrails = &chaosv1beta1.GuardrailsStatus{
+					Checks: []chaosv1beta1.GuardrailCheckStatus{{Name: "probe", ConsecutiveFailures: 1, Message: "unreachable"}},
+				}
+
+				progressed, err := reconciler.handleProgression(ctx, instance)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progressed).To(BeFalse())
+				Expect(instance.Status.Progression.CurrentStep).To(Equal(0))
+				Expect(instance.Status.Progression.Message).To(ContainSubstring("guardrail check probe is failing"))
+				Expect(recorder.Events).To(Receive(ContainSubstring("ProgressionOnHold")))
+			})
+
+			It("should hold while the targets readiness is too low", func(ctx SpecContext) {
+				instance.Spec.Progression.MinReadyPercentage = 80
+				checker.EXPECT().Check(mock.Anything, mock.Anything, mock.Anything).Return(guardrails.Result{Breached: true, Message: "1/2 targets are ready"}, nil)
+
+				progressed, err := reconciler.handleProgression(ctx, instance)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progressed).To(BeFalse())
+				Expect(instance.Status.Progression.Message).To(Equal("1/2 targets are ready"))
+			})
+
+			It("should escalate when the targets readiness is high enough", func(ctx SpecContext) {
+				instance.Spec.Progression.MinReadyPercentage = 80
+				checker.EXPECT().Check(mock.Anything, mock.Anything, mock.Anything).Return(guardrails.Result{Message: "2/2 targets are ready"}, nil)
+
+				progressed, err := reconciler.handleProgression(ctx, instance)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progressed).To(BeTrue())
+			})
+		})
+
+		It("should not escalate beyond the last step", func(ctx SpecContext) {
+			instance.Status.Progression = &chaosv1beta1.ProgressionStatus{CurrentStep: 2, LastStepTime: &metav1.Time{Time: time.Now().Add(-time.Hour)}}
+
+			progressed, err := reconciler.handleProgression(ctx, instance)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progressed).To(BeFalse())
+			Expect(instance.Status.Progression.CurrentStep).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Disruption quotas", func() {
+	var (
+		instance   *chaosv1beta1.Disruption
+		running    *chaosv1beta1.Disruption
+		reconciler *DisruptionReconciler
+		recorder   *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "new",
+				Namespace:         "chaos-demo",
+				CreationTimestamp: metav1.NewTime(time.Now()),
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Level:   chaostypes.DisruptionLevelPod,
+				Network: &chaosv1beta1.NetworkDisruptionSpec{Drop: 100},
+			},
+			Status: chaosv1beta1.DisruptionStatus{
+				TargetInjections: chaosv1beta1.TargetInjections{
+					"target-pod": {InjectionStatus: chaostypes.DisruptionTargetInjectionStatusNotInjected},
+				},
+			},
+		}
+		running = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "running",
+				Namespace:         "chaos-demo",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Level:   chaostypes.DisruptionLevelPod,
+				Network: &chaosv1beta1.NetworkDisruptionSpec{Drop: 100},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &DisruptionReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, running).Build(),
+			Recorder:       recorder,
+			ChaosNamespace: "chaos-engineering",
+			Quotas:         chaosv1beta1.DisruptionQuotas{MaxDisruptionsPerNamespace: 1},
+			log:            zap.NewNop().Sugar(),
+		}
+	})
+
+	It("should not create chaos pods when the quotas are exceeded", func() {
+		Expect(reconciler.startInjection(instance)).To(Succeed())
+
+		chaosPods := &corev1.PodList{}
+		Expect(reconciler.Client.List(context.Background(), chaosPods, client.InNamespace("chaos-engineering"))).To(Succeed())
+		Expect(chaosPods.Items).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(ContainSubstring("QuotaExceeded")))
+	})
+
+	It("should ignore the quotas when all the safety nets are disabled", func() {
+		instance.Spec.Unsafemode = &chaosv1beta1.UnsafemodeSpec{DisableAll: true}
+
+		Expect(reconciler.handleQuotas(instance)).To(BeFalse())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	When("the other disruption was created after this one", func() {
+		BeforeEach(func() {
+			running.CreationTimestamp = metav1.NewTime(instance.CreationTimestamp.Add(time.Minute))
+		})
+
+		It("should not hold the injection", func() {
+			Expect(reconciler.handleQuotas(instance)).To(BeFalse())
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	It("should only check the quotas when some targets are missing chaos pods", func() {
+		Expect(reconciler.hasTargetsToInject(instance, map[string]map[string]bool{"target-pod": {}})).To(BeTrue())
+		Expect(reconciler.hasTargetsToInject(instance, map[string]map[string]bool{
+			"target-pod": {chaostypes.DisruptionKindNetworkDisruption: true},
+		})).To(BeFalse())
+	})
+})
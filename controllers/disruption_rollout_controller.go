@@ -11,18 +11,24 @@ import (
 	"time"
 
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type DisruptionRolloutReconciler struct {
-	Client  client.Client
-	Scheme  *runtime.Scheme
-	BaseLog *zap.SugaredLogger
-	log     *zap.SugaredLogger
+	Client      client.Client
+	Scheme      *runtime.Scheme
+	BaseLog     *zap.SugaredLogger
+	Recorder    record.EventRecorder
+	MetricsSink metrics.Sink
+	log         *zap.SugaredLogger
+	skippedRuns skippedRuns
 }
 
 func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -34,6 +40,10 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Fetch DisruptionRollout instance
 	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.skippedRuns.remove(req.NamespacedName)
+		}
+
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -42,6 +52,12 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	if err := instance.Spec.ValidateTargetResource(); err != nil {
+		r.log.Errorw("invalid target resource", "err", err)
+		// Don't requeue until an update to the spec is received
+		return ctrl.Result{}, nil
+	}
+
 	// Update the DisruptionRollout status based on the presence of the target resource
 	// If the target resource has been missing for longer than the TargetResourceMissingThreshold, delete the instance
 	targetResourceExists, instanceDeleted, err := r.updateTargetResourcePreviouslyMissing(ctx, instance)
@@ -72,14 +88,28 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	requeueTime := requeueAfter.Round(time.Second)
 	scheduledResult := ctrl.Result{RequeueAfter: requeueAfter}
 
+	// a run is pending when the last target resource update has not been tested yet, and is identified by the time of this update
+	runPending := r.targetResourceUpdated(&instance.Status) && !r.targetResourceUpdateTested(&instance.Status)
+	runChangeTime := time.Time{}
+
+	if instance.Status.LastContainerChangeTime != nil {
+		runChangeTime = instance.Status.LastContainerChangeTime.Time
+	}
+
 	// Run a new disruption if the following conditions are met:
 	// 1. The target resource is available
 	// 2. The target resource has been updated
 	// 3. The target resource update has not been tested
 	// 4. It's not blocked by another disruption already running
 	// 5. It's not past the deadline
+	// 6. The target resource rollout is complete, if required
 	if !targetResourceExists {
 		r.log.Infow(fmt.Sprintf("target resource is missing, scheduling next check in %s", requeueTime))
+
+		if runPending {
+			r.runReporter(instance).skipped(runChangeTime, runSkipReasonTargetMissing)
+		}
+
 		return scheduledResult, nil
 	}
 
@@ -88,7 +118,7 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
-	if instance.Status.LastContainerChangeTime.Before(instance.Status.LastScheduleTime) || instance.Status.LastContainerChangeTime.Equal(instance.Status.LastScheduleTime) {
+	if !runPending {
 		r.log.Debugw("target resource update has already been tested, sleeping",
 			"LastContainerChangeTime", instance.Status.LastContainerChangeTime,
 			"LastScheduleTime", instance.Status.LastScheduleTime)
@@ -98,6 +128,8 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	if len(disruptions.Items) > 0 {
 		r.log.Infow(fmt.Sprintf("cannot start a new disruption as a prior one is still running, scheduling next check in %s", requeueTime), "numActiveDisruptions", len(disruptions.Items))
+		r.runReporter(instance).skipped(runChangeTime, runSkipReasonConcurrentRun)
+
 		return scheduledResult, nil
 	}
 
@@ -110,25 +142,46 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		r.log.Infow("missed schedule to start a disruption, sleeping",
 			"LastContainerChangeTime", instance.Status.LastContainerChangeTime,
 			"DelayedStartTolerance", instance.Spec.DelayedStartTolerance)
+		r.runReporter(instance).skipped(runChangeTime, runSkipReasonTooLate)
 
 		return ctrl.Result{}, nil
 	}
 
+	if instance.Spec.WaitForRolloutComplete {
+		rolloutComplete, reason, err := r.targetResourceRolloutComplete(ctx, instance)
+		if err != nil {
+			r.log.Errorw("unable to check the target resource rollout status", "err", err)
+			return ctrl.Result{}, err
+		}
+
+		if !rolloutComplete {
+			r.log.Infow(fmt.Sprintf("target resource rollout is not complete yet, scheduling next check in %s", requeueTime), "reason", reason)
+			r.runReporter(instance).skipped(runChangeTime, runSkipReasonRolloutIncomplete)
+
+			return scheduledResult, nil
+		}
+	}
+
 	// Create disruption
 	scheduledTime := time.Now()
 	disruption, err := CreateDisruptionFromTemplate(ctx, r.Client, r.Scheme, instance, &instance.Spec.TargetResource, &instance.Spec.DisruptionTemplate, scheduledTime)
 
 	if err != nil {
 		r.log.Warnw("unable to construct disruption from template", "err", err)
+		r.runReporter(instance).failed(scheduledTime, err)
+
 		return scheduledResult, nil
 	}
 
 	if err := r.Client.Create(ctx, disruption); err != nil {
 		r.log.Warnw("unable to create Disruption for DisruptionRollout", "disruption", disruption, "err", err)
+		r.runReporter(instance).failed(scheduledTime, err)
+
 		return ctrl.Result{}, err
 	}
 
 	r.log.Infow("created Disruption for DisruptionRollout run", "disruptionName", disruption.Name)
+	r.runReporter(instance).scheduled(disruption.Name, scheduledTime)
 
 	// ------------------------------------------------------------------ //
 	// If this process restarts at this point (after posting a disruption, but
@@ -147,6 +200,17 @@ func (r *DisruptionRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	return ctrl.Result{}, nil
 }
 
+// runReporter returns the reporter of the decisions taken on the runs of the given DisruptionRollout
+func (r *DisruptionRolloutReconciler) runReporter(instance *chaosv1beta1.DisruptionRollout) runReporter {
+	return runReporter{
+		owner:       instance,
+		recorder:    r.Recorder,
+		metricsSink: r.MetricsSink,
+		skippedRuns: &r.skippedRuns,
+		log:         r.log,
+	}
+}
+
 // updateLastScheduleTime updates the LastScheduleTime in the status of a DisruptionRollout instance
 // based on the most recent schedule time among the given disruptions.
 func (r *DisruptionRolloutReconciler) updateLastScheduleTime(ctx context.Context, instance *chaosv1beta1.DisruptionRollout, disruptions *chaosv1beta1.DisruptionList) error {
@@ -230,6 +294,19 @@ func (r *DisruptionRolloutReconciler) handleTargetResourceNowPresent(ctx context
 	return nil
 }
 
+// targetResourceRolloutComplete fetches the target resource and checks whether its rollout is complete.
+// It returns the reason why the rollout is not complete, if so.
+func (r *DisruptionRolloutReconciler) targetResourceRolloutComplete(ctx context.Context, instance *chaosv1beta1.DisruptionRollout) (bool, string, error) {
+	targetObj, err := GetTargetResource(ctx, r.Client, &instance.Spec.TargetResource, instance.Namespace)
+	if err != nil {
+		return false, "", err
+	}
+
+	complete, reason := IsRolloutComplete(targetObj)
+
+	return complete, reason, nil
+}
+
 // targetResourceUpdated checks whether the target resource has been updated or not.
 func (r *DisruptionRolloutReconciler) targetResourceUpdated(status *chaosv1beta1.DisruptionRolloutStatus) bool {
 	if status == nil {
@@ -245,6 +322,11 @@ func (r *DisruptionRolloutReconciler) targetResourceUpdated(status *chaosv1beta1
 	return true
 }
 
+// targetResourceUpdateTested checks whether the last target resource update has already been tested or not.
+func (r *DisruptionRolloutReconciler) targetResourceUpdateTested(status *chaosv1beta1.DisruptionRolloutStatus) bool {
+	return status.LastContainerChangeTime.Before(status.LastScheduleTime) || status.LastContainerChangeTime.Equal(status.LastScheduleTime)
+}
+
 // SetupWithManager setups the current reconciler with the given manager
 func (r *DisruptionRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionRollout run decisions", func() {
+	var (
+		instance    *chaosv1beta1.DisruptionRollout
+		deployment  *appsv1.Deployment
+		objects     []client.Object
+		recorder    *record.FakeRecorder
+		metricsSink *metrics.SinkMock
+		reconciler  *DisruptionRolloutReconciler
+		tags        []string
+	)
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.DisruptionRollout{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "network-drop",
+				Namespace:         "chaos-demo",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+			Spec: chaosv1beta1.DisruptionRolloutSpec{
+				TargetResource: chaosv1beta1.TargetResourceSpec{
+					Kind: "deployment",
+					Name: "demo-curl",
+				},
+				DisruptionTemplate: chaosv1beta1.DisruptionSpec{
+					Count:    &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					Duration: "1h",
+					Network:  &chaosv1beta1.NetworkDisruptionSpec{Drop: 100},
+				},
+			},
+			Status: chaosv1beta1.DisruptionRolloutStatus{
+				// the target resource has been updated a minute ago and the update has not been tested yet
+				LatestContainersHash:    map[string]string{"curl": "hash"},
+				LastContainerChangeTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		}
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo-curl", Namespace: "chaos-demo"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo-curl"}},
+			},
+		}
+		objects = []client.Object{instance, deployment}
+		tags = []string{"controller:disruption-rollout", "name:network-drop", "namespace:chaos-demo"}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		metricsSink = metrics.NewSinkMock(GinkgoT())
+		reconciler = &DisruptionRolloutReconciler{
+			Client:      fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			Scheme:      scheme,
+			BaseLog:     zap.NewNop().Sugar(),
+			Recorder:    recorder,
+			MetricsSink: metricsSink,
+		}
+	})
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	It("should report the scheduled run", func() {
+		// Arrange
+		metricsSink.EXPECT().MetricRunScheduled(tags).Return(nil).Once()
+
+		// Action
+		reconcile()
+
+		// Assert
+		Expect(recorder.Events).To(Receive(ContainSubstring("Normal RunScheduled Created disruption disruption-rollout-network-drop")))
+
+		disruption := &chaosv1beta1.Disruption{}
+		Expect(reconciler.Client.Get(context.Background(), client.ObjectKey{Namespace: "chaos-demo", Name: "disruption-rollout-network-drop"}, disruption)).To(Succeed())
+	})
+
+	When("the target resource update has already been tested", func() {
+		BeforeEach(func() {
+			instance.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+		})
+
+		It("should not report any run", func() {
+			// Action
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("the target resource is missing", func() {
+		BeforeEach(func() {
+			objects = []client.Object{instance}
+		})
+
+		It("should report the skipped run once", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("target_missing", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("Warning RunSkipped Skipped the run scheduled at")))
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("a prior disruption is still running", func() {
+		BeforeEach(func() {
+			objects = append(objects, &chaosv1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "disruption-rollout-network-drop",
+					Namespace:         "chaos-demo",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+					Labels:            map[string]string{DisruptionRolloutNameLabel: "network-drop"},
+				},
+				Spec: chaosv1beta1.DisruptionSpec{
+					Duration: "1h",
+				},
+			})
+		})
+
+		It("should report the skipped run once", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("concurrent_run", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("as a prior disruption is still running")))
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("the delayed start tolerance has been exceeded", func() {
+		BeforeEach(func() {
+			instance.Spec.DelayedStartTolerance = "30s"
+		})
+
+		It("should report the skipped run", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("too_late", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("as the delayed start tolerance has been exceeded")))
+		})
+	})
+
+	When("the target resource rollout is not complete", func() {
+		BeforeEach(func() {
+			instance.Spec.WaitForRolloutComplete = true
+			deployment.Spec.Replicas = pointer.Int32(2)
+			deployment.Status.UpdatedReplicas = 1
+		})
+
+		It("should report the skipped run once", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("rollout_incomplete", tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("as the target resource rollout is not complete")))
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("should report the scheduled run once the rollout completes", func() {
+			// Arrange
+			metricsSink.EXPECT().MetricRunSkipped("rollout_incomplete", tags).Return(nil).Once()
+			metricsSink.EXPECT().MetricRunScheduled(tags).Return(nil).Once()
+
+			// Action
+			reconcile()
+
+			completed := &appsv1.Deployment{}
+			Expect(reconciler.Client.Get(context.Background(), client.ObjectKeyFromObject(deployment), completed)).To(Succeed())
+			completed.Status.Replicas = 2
+			completed.Status.UpdatedReplicas = 2
+			completed.Status.AvailableReplicas = 2
+			Expect(reconciler.Client.Status().Update(context.Background(), completed)).To(Succeed())
+
+			reconcile()
+
+			// Assert
+			Expect(recorder.Events).To(Receive(ContainSubstring("as the target resource rollout is not complete")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Normal RunScheduled Created disruption disruption-rollout-network-drop")))
+		})
+	})
+})
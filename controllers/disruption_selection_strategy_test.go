@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Disruption selection strategy", func() {
+	var (
+		instance        *chaosv1beta1.Disruption
+		reconciler      *DisruptionReconciler
+		recorder        *record.FakeRecorder
+		eligibleTargets chaosv1beta1.TargetInjections
+	)
+
+	newPod := func(name string) *corev1.Pod {
+		controller := true
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "chaos-demo",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "demo", UID: "demo", Controller: &controller},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "spread",
+				Namespace: "chaos-demo",
+				UID:       "spread",
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Level:             chaostypes.DisruptionLevelPod,
+				SelectionStrategy: &chaosv1beta1.SelectionStrategy{MaxPerOwner: 1},
+			},
+		}
+		eligibleTargets = chaosv1beta1.TargetInjections{
+			"demo-1": {InjectionStatus: chaostypes.DisruptionTargetInjectionStatusNotInjected},
+			"demo-2": {InjectionStatus: chaostypes.DisruptionTargetInjectionStatusNotInjected},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &DisruptionReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPod("demo-1"), newPod("demo-2"), newPod("demo-3")).Build(),
+			Recorder: recorder,
+			log:      zap.NewNop().Sugar(),
+		}
+	})
+
+	It("should report a selection limited by the strategy only when it changes", func() {
+		Expect(reconciler.addStrategyTargets(instance, 2, eligibleTargets)).To(Succeed())
+		Expect(instance.Status.TargetInjections).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(ContainSubstring("1 of the 2 missing targets")))
+
+		// the same limited selection on the next reconcile loop is not reported again
+		instance.Status.TargetInjections = nil
+		Expect(reconciler.addStrategyTargets(instance, 2, eligibleTargets)).To(Succeed())
+		Expect(recorder.Events).ToNot(Receive())
+
+		// the limited selection changes
+		instance.Status.TargetInjections = nil
+		eligibleTargets["demo-3"] = chaosv1beta1.TargetInjection{InjectionStatus: chaostypes.DisruptionTargetInjectionStatusNotInjected}
+		Expect(reconciler.addStrategyTargets(instance, 3, eligibleTargets)).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring("1 of the 3 missing targets")))
+	})
+})
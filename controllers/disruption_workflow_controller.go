@@ -0,0 +1,391 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DisruptionWorkflowReconciler struct {
+	Client  client.Client
+	Reader  client.Reader // Use the k8s API without the cache
+	Scheme  *runtime.Scheme
+	BaseLog *zap.SugaredLogger
+	log     *zap.SugaredLogger
+}
+
+func (r *DisruptionWorkflowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	r.log = r.BaseLog.With("disruptionWorkflowNamespace", req.Namespace, "disruptionWorkflowName", req.Name)
+	r.log.Info("Reconciling DisruptionWorkflow")
+
+	instance := &chaosv1beta1.DisruptionWorkflow{}
+
+	// Fetch DisruptionWorkflow instance
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !instance.DeletionTimestamp.IsZero() {
+		// child disruptions are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	}
+
+	// A finished workflow won't evolve anymore, it has to be re-created to be run again
+	if instance.Status.Phase == chaosv1beta1.DisruptionWorkflowPhaseCompleted || instance.Status.Phase == chaosv1beta1.DisruptionWorkflowPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+
+	if err := instance.Spec.Validate(); err != nil {
+		r.log.Errorw("invalid DisruptionWorkflow spec", "err", err)
+
+		instance.Status.Phase = chaosv1beta1.DisruptionWorkflowPhaseFailed
+		instance.Status.Message = err.Error()
+		instance.Status.CompletionTime = &metav1.Time{Time: now}
+
+		return ctrl.Result{}, r.Client.Status().Update(ctx, instance)
+	}
+
+	initWorkflowStatus(instance, now)
+
+	disruptions, err := GetChildDisruptions(ctx, r.Client, r.log, instance.Namespace, DisruptionWorkflowNameLabel, instance.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A disruption created by a step during a previous reconcile can be missing from the cache,
+	// it must not be considered as deleted before checking the API
+	if err := r.addUncachedStepDisruptions(ctx, instance, disruptions); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Update running steps from their disruptions
+	updateRunningWorkflowSteps(instance, disruptions, now)
+
+	// Start or skip the steps whose dependencies evolved
+	// several passes are needed as skipping a step can skip the steps depending on it
+	requeueAfter := time.Duration(0)
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, step := range instance.Spec.Steps {
+			stepStatus := instance.Status.GetStepStatus(step.Name)
+
+			if stepStatus.Phase == chaosv1beta1.DisruptionWorkflowStepPhasePending {
+				ready, skipReason := evaluateWorkflowStepDependencies(step, &instance.Status)
+
+				if skipReason != "" {
+					r.log.Infow("skipping workflow step", "step", step.Name, "reason", skipReason)
+
+					stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseSkipped
+					stepStatus.Message = skipReason
+					stepStatus.CompletionTime = &metav1.Time{Time: now}
+					changed = true
+
+					continue
+				}
+
+				if !ready {
+					continue
+				}
+
+				stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseWaiting
+				stepStatus.ReadyTime = &metav1.Time{Time: now}
+			}
+
+			if stepStatus.Phase != chaosv1beta1.DisruptionWorkflowStepPhaseWaiting {
+				continue
+			}
+
+			if remaining := step.Wait.Duration() - now.Sub(stepStatus.ReadyTime.Time); remaining > 0 {
+				r.log.Infow(fmt.Sprintf("waiting %s before starting workflow step", remaining.Round(time.Second)), "step", step.Name)
+
+				if requeueAfter == 0 || remaining < requeueAfter {
+					requeueAfter = remaining
+				}
+
+				continue
+			}
+
+			if err := r.startWorkflowStep(ctx, instance, step, stepStatus, now); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			// a failed step can skip the steps depending on it
+			changed = changed || stepStatus.Phase == chaosv1beta1.DisruptionWorkflowStepPhaseFailed
+		}
+	}
+
+	updateWorkflowPhase(instance, now)
+
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		r.log.Warnw("unable to update DisruptionWorkflow status", "err", err)
+		return ctrl.Result{}, err
+	}
+
+	if instance.Status.Phase != chaosv1beta1.DisruptionWorkflowPhaseRunning {
+		r.log.Infow("DisruptionWorkflow is over", "phase", instance.Status.Phase)
+		return ctrl.Result{}, nil
+	}
+
+	// Running steps disruptions updates trigger a reconcile, but their expiration doesn't, so we check them regularly
+	for _, stepStatus := range instance.Status.Steps {
+		if stepStatus.Phase == chaosv1beta1.DisruptionWorkflowStepPhaseRunning {
+			randSource := rand.New(rand.NewSource(now.UnixNano()))
+			runningRequeueAfter := time.Duration(randSource.Intn(5)+15) * time.Second //nolint:gosec
+
+			if requeueAfter == 0 || runningRequeueAfter < requeueAfter {
+				requeueAfter = runningRequeueAfter
+			}
+
+			break
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// initWorkflowStatus initializes the status of the workflow and of its steps the first time it is reconciled
+func initWorkflowStatus(instance *chaosv1beta1.DisruptionWorkflow, now time.Time) {
+	if instance.Status.Phase == "" {
+		instance.Status.Phase = chaosv1beta1.DisruptionWorkflowPhaseRunning
+		instance.Status.StartTime = &metav1.Time{Time: now}
+	}
+
+	for _, step := range instance.Spec.Steps {
+		if instance.Status.GetStepStatus(step.Name) == nil {
+			instance.Status.Steps = append(instance.Status.Steps, chaosv1beta1.DisruptionWorkflowStepStatus{
+				Name:  step.Name,
+				Phase: chaosv1beta1.DisruptionWorkflowStepPhasePending,
+			})
+		}
+	}
+}
+
+// updateRunningWorkflowSteps updates the running steps with the injection status of their disruptions
+// a step is completed once its disruption expired or has been deleted
+func updateRunningWorkflowSteps(instance *chaosv1beta1.DisruptionWorkflow, disruptions *chaosv1beta1.DisruptionList, now time.Time) {
+	disruptionsByStep := map[string]chaosv1beta1.Disruption{}
+	for _, disruption := range disruptions.Items {
+		disruptionsByStep[disruption.Labels[DisruptionWorkflowStepLabel]] = disruption
+	}
+
+	for i := range instance.Status.Steps {
+		stepStatus := &instance.Status.Steps[i]
+
+		if stepStatus.Phase != chaosv1beta1.DisruptionWorkflowStepPhaseRunning {
+			continue
+		}
+
+		disruption, found := disruptionsByStep[stepStatus.Name]
+		if found {
+			stepStatus.InjectionStatus = disruption.Status.InjectionStatus
+
+			switch disruption.Status.InjectionStatus {
+			case chaostypes.DisruptionInjectionStatusInjected,
+				chaostypes.DisruptionInjectionStatusPausedInjected,
+				chaostypes.DisruptionInjectionStatusPreviouslyInjected:
+				stepStatus.FullyInjected = true
+			}
+		}
+
+		if !found || !disruption.DeletionTimestamp.IsZero() || disruption.Status.InjectionStatus.Previously() || calculateRemainingDuration(disruption) <= 0 {
+			stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseCompleted
+			stepStatus.CompletionTime = &metav1.Time{Time: now}
+		}
+	}
+}
+
+// addUncachedStepDisruptions adds the disruptions of the running steps missing from the given cached disruptions,
+// fetching them from the API, disruptions not found in the API being really deleted
+func (r *DisruptionWorkflowReconciler) addUncachedStepDisruptions(ctx context.Context, instance *chaosv1beta1.DisruptionWorkflow, disruptions *chaosv1beta1.DisruptionList) error {
+	cachedSteps := map[string]struct{}{}
+	for _, disruption := range disruptions.Items {
+		cachedSteps[disruption.Labels[DisruptionWorkflowStepLabel]] = struct{}{}
+	}
+
+	reader := r.Reader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	for _, stepStatus := range instance.Status.Steps {
+		if stepStatus.Phase != chaosv1beta1.DisruptionWorkflowStepPhaseRunning || stepStatus.DisruptionName == "" {
+			continue
+		}
+
+		if _, found := cachedSteps[stepStatus.Name]; found {
+			continue
+		}
+
+		disruption := chaosv1beta1.Disruption{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: stepStatus.DisruptionName}, &disruption); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting the disruption of workflow step %s: %w", stepStatus.Name, err)
+		}
+
+		r.log.Debugw("disruption of workflow step is not in the cache yet", "step", stepStatus.Name, "disruptionName", stepStatus.DisruptionName)
+
+		disruptions.Items = append(disruptions.Items, disruption)
+	}
+
+	return nil
+}
+
+// evaluateWorkflowStepDependencies returns true if the dependencies of the given step meet its condition
+// it returns a reason instead if the dependencies can't meet the condition anymore, meaning that the step must be skipped
+func evaluateWorkflowStepDependencies(step chaosv1beta1.DisruptionWorkflowStep, status *chaosv1beta1.DisruptionWorkflowStatus) (bool, string) {
+	ready := true
+
+	for _, dependency := range step.DependsOn {
+		dependencyStatus := status.GetStepStatus(dependency)
+		if dependencyStatus == nil {
+			return false, fmt.Sprintf("dependency %s does not exist", dependency)
+		}
+
+		switch dependencyStatus.Phase {
+		case chaosv1beta1.DisruptionWorkflowStepPhaseSkipped, chaosv1beta1.DisruptionWorkflowStepPhaseFailed:
+			return false, fmt.Sprintf("dependency %s %s", dependency, strings.ToLower(string(dependencyStatus.Phase)))
+		}
+
+		completed := dependencyStatus.Phase == chaosv1beta1.DisruptionWorkflowStepPhaseCompleted
+
+		switch step.GetCondition() {
+		case chaosv1beta1.DisruptionWorkflowStepConditionCompleted:
+			ready = ready && completed
+		case chaosv1beta1.DisruptionWorkflowStepConditionInjected:
+			if completed && !dependencyStatus.FullyInjected {
+				return false, fmt.Sprintf("dependency %s completed without injecting all its targets", dependency)
+			}
+
+			ready = ready && dependencyStatus.FullyInjected
+		case chaosv1beta1.DisruptionWorkflowStepConditionCompletedAndInjected:
+			if completed && !dependencyStatus.FullyInjected {
+				return false, fmt.Sprintf("dependency %s completed without injecting all its targets", dependency)
+			}
+
+			ready = ready && completed
+		}
+	}
+
+	return ready, ""
+}
+
+// startWorkflowStep creates the disruption of the given step and marks the step as running
+// the step is marked as failed if its disruption can't be constructed or is rejected
+func (r *DisruptionWorkflowReconciler) startWorkflowStep(ctx context.Context, instance *chaosv1beta1.DisruptionWorkflow, step chaosv1beta1.DisruptionWorkflowStep, stepStatus *chaosv1beta1.DisruptionWorkflowStepStatus, now time.Time) error {
+	disruptionTemplate := step.DisruptionTemplate.DeepCopy()
+	if step.Duration.Duration() > 0 {
+		disruptionTemplate.Duration = step.Duration
+	}
+
+	disruption, err := CreateDisruptionFromTemplate(ctx, r.Client, r.Scheme, instance, instance.Spec.GetTargetResource(step), disruptionTemplate, now)
+	if err != nil {
+		r.log.Warnw("unable to construct disruption from template", "step", step.Name, "err", err)
+
+		stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseFailed
+		stepStatus.Message = fmt.Sprintf("unable to construct disruption from template: %s", err)
+		stepStatus.CompletionTime = &metav1.Time{Time: now}
+
+		return nil
+	}
+
+	// the step name acts as a lock to prevent creating the step disruption twice
+	disruption.Name = workflowStepDisruptionName(disruption.Name, step.Name)
+	disruption.Labels[DisruptionWorkflowStepLabel] = step.Name
+
+	if err := r.Client.Create(ctx, disruption); err != nil && !apierrors.IsAlreadyExists(err) {
+		if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+			r.log.Warnw("Disruption for DisruptionWorkflow step has been rejected", "step", step.Name, "err", err)
+
+			stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseFailed
+			stepStatus.Message = fmt.Sprintf("disruption has been rejected: %s", err)
+			stepStatus.CompletionTime = &metav1.Time{Time: now}
+
+			return nil
+		}
+
+		r.log.Warnw("unable to create Disruption for DisruptionWorkflow step", "step", step.Name, "err", err)
+
+		return err
+	}
+
+	r.log.Infow("created Disruption for DisruptionWorkflow step", "step", step.Name, "disruptionName", disruption.Name)
+
+	stepStatus.Phase = chaosv1beta1.DisruptionWorkflowStepPhaseRunning
+	stepStatus.DisruptionName = disruption.Name
+	stepStatus.StartTime = &metav1.Time{Time: now}
+
+	return nil
+}
+
+// workflowStepDisruptionName returns the name of the disruption of the given step, suffixing the given disruption name with the step name
+// names too long to be valid are truncated and suffixed with a hash of the full name so they remain unique
+func workflowStepDisruptionName(disruptionName string, stepName string) string {
+	name := fmt.Sprintf("%s-%s", disruptionName, stepName)
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	suffix := fmt.Sprintf("-%x-%s", hash.Sum32(), stepName)
+
+	return strings.TrimRight(disruptionName[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.") + suffix
+}
+
+// updateWorkflowPhase marks the workflow as over once all its steps are finished
+// the workflow is failed if at least one of its steps failed
+func updateWorkflowPhase(instance *chaosv1beta1.DisruptionWorkflow, now time.Time) {
+	failedSteps := []string{}
+
+	for _, stepStatus := range instance.Status.Steps {
+		if !stepStatus.Phase.IsFinished() {
+			return
+		}
+
+		if stepStatus.Phase == chaosv1beta1.DisruptionWorkflowStepPhaseFailed {
+			failedSteps = append(failedSteps, stepStatus.Name)
+		}
+	}
+
+	instance.Status.Phase = chaosv1beta1.DisruptionWorkflowPhaseCompleted
+	instance.Status.CompletionTime = &metav1.Time{Time: now}
+
+	if len(failedSteps) > 0 {
+		instance.Status.Phase = chaosv1beta1.DisruptionWorkflowPhaseFailed
+		instance.Status.Message = fmt.Sprintf("step(s) %s failed", strings.Join(failedSteps, ", "))
+	}
+}
+
+// SetupWithManager setups the current reconciler with the given manager
+func (r *DisruptionWorkflowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&chaosv1beta1.DisruptionWorkflow{}).
+		Owns(&chaosv1beta1.Disruption{}).
+		Complete(r)
+}
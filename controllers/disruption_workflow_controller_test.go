@@ -0,0 +1,225 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionWorkflow helpers", func() {
+	Describe("evaluateWorkflowStepDependencies", func() {
+		status := &chaosv1beta1.DisruptionWorkflowStatus{
+			Steps: []chaosv1beta1.DisruptionWorkflowStepStatus{
+				{Name: "pending", Phase: chaosv1beta1.DisruptionWorkflowStepPhasePending},
+				{Name: "running", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning},
+				{Name: "running-injected", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning, FullyInjected: true},
+				{Name: "completed", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseCompleted},
+				{Name: "completed-injected", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseCompleted, FullyInjected: true},
+				{Name: "skipped", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseSkipped},
+				{Name: "failed", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseFailed},
+			},
+		}
+
+		DescribeTable("",
+			func(condition chaosv1beta1.DisruptionWorkflowStepCondition, dependsOn []string, expectedReady bool, expectedSkipReason string) {
+				// Arrange
+				step := chaosv1beta1.DisruptionWorkflowStep{
+					Name:      "step",
+					DependsOn: dependsOn,
+					Condition: condition,
+				}
+
+				// Action
+				ready, skipReason := evaluateWorkflowStepDependencies(step, status)
+
+				// Assert
+				Expect(ready).To(Equal(expectedReady))
+				Expect(skipReason).To(Equal(expectedSkipReason))
+			},
+			Entry("without dependencies", chaosv1beta1.DisruptionWorkflowStepCondition(""), nil, true, ""),
+			Entry("completed dependencies", chaosv1beta1.DisruptionWorkflowStepConditionCompleted, []string{"completed", "completed-injected"}, true, ""),
+			Entry("running dependency", chaosv1beta1.DisruptionWorkflowStepConditionCompleted, []string{"completed", "running"}, false, ""),
+			Entry("skipped dependency", chaosv1beta1.DisruptionWorkflowStepConditionCompleted, []string{"running", "skipped"}, false, "dependency skipped skipped"),
+			Entry("failed dependency", chaosv1beta1.DisruptionWorkflowStepConditionCompleted, []string{"failed"}, false, "dependency failed failed"),
+			Entry("injected dependencies", chaosv1beta1.DisruptionWorkflowStepConditionInjected, []string{"running-injected", "completed-injected"}, true, ""),
+			Entry("not yet injected dependency", chaosv1beta1.DisruptionWorkflowStepConditionInjected, []string{"running-injected", "running"}, false, ""),
+			Entry("never injected dependency", chaosv1beta1.DisruptionWorkflowStepConditionInjected, []string{"completed"}, false, "dependency completed completed without injecting all its targets"),
+			Entry("completed and injected dependencies", chaosv1beta1.DisruptionWorkflowStepConditionCompletedAndInjected, []string{"completed-injected"}, true, ""),
+			Entry("injected but running dependency", chaosv1beta1.DisruptionWorkflowStepConditionCompletedAndInjected, []string{"running-injected"}, false, ""),
+			Entry("completed but not injected dependency", chaosv1beta1.DisruptionWorkflowStepConditionCompletedAndInjected, []string{"completed-injected", "completed"}, false, "dependency completed completed without injecting all its targets"),
+		)
+	})
+
+	Describe("updateRunningWorkflowSteps", func() {
+		It("should track the injection status of the running steps and complete them once their disruption is over", func() {
+			// Arrange
+			now := time.Now()
+			instance := &chaosv1beta1.DisruptionWorkflow{
+				Status: chaosv1beta1.DisruptionWorkflowStatus{
+					Steps: []chaosv1beta1.DisruptionWorkflowStepStatus{
+						{Name: "injected", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning},
+						{Name: "expired", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning},
+						{Name: "deleted", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning, FullyInjected: true},
+						{Name: "pending", Phase: chaosv1beta1.DisruptionWorkflowStepPhasePending},
+					},
+				},
+			}
+			newDisruption := func(step string, injectionStatus chaostypes.DisruptionInjectionStatus) chaosv1beta1.Disruption {
+				return chaosv1beta1.Disruption{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:            map[string]string{DisruptionWorkflowStepLabel: step},
+						CreationTimestamp: metav1.NewTime(now),
+					},
+					Spec: chaosv1beta1.DisruptionSpec{
+						Duration: "1h",
+					},
+					Status: chaosv1beta1.DisruptionStatus{
+						InjectionStatus: injectionStatus,
+					},
+				}
+			}
+			disruptions := &chaosv1beta1.DisruptionList{
+				Items: []chaosv1beta1.Disruption{
+					newDisruption("injected", chaostypes.DisruptionInjectionStatusInjected),
+					newDisruption("expired", chaostypes.DisruptionInjectionStatusPreviouslyPartiallyInjected),
+				},
+			}
+
+			// Action
+			updateRunningWorkflowSteps(instance, disruptions, now)
+
+			// Assert
+			injected := instance.Status.GetStepStatus("injected")
+			Expect(injected.Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhaseRunning))
+			Expect(injected.FullyInjected).To(BeTrue())
+			Expect(injected.InjectionStatus).To(Equal(chaostypes.DisruptionInjectionStatusInjected))
+
+			expired := instance.Status.GetStepStatus("expired")
+			Expect(expired.Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhaseCompleted))
+			Expect(expired.FullyInjected).To(BeFalse())
+
+			deleted := instance.Status.GetStepStatus("deleted")
+			Expect(deleted.Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhaseCompleted))
+			Expect(deleted.FullyInjected).To(BeTrue())
+
+			Expect(instance.Status.GetStepStatus("pending").Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhasePending))
+		})
+	})
+
+	Describe("updateWorkflowPhase", func() {
+		DescribeTable("",
+			func(phases []chaosv1beta1.DisruptionWorkflowStepPhase, expectedPhase chaosv1beta1.DisruptionWorkflowPhase) {
+				// Arrange
+				instance := &chaosv1beta1.DisruptionWorkflow{
+					Status: chaosv1beta1.DisruptionWorkflowStatus{
+						Phase: chaosv1beta1.DisruptionWorkflowPhaseRunning,
+					},
+				}
+
+				for i, phase := range phases {
+					instance.Status.Steps = append(instance.Status.Steps, chaosv1beta1.DisruptionWorkflowStepStatus{
+						Name:  string(rune('a' + i)),
+						Phase: phase,
+					})
+				}
+
+				// Action
+				updateWorkflowPhase(instance, time.Now())
+
+				// Assert
+				Expect(instance.Status.Phase).To(Equal(expectedPhase))
+			},
+			Entry("with a running step",
+				[]chaosv1beta1.DisruptionWorkflowStepPhase{chaosv1beta1.DisruptionWorkflowStepPhaseCompleted, chaosv1beta1.DisruptionWorkflowStepPhaseRunning},
+				chaosv1beta1.DisruptionWorkflowPhaseRunning,
+			),
+			Entry("with completed and skipped steps",
+				[]chaosv1beta1.DisruptionWorkflowStepPhase{chaosv1beta1.DisruptionWorkflowStepPhaseCompleted, chaosv1beta1.DisruptionWorkflowStepPhaseSkipped},
+				chaosv1beta1.DisruptionWorkflowPhaseCompleted,
+			),
+			Entry("with a failed step",
+				[]chaosv1beta1.DisruptionWorkflowStepPhase{chaosv1beta1.DisruptionWorkflowStepPhaseFailed, chaosv1beta1.DisruptionWorkflowStepPhaseSkipped},
+				chaosv1beta1.DisruptionWorkflowPhaseFailed,
+			),
+		)
+	})
+
+	Describe("addUncachedStepDisruptions", func() {
+		It("should add the running steps disruptions missing from the cache only if they exist", func() {
+			// Arrange
+			scheme := runtime.NewScheme()
+			Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&chaosv1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "disruption-workflow-demo-created",
+					Namespace:         "chaos-demo",
+					Labels:            map[string]string{DisruptionWorkflowStepLabel: "created"},
+					CreationTimestamp: metav1.NewTime(time.Now()),
+				},
+				Spec: chaosv1beta1.DisruptionSpec{
+					Duration: "1h",
+				},
+			}).Build()
+			reconciler := &DisruptionWorkflowReconciler{
+				Reader: reader,
+				log:    zap.NewNop().Sugar(),
+			}
+			instance := &chaosv1beta1.DisruptionWorkflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "chaos-demo"},
+				Status: chaosv1beta1.DisruptionWorkflowStatus{
+					Steps: []chaosv1beta1.DisruptionWorkflowStepStatus{
+						{Name: "created", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning, DisruptionName: "disruption-workflow-demo-created"},
+						{Name: "deleted", Phase: chaosv1beta1.DisruptionWorkflowStepPhaseRunning, DisruptionName: "disruption-workflow-demo-deleted"},
+					},
+				},
+			}
+			disruptions := &chaosv1beta1.DisruptionList{}
+
+			// Action
+			Expect(reconciler.addUncachedStepDisruptions(context.Background(), instance, disruptions)).To(Succeed())
+			updateRunningWorkflowSteps(instance, disruptions, time.Now())
+
+			// Assert
+			Expect(disruptions.Items).To(HaveLen(1))
+			Expect(instance.Status.GetStepStatus("created").Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhaseRunning))
+			Expect(instance.Status.GetStepStatus("deleted").Phase).To(Equal(chaosv1beta1.DisruptionWorkflowStepPhaseCompleted))
+		})
+	})
+
+	Describe("workflowStepDisruptionName", func() {
+		It("should suffix the disruption name with the step name", func() {
+			Expect(workflowStepDisruptionName("disruption-workflow-demo", "drop")).To(Equal("disruption-workflow-demo-drop"))
+		})
+
+		It("should truncate too long names while keeping them unique", func() {
+			// Arrange
+			disruptionName := "disruption-workflow-" + strings.Repeat("a", 250)
+
+			// Action
+			name := workflowStepDisruptionName(disruptionName, "drop")
+			otherName := workflowStepDisruptionName(disruptionName+"b", "drop")
+
+			// Assert
+			Expect(len(name)).To(BeNumerically("<=", validation.DNS1123SubdomainMaxLength))
+			Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+			Expect(name).To(HaveSuffix("-drop"))
+			Expect(name).ToNot(Equal(otherName))
+		})
+	})
+})
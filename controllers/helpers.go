@@ -15,6 +15,7 @@ import (
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/cloudservice"
 	cloudtypes "github.com/DataDog/chaos-controller/cloudservice/types"
+	"github.com/DataDog/chaos-controller/network/cidr"
 	chaostypes "github.com/DataDog/chaos-controller/types"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -126,10 +127,15 @@ func validateLabelSelector(selector labels.Selector) error {
 }
 
 // transformCloudSpecToHostsSpec from a cloud spec disruption, get all ip ranges of services provided and transform them into a list of hosts spec
+// ip ranges sharing the same protocol, flow and connection state are collapsed to reduce the number of filters created by the injector
 func transformCloudSpecToHostsSpec(cloudManager *cloudservice.CloudServicesProvidersManager, cloudSpec *chaosv1beta1.NetworkDisruptionCloudSpec) ([]chaosv1beta1.NetworkDisruptionHostSpec, error) {
 	hosts := []chaosv1beta1.NetworkDisruptionHostSpec{}
 	clouds := cloudSpec.TransformToCloudMap()
 
+	// ip ranges per host spec without host
+	ipRangesPerHostSpec := map[chaosv1beta1.NetworkDisruptionHostSpec][]string{}
+	hostSpecs := []chaosv1beta1.NetworkDisruptionHostSpec{}
+
 	for cloudName, serviceList := range clouds {
 		serviceListNames := []string{}
 
@@ -143,14 +149,30 @@ func transformCloudSpecToHostsSpec(cloudManager *cloudservice.CloudServicesProvi
 		}
 
 		for _, serviceSpec := range serviceList {
-			for _, ipRange := range ipRangesPerService[serviceSpec.ServiceName] {
-				hosts = append(hosts, chaosv1beta1.NetworkDisruptionHostSpec{
-					Host:      ipRange,
-					Protocol:  serviceSpec.Protocol,
-					Flow:      serviceSpec.Flow,
-					ConnState: serviceSpec.ConnState,
-				})
+			hostSpec := chaosv1beta1.NetworkDisruptionHostSpec{
+				Protocol:  serviceSpec.Protocol,
+				Flow:      serviceSpec.Flow,
+				ConnState: serviceSpec.ConnState,
+			}
+
+			if _, ok := ipRangesPerHostSpec[hostSpec]; !ok {
+				hostSpecs = append(hostSpecs, hostSpec)
 			}
+
+			ipRangesPerHostSpec[hostSpec] = append(ipRangesPerHostSpec[hostSpec], ipRangesPerService[serviceSpec.ServiceName]...)
+		}
+	}
+
+	for _, hostSpec := range hostSpecs {
+		ipRanges, err := cidr.CollapseStrings(ipRangesPerHostSpec[hostSpec])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ipRange := range ipRanges {
+			host := hostSpec
+			host.Host = ipRange
+			hosts = append(hosts, host)
 		}
 	}
 
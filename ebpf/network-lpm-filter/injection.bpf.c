@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+// +build ignore
+#include "injection.bpf.h"
+
+// Must match the MaximumLPMFilterEntries constant of the api/v1beta1 package
+#define MAX_LPM_ENTRIES 16384
+
+#define PROTOCOL_TCP 1
+#define PROTOCOL_UDP 2
+
+// Key of the LPM tries: the prefix length followed by the IPv4 address in network byte order
+struct lpm_key {
+    __u32 prefixlen;
+    __u32 addr;
+};
+
+// Value of the LPM tries: the port to match (0 matches any port) and the protocols to match
+struct lpm_value {
+    __u16 port;
+    __u8 protocols;
+    __u8 padding;
+};
+
+// Prefixes to match against the destination address of the packets (egress flow)
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __uint(max_entries, MAX_LPM_ENTRIES);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+    __type(key, struct lpm_key);
+    __type(value, struct lpm_value);
+} lpm_egress SEC(".maps");
+
+// Prefixes to match against the source address of the packets (ingress flow)
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __uint(max_entries, MAX_LPM_ENTRIES);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+    __type(key, struct lpm_key);
+    __type(value, struct lpm_value);
+} lpm_ingress SEC(".maps");
+
+static __always_inline bool match_prefix(void *map, __u32 addr, __u16 port, __u8 protocol) {
+    struct lpm_key key = {
+        .prefixlen = 32,
+        .addr = addr,
+    };
+
+    struct lpm_value *value = bpf_map_lookup_elem(map, &key);
+    if (value == NULL)
+        return false;
+
+    if ((value->protocols & protocol) == 0)
+        return false;
+
+    return value->port == 0 || value->port == port;
+}
+
+SEC("classifier")
+int cls_entry(struct __sk_buff *skb)
+{
+    skb_info_t skb_info;
+
+    if (!read_conn_tuple_skb(skb, &skb_info))
+        return 0;
+
+    // only IPv4 is supported
+    if (skb_info.tup.metadata & CONN_V6)
+        return 0;
+
+    __u8 protocol = (skb_info.tup.metadata & CONN_TYPE_TCP) ? PROTOCOL_TCP : PROTOCOL_UDP;
+
+    // addresses are read in network byte order in the lower bytes of the tuple fields
+    if (match_prefix(&lpm_egress, (__u32) skb_info.tup.daddr_l, skb_info.tup.dport, protocol)) {
+        return -1;
+    }
+
+    if (match_prefix(&lpm_ingress, (__u32) skb_info.tup.saddr_l, skb_info.tup.sport, protocol)) {
+        return -1;
+    }
+
+    // Don't apply the next tc rule.
+    return 0;
+}
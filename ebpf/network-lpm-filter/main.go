@@ -0,0 +1,280 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+//go:build !cgo
+// +build !cgo
+
+package main
+
+/*
+#cgo LDFLAGS: -lelf -lz
+#include <bpf/bpf.h>
+
+// get_prog_map_ids fills ids with the ids of the maps used by the program, up to nr ids,
+// nr being updated with the number of maps used by the program
+static int get_prog_map_ids(int fd, __u32 *ids, __u32 *nr) {
+	struct bpf_prog_info info = {};
+	__u32 len = sizeof(info);
+	int err;
+
+	info.nr_map_ids = *nr;
+	info.map_ids = (__u64)(unsigned long)ids;
+
+	err = bpf_obj_get_info_by_fd(fd, &info, &len);
+	*nr = info.nr_map_ids;
+
+	return err;
+}
+*/
+import "C"
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/DataDog/chaos-controller/log"
+	"go.uber.org/zap"
+)
+
+const (
+	EgressMapName  = "lpm_egress"
+	IngressMapName = "lpm_ingress"
+
+	ProtocolTCP = 1
+	ProtocolUDP = 2
+
+	tcPath = "/sbin/tc"
+)
+
+var (
+	err        error
+	logger     *zap.SugaredLogger
+	egress     prefixes
+	ingress    prefixes
+	interfaces prefixes
+	priority   uint
+
+	// programIDRegexp extracts the id of the eBPF program of a tc filter, e.g. "... direct-action not_in_hw id 42 tag ..."
+	programIDRegexp = regexp.MustCompile(`\sid (\d+)\s`)
+)
+
+// prefixes is a repeatable flag of <cidr>;<port>;<protocols> entries, e.g. 10.0.0.0/8;443;tcp,udp
+type prefixes []string
+
+func (p *prefixes) String() string {
+	return strings.Join(*p, " ")
+}
+
+func (p *prefixes) Set(value string) error {
+	*p = append(*p, value)
+
+	return nil
+}
+
+type BPFMap struct {
+	name string
+	fd   C.int
+}
+
+// Close closes the file descriptor of the map
+func (b *BPFMap) Close() {
+	syscall.Close(int(b.fd))
+}
+
+func (b *BPFMap) Update(key, value unsafe.Pointer) error {
+	errC := C.bpf_map_update_elem(b.fd, key, value, C.ulonglong(0))
+	if errC != 0 {
+		return fmt.Errorf("failed to update map %s: %w", b.name, syscall.Errno(-errC))
+	}
+	return nil
+}
+
+func main() {
+	flag.Var(&egress, "e", "Prefix to match against the destination of the packets, as <cidr>;<port>;<protocols> (repeatable)")
+	flag.Var(&ingress, "i", "Prefix to match against the source of the packets, as <cidr>;<port>;<protocols> (repeatable)")
+	flag.Var(&interfaces, "d", "Interface the eBPF LPM filter is attached to (repeatable)")
+	flag.UintVar(&priority, "p", 0, "Priority of the eBPF LPM filter tc filter")
+	flag.Parse()
+
+	logger, err = log.NewZapLogger()
+	if err != nil {
+		logger.Fatalf("could not initialize the logger: %w", err, err)
+	}
+
+	// the filter is loaded once per interface, each instance owning its own maps
+	for _, iface := range interfaces {
+		programID, err := getFilterProgramID(iface, priority)
+		if err != nil {
+			logger.Fatalf("could not get the eBPF LPM filter program of interface %s: %v", iface, err)
+		}
+
+		if err = updatePrefixes(programID, EgressMapName, egress); err != nil {
+			logger.Fatalf("could not update the egress prefixes of interface %s: %v", iface, err)
+		}
+
+		if err = updatePrefixes(programID, IngressMapName, ingress); err != nil {
+			logger.Fatalf("could not update the ingress prefixes of interface %s: %v", iface, err)
+		}
+
+		logger.Infof("the %s and %s maps of interface %s are updated", EgressMapName, IngressMapName, iface)
+	}
+}
+
+// getFilterProgramID returns the id of the eBPF program of the tc filter with the given priority attached to the given interface
+func getFilterProgramID(iface string, priority uint) (uint32, error) {
+	output, err := exec.Command(tcPath, "filter", "show", "dev", iface, "parent", "1:0", "prio", strconv.FormatUint(uint64(priority), 10)).CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("could not list the tc filters: %w: %s", err, output)
+	}
+
+	matches := programIDRegexp.FindSubmatch(output)
+	if matches == nil {
+		return 0, fmt.Errorf("no eBPF program found in the tc filter with priority %d", priority)
+	}
+
+	programID, err := strconv.ParseUint(string(matches[1]), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected eBPF program id %s: %w", matches[1], err)
+	}
+
+	return uint32(programID), nil
+}
+
+func updatePrefixes(programID uint32, mapName string, entries prefixes) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	bpfMap, err := GetProgramMapByName(programID, mapName)
+	if err != nil {
+		return fmt.Errorf("could not get the %s map: %w", mapName, err)
+	}
+	defer bpfMap.Close()
+
+	for _, entry := range entries {
+		key, value, err := parseEntry(entry)
+		if err != nil {
+			return err
+		}
+
+		logger.Debugf("UPDATE MAP %s entry: %s\n", mapName, entry)
+
+		if err := bpfMap.Update(unsafe.Pointer(&key[0]), unsafe.Pointer(&value[0])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseEntry builds the LPM trie key and value of the given <cidr>;<port>;<protocols> entry
+// the key is the prefix length (host byte order) followed by the IPv4 address (network byte order)
+// the value is the port (host byte order), the protocols mask and a padding byte
+func parseEntry(entry string) ([]byte, []byte, error) {
+	parts := strings.Split(entry, ";")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("unexpected entry %s, expected <cidr>;<port>;<protocols>", entry)
+	}
+
+	_, ipNet, err := net.ParseCIDR(parts[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unexpected cidr in entry %s: %w", entry, err)
+	}
+
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return nil, nil, fmt.Errorf("unexpected cidr in entry %s: only IPv4 is supported", entry)
+	}
+
+	port, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unexpected port in entry %s: %w", entry, err)
+	}
+
+	protocols := uint8(0)
+
+	for _, protocol := range strings.Split(parts[2], ",") {
+		switch protocol {
+		case "tcp":
+			protocols |= ProtocolTCP
+		case "udp":
+			protocols |= ProtocolUDP
+		default:
+			return nil, nil, fmt.Errorf("unexpected protocol in entry %s", entry)
+		}
+	}
+
+	prefixLen, _ := ipNet.Mask.Size()
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint32(key[0:4], uint32(prefixLen))
+	copy(key[4:8], ip)
+
+	value := make([]byte, 4)
+	binary.LittleEndian.PutUint16(value[0:2], uint16(port))
+	value[2] = protocols
+
+	return key, value, nil
+}
+
+// GetProgramMapByName returns the map with the given name used by the eBPF program with the given id
+func GetProgramMapByName(programID uint32, name string) (*BPFMap, error) {
+	progFd := C.bpf_prog_get_fd_by_id(C.uint(programID))
+	if progFd < 0 {
+		return nil, fmt.Errorf("could not get the file descriptor of the program %d: %w", programID, syscall.Errno(-progFd))
+	}
+	defer syscall.Close(int(progFd))
+
+	// get the number of maps used by the program first, then their ids
+	nrMapIDs := C.__u32(0)
+	if errC := C.get_prog_map_ids(progFd, nil, &nrMapIDs); errC != 0 {
+		return nil, fmt.Errorf("could not get the program info: %w", syscall.Errno(-errC))
+	}
+
+	if nrMapIDs == 0 {
+		return nil, fmt.Errorf("the program %d does not use any map", programID)
+	}
+
+	mapIDs := make([]C.__u32, nrMapIDs)
+	if errC := C.get_prog_map_ids(progFd, &mapIDs[0], &nrMapIDs); errC != 0 {
+		return nil, fmt.Errorf("could not get the program maps: %w", syscall.Errno(-errC))
+	}
+
+	for _, mapID := range mapIDs {
+		fd := C.bpf_map_get_fd_by_id(mapID)
+		if fd < 0 {
+			return nil, fmt.Errorf("could not get the file descriptor of the map %d", mapID)
+		}
+
+		mapInfo := C.struct_bpf_map_info{}
+		mapInfolen := C.uint(unsafe.Sizeof(mapInfo))
+
+		if errC := C.bpf_obj_get_info_by_fd(fd, unsafe.Pointer(&mapInfo), &mapInfolen); errC != 0 {
+			syscall.Close(int(fd))
+
+			return nil, fmt.Errorf("could not get the map info: %w", syscall.Errno(-errC))
+		}
+
+		if C.GoString((*C.char)(unsafe.Pointer(&mapInfo.name[0]))) != name {
+			syscall.Close(int(fd))
+
+			continue
+		}
+
+		return &BPFMap{
+			name: name,
+			fd:   fd,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("the program %d does not use any map named %s", programID, name)
+}
@@ -16,6 +16,7 @@ import (
 
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,12 +34,19 @@ type NotifierSink struct {
 func RegisterNotifierSinks(mgr ctrl.Manager, broadcaster record.EventBroadcaster, notifiersConfig eventnotifier.NotifiersConfig, logger *zap.SugaredLogger) (err error) {
 	client := mgr.GetClient()
 
-	notifiers, err := eventnotifier.GetNotifiers(notifiersConfig, logger)
+	notifiers, err := eventnotifier.GetNotifiers(notifiersConfig, client, logger)
 
 	for _, notifier := range notifiers {
 		logger.Infof("notifier %s enabled", notifier.GetNotifierName())
 
 		broadcaster.StartRecordingToSink(&NotifierSink{client: client, notifier: notifier, logger: logger})
+
+		// some notifiers serve requests along with the controller (e.g. slack interactive messages callbacks)
+		if runnable, ok := notifier.(manager.Runnable); ok {
+			if addErr := mgr.Add(runnable); addErr != nil {
+				err = addErr
+			}
+		}
 	}
 
 	corev1Client, _ := corev1client.NewForConfig(mgr.GetConfig())
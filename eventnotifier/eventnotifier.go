@@ -10,18 +10,25 @@ import (
 	"github.com/DataDog/chaos-controller/eventnotifier/datadog"
 	http "github.com/DataDog/chaos-controller/eventnotifier/http"
 	"github.com/DataDog/chaos-controller/eventnotifier/noop"
+	"github.com/DataDog/chaos-controller/eventnotifier/opsgenie"
 	"github.com/DataDog/chaos-controller/eventnotifier/slack"
+	"github.com/DataDog/chaos-controller/eventnotifier/smtp"
+	"github.com/DataDog/chaos-controller/eventnotifier/teams"
 	"github.com/DataDog/chaos-controller/eventnotifier/types"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type NotifiersConfig struct {
-	Common  types.NotifiersCommonConfig   `json:"notifiersCommonConfig"`
-	Noop    noop.NotifierNoopConfig       `json:"notifierNoopConfig"`
-	Slack   slack.NotifierSlackConfig     `json:"notifierSlackConfig"`
-	Datadog datadog.NotifierDatadogConfig `json:"notifierDatadogConfig"`
-	HTTP    http.NotifierHTTPConfig       `json:"notifierHTTPConfig"`
+	Common   types.NotifiersCommonConfig     `json:"notifiersCommonConfig"`
+	Noop     noop.NotifierNoopConfig         `json:"notifierNoopConfig"`
+	Slack    slack.NotifierSlackConfig       `json:"notifierSlackConfig"`
+	Datadog  datadog.NotifierDatadogConfig   `json:"notifierDatadogConfig"`
+	HTTP     http.NotifierHTTPConfig         `json:"notifierHTTPConfig"`
+	Teams    teams.NotifierTeamsConfig       `json:"notifierTeamsConfig"`
+	Opsgenie opsgenie.NotifierOpsgenieConfig `json:"notifierOpsgenieConfig"`
+	SMTP     smtp.NotifierSMTPConfig         `json:"notifierSMTPConfig"`
 }
 
 type Notifier interface {
@@ -30,7 +37,8 @@ type Notifier interface {
 }
 
 // GetNotifier returns an initiated Notifier instance
-func GetNotifiers(config NotifiersConfig, logger *zap.SugaredLogger) (notifiers []Notifier, err error) {
+// the given client is used by notifiers storing state on disruptions or acting on them (e.g. slack threads and interactive messages)
+func GetNotifiers(config NotifiersConfig, k8sClient client.Client, logger *zap.SugaredLogger) (notifiers []Notifier, err error) {
 	err = nil
 
 	if config.Noop.Enabled {
@@ -39,7 +47,7 @@ func GetNotifiers(config NotifiersConfig, logger *zap.SugaredLogger) (notifiers
 	}
 
 	if config.Slack.Enabled {
-		not, slackErr := slack.New(config.Common, config.Slack, logger)
+		not, slackErr := slack.New(config.Common, config.Slack, k8sClient, logger)
 		if slackErr != nil {
 			err = slackErr
 		} else {
@@ -65,5 +73,32 @@ func GetNotifiers(config NotifiersConfig, logger *zap.SugaredLogger) (notifiers
 		}
 	}
 
+	if config.Teams.Enabled {
+		not, teamsErr := teams.New(config.Common, config.Teams, logger)
+		if teamsErr != nil {
+			err = teamsErr
+		} else {
+			notifiers = append(notifiers, not)
+		}
+	}
+
+	if config.Opsgenie.Enabled {
+		not, opsgenieErr := opsgenie.New(config.Common, config.Opsgenie, logger)
+		if opsgenieErr != nil {
+			err = opsgenieErr
+		} else {
+			notifiers = append(notifiers, not)
+		}
+	}
+
+	if config.SMTP.Enabled {
+		not, smtpErr := smtp.New(config.Common, config.SMTP, logger)
+		if smtpErr != nil {
+			err = smtpErr
+		} else {
+			notifiers = append(notifiers, not)
+		}
+	}
+
 	return notifiers, err
 }
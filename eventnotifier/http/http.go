@@ -7,6 +7,10 @@ package datadog
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,29 +19,71 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/eventnotifier/types"
 	"github.com/DataDog/chaos-controller/eventnotifier/utils"
+	"github.com/cenkalti/backoff"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// SignatureHeader is the header holding the HMAC SHA256 signature of the request body when a signing secret is configured
+	SignatureHeader = "X-Chaos-Controller-Signature"
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsTypePrefix  = "com.datadoghq.chaos-controller.disruption."
+	cloudEventsContentType = "application/cloudevents+json"
+)
+
 type NotifierHTTPConfig struct {
-	Enabled         bool
-	URL             string
-	Headers         []string
-	HeadersFilepath string
+	Enabled                 bool
+	URL                     string
+	Headers                 []string
+	HeadersFilepath         string
+	PayloadTemplate         string
+	PayloadTemplateFilepath string
+	CloudEvents             bool
+	SigningSecretFilepath   string
+	MaxRetries              int
 }
 
 // Notifier describes a HTTP notifier
 type Notifier struct {
-	common  types.NotifiersCommonConfig
-	client  *http.Client
-	url     string
-	headers map[string]string
-	logger  *zap.SugaredLogger
+	common        types.NotifiersCommonConfig
+	client        *http.Client
+	url           string
+	headers       map[string]string
+	template      *template.Template
+	cloudEvents   bool
+	signingSecret []byte
+	maxRetries    int
+	backoff       func() backoff.BackOff
+	logger        *zap.SugaredLogger
+}
+
+// HTTPNotifierTemplateData is the data given to the payload template
+type HTTPNotifierTemplateData struct {
+	HTTPNotifierEvent
+	Disruption v1beta1.Disruption
+	Event      corev1.Event
+}
+
+// CloudEvent is a CloudEvents 1.0 event in the structured content mode
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
 }
 
 type HTTPNotifierEvent struct {
@@ -90,20 +136,68 @@ func New(commonConfig types.NotifiersCommonConfig, httpConfig NotifierHTTPConfig
 			continue
 		}
 
-		splittedHeader := strings.Split(header, ":")
-		if len(splittedHeader) == 2 {
-			parsedHeaders[splittedHeader[0]] = splittedHeader[1]
-		} else {
+		// only the first colon separates the key from the value, so values such as URLs or tokens can contain colons
+		key, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(key) == "" {
 			return nil, fmt.Errorf("notifier http: invalid headers in headers file. Must be of format: key:value")
 		}
+
+		parsedHeaders[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	payloadTemplate := httpConfig.PayloadTemplate
+
+	if httpConfig.PayloadTemplateFilepath != "" {
+		readTemplate, err := os.ReadFile(filepath.Clean(httpConfig.PayloadTemplateFilepath))
+		if err != nil {
+			return nil, fmt.Errorf("notifier http: payload template file could not be read: %w", err)
+		}
+
+		payloadTemplate = string(readTemplate)
+	}
+
+	var tpl *template.Template
+
+	if payloadTemplate != "" {
+		parsedTemplate, err := template.New("payload").Funcs(template.FuncMap{"json": toJSON}).Option("missingkey=error").Parse(payloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("notifier http: invalid payload template: %w", err)
+		}
+
+		tpl = parsedTemplate
+	}
+
+	var signingSecret []byte
+
+	if httpConfig.SigningSecretFilepath != "" {
+		readSecret, err := os.ReadFile(filepath.Clean(httpConfig.SigningSecretFilepath))
+		if err != nil {
+			return nil, fmt.Errorf("notifier http: signing secret file could not be read: %w", err)
+		}
+
+		signingSecret = bytes.TrimSpace(readSecret)
+		if len(signingSecret) == 0 {
+			return nil, fmt.Errorf("notifier http: signing secret file is empty")
+		}
+	}
+
+	if httpConfig.MaxRetries < 0 {
+		return nil, fmt.Errorf("notifier http: max retries must be positive")
 	}
 
 	return &Notifier{
-		common:  commonConfig,
-		client:  client,
-		url:     httpConfig.URL,
-		headers: parsedHeaders,
-		logger:  logger,
+		common:        commonConfig,
+		client:        client,
+		url:           httpConfig.URL,
+		headers:       parsedHeaders,
+		template:      tpl,
+		cloudEvents:   httpConfig.CloudEvents,
+		signingSecret: signingSecret,
+		maxRetries:    httpConfig.MaxRetries,
+		backoff: func() backoff.BackOff {
+			return backoff.NewExponentialBackOff()
+		},
+		logger: logger,
 	}, nil
 }
 
@@ -139,35 +233,137 @@ func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType
 		UserEmail:          emailAddr.Address,
 	}
 
-	body, err := json.Marshal(notif)
+	body, contentType, err := n.buildBody(dis, event, notif)
+	if err != nil {
+		return fmt.Errorf("http notifier: couldn't send notification: %w", err)
+	}
+
+	n.logger.Debugw("notifier: sending notifier event to http", "disruption", dis.Name, "eventType", event.Type, "message", notif.EventMessage)
+
+	// retry on network errors and server side errors only, other client errors won't succeed on retry
+	send := func() error {
+		return n.send(body, contentType)
+	}
+
+	notify := func(err error, delay time.Duration) {
+		n.logger.Warnw("http notifier: error when sending notification, retrying", "disruption", dis.Name, "error", err, "retryIn", delay)
+	}
+
+	if err := backoff.RetryNotify(send, backoff.WithMaxRetries(n.backoff(), uint64(n.maxRetries)), notify); err != nil {
+		return fmt.Errorf("http notifier: %w", err)
+	}
+
+	return nil
+}
+
+// buildBody returns the body of the notification request along with its content type
+// the payload is either the default JSON event or the rendered template, optionally wrapped in a CloudEvents envelope
+func (n *Notifier) buildBody(dis v1beta1.Disruption, event corev1.Event, notif HTTPNotifierEvent) ([]byte, string, error) {
+	payload, err := json.Marshal(notif)
 	if err != nil {
-		return fmt.Errorf("http notifier: couldn't send notification: %s", err.Error())
+		return nil, "", err
 	}
 
+	if n.template != nil {
+		rendered := bytes.Buffer{}
+
+		if err := n.template.Execute(&rendered, HTTPNotifierTemplateData{HTTPNotifierEvent: notif, Disruption: dis, Event: event}); err != nil {
+			return nil, "", fmt.Errorf("error rendering the payload template: %w", err)
+		}
+
+		payload = rendered.Bytes()
+	}
+
+	if !n.cloudEvents {
+		return payload, "application/json", nil
+	}
+
+	eventID := string(event.UID)
+	if eventID == "" {
+		eventID = uuid.New().String()
+	}
+
+	eventTime := event.LastTimestamp.Time
+	if eventTime.IsZero() {
+		eventTime = time.Now()
+	}
+
+	cloudEvent := CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              eventID,
+		Source:          fmt.Sprintf("/chaos-controller/%s/namespaces/%s/disruptions/%s", n.common.ClusterName, dis.Namespace, dis.Name),
+		Type:            cloudEventsTypePrefix + strings.ToLower(string(notif.NotificationType)),
+		Subject:         event.Reason,
+		Time:            eventTime.UTC(),
+		DataContentType: "application/json",
+	}
+
+	// a template may render a non-JSON payload, which is then carried as base64 encoded binary data
+	if json.Valid(payload) {
+		cloudEvent.Data = payload
+	} else {
+		cloudEvent.DataContentType = "text/plain"
+		cloudEvent.DataBase64 = base64.StdEncoding.EncodeToString(payload)
+	}
+
+	body, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, cloudEventsContentType, nil
+}
+
+// send sends the given body to the configured URL, errors which are not worth retrying are marked as permanent
+func (n *Notifier) send(body []byte, contentType string) error {
 	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("http notifier: couldn't send notification: %s", err.Error())
+		return backoff.Permanent(fmt.Errorf("couldn't send notification: %w", err))
 	}
 
+	req.Header.Set("Content-Type", contentType)
+
 	for headerKey, headerValue := range n.headers {
-		req.Header.Add(headerKey, headerValue)
+		req.Header.Set(headerKey, headerValue)
 	}
 
-	res, err := n.client.Do(req)
+	if len(n.signingSecret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.signingSecret, body))
+	}
 
+	res, err := n.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("http notifier: error when sending notification: %s", err.Error())
+		return fmt.Errorf("error when sending notification: %w", err)
 	}
 
-	n.logger.Debugw("notifier: sending notifier event to http", "disruption", dis.Name, "eventType", event.Type, "message", notif.EventMessage)
+	if err = res.Body.Close(); err != nil {
+		return fmt.Errorf("error when sending notification: %w", err)
+	}
 
 	if res.StatusCode >= 300 || res.StatusCode < 200 {
-		return fmt.Errorf("http notifier: receiving %d status code from sent notification", res.StatusCode)
-	}
+		err := fmt.Errorf("receiving %d status code from sent notification", res.StatusCode)
 
-	if err = res.Body.Close(); err != nil {
-		return fmt.Errorf("http notifier: error when sending notification: %s", err.Error())
+		if res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests {
+			return err
+		}
+
+		return backoff.Permanent(err)
 	}
 
 	return nil
 }
+
+// Sign returns the hex encoded HMAC SHA256 of the given body with the given secret
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// toJSON is a template function marshaling the given value to JSON, useful to escape strings in JSON payloads
+func toJSON(v interface{}) (string, error) {
+	marshaled, err := json.Marshal(v)
+
+	return string(marshaled), err
+}
@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"github.com/cenkalti/backoff"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	headers http.Header
+	body    []byte
+}
+
+func TestNew_Headers(t *testing.T) {
+	t.Parallel()
+
+	n, err := New(types.NotifiersCommonConfig{}, NotifierHTTPConfig{
+		URL:     "http://localhost",
+		Headers: []string{"Authorization:Bearer abc:def", "X-Callback: https://example.com/callback"},
+	}, zaptest.NewLogger(t).Sugar())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"Authorization": "Bearer abc:def",
+		"X-Callback":    "https://example.com/callback",
+	}, n.headers)
+
+	_, err = New(types.NotifiersCommonConfig{}, NotifierHTTPConfig{URL: "http://localhost", Headers: []string{"no-separator"}}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "Must be of format: key:value")
+
+	_, err = New(types.NotifiersCommonConfig{}, NotifierHTTPConfig{URL: "http://localhost", PayloadTemplate: "{{ .Unclosed"}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "invalid payload template")
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	secretFilepath := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFilepath, []byte("s3cr3t\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		config   NotifierHTTPConfig
+		statuses []int
+		wantErr  string
+		check    func(*testing.T, []receivedRequest)
+	}{
+		{
+			name: "default json event",
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+				require.Equal(t, "application/json", requests[0].headers.Get("Content-Type"))
+
+				event := HTTPNotifierEvent{}
+				require.NoError(t, json.Unmarshal(requests[0].body, &event))
+				require.Equal(t, "disruption", event.DisruptionName)
+				require.Equal(t, "cluster", event.Cluster)
+				require.Equal(t, types.NotificationWarning, event.NotificationType)
+			},
+		},
+		{
+			name: "templated payload",
+			config: NotifierHTTPConfig{
+				PayloadTemplate: `{"summary": {{ json .NotificationTitle }}, "name": "{{ .Disruption.Name }}", "reason": "{{ .Event.Reason }}"}`,
+			},
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+
+				payload := map[string]string{}
+				require.NoError(t, json.Unmarshal(requests[0].body, &payload))
+				require.Equal(t, "disruption", payload["name"])
+				require.Equal(t, "Stuck", payload["reason"])
+				require.NotEmpty(t, payload["summary"])
+			},
+		},
+		{
+			name:   "cloudevents structured mode",
+			config: NotifierHTTPConfig{CloudEvents: true},
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+				require.Equal(t, "application/cloudevents+json", requests[0].headers.Get("Content-Type"))
+
+				event := CloudEvent{}
+				require.NoError(t, json.Unmarshal(requests[0].body, &event))
+				require.Equal(t, "1.0", event.SpecVersion)
+				require.Equal(t, "event-uid", event.ID)
+				require.Equal(t, "com.datadoghq.chaos-controller.disruption.warning", event.Type)
+				require.Equal(t, "/chaos-controller/cluster/namespaces/namespace/disruptions/disruption", event.Source)
+				require.Contains(t, string(event.Data), `"disruption-name":"disruption"`)
+			},
+		},
+		{
+			name:   "cloudevents with a non json template",
+			config: NotifierHTTPConfig{CloudEvents: true, PayloadTemplate: "disruption {{ .DisruptionName }}"},
+			check: func(t *testing.T, requests []receivedRequest) {
+				event := CloudEvent{}
+				require.NoError(t, json.Unmarshal(requests[0].body, &event))
+				require.Equal(t, "text/plain", event.DataContentType)
+				require.Equal(t, "ZGlzcnVwdGlvbiBkaXNydXB0aW9u", event.DataBase64)
+			},
+		},
+		{
+			name:   "signed body",
+			config: NotifierHTTPConfig{SigningSecretFilepath: secretFilepath},
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Equal(t, "sha256="+Sign([]byte("s3cr3t"), requests[0].body), requests[0].headers.Get(SignatureHeader))
+			},
+		},
+		{
+			name:     "retries on server errors",
+			config:   NotifierHTTPConfig{MaxRetries: 3},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 3)
+			},
+		},
+		{
+			name:     "gives up after max retries",
+			config:   NotifierHTTPConfig{MaxRetries: 1},
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantErr:  "receiving 502 status code",
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 2)
+			},
+		},
+		{
+			name:     "does not retry on client errors",
+			config:   NotifierHTTPConfig{MaxRetries: 3},
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			wantErr:  "receiving 400 status code",
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock     sync.Mutex
+				requests []receivedRequest
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, receivedRequest{headers: r.Header, body: body})
+
+				if len(tt.statuses) >= len(requests) {
+					w.WriteHeader(tt.statuses[len(requests)-1])
+				}
+			}))
+			defer server.Close()
+
+			tt.config.URL = server.URL
+
+			n, err := New(types.NotifiersCommonConfig{ClusterName: "cluster"}, tt.config, zaptest.NewLogger(t).Sugar())
+			require.NoError(t, err)
+
+			n.backoff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+
+			dis := v1beta1.Disruption{ObjectMeta: metav1.ObjectMeta{Name: "disruption", Namespace: "namespace"}}
+			event := corev1.Event{ObjectMeta: metav1.ObjectMeta{UID: "event-uid"}, Reason: "Stuck", Message: "the disruption is stuck"}
+
+			err = n.Notify(dis, event, types.NotificationWarning)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			tt.check(t, requests)
+		})
+	}
+}
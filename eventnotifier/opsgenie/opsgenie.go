@@ -0,0 +1,237 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package opsgenie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"github.com/DataDog/chaos-controller/eventnotifier/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultAPIURL is the Opsgenie API URL used when none is configured, EU accounts must use https://api.eu.opsgenie.com
+	DefaultAPIURL = "https://api.opsgenie.com"
+	// DefaultPriority is the priority of the created alerts when none is configured
+	DefaultPriority = "P3"
+
+	alertSource = "chaos-controller"
+)
+
+type NotifierOpsgenieConfig struct {
+	Enabled        bool
+	APIKeyFilepath string
+	APIURL         string
+	Priority       string
+	Responders     []string
+	Tags           []string
+}
+
+// Notifier describes an Opsgenie notifier
+type Notifier struct {
+	common     types.NotifiersCommonConfig
+	client     *http.Client
+	apiURL     string
+	apiKey     string
+	priority   string
+	responders []Responder
+	tags       []string
+	logger     *zap.SugaredLogger
+}
+
+// Responder is a team notified by an alert
+type Responder struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// CreateAlertRequest is the body of an alert creation request
+type CreateAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Responders  []Responder       `json:"responders,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+}
+
+// CloseAlertRequest is the body of an alert close request
+type CloseAlertRequest struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+// New Opsgenie Notifier
+func New(commonConfig types.NotifiersCommonConfig, opsgenieConfig NotifierOpsgenieConfig, logger *zap.SugaredLogger) (*Notifier, error) {
+	if opsgenieConfig.APIKeyFilepath == "" {
+		return nil, fmt.Errorf("opsgenie notifier: missing API key filepath")
+	}
+
+	readKey, err := os.ReadFile(filepath.Clean(opsgenieConfig.APIKeyFilepath))
+	if err != nil {
+		return nil, fmt.Errorf("opsgenie notifier: API key file could not be read: %w", err)
+	}
+
+	apiKey := strings.TrimSpace(string(readKey))
+	if apiKey == "" {
+		return nil, fmt.Errorf("opsgenie notifier: API key file is empty")
+	}
+
+	apiURL := opsgenieConfig.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	priority := opsgenieConfig.Priority
+	if priority == "" {
+		priority = DefaultPriority
+	}
+
+	switch priority {
+	case "P1", "P2", "P3", "P4", "P5":
+	default:
+		return nil, fmt.Errorf("opsgenie notifier: invalid priority %s, must be one of P1, P2, P3, P4 or P5", priority)
+	}
+
+	responders := []Responder{}
+	for _, team := range opsgenieConfig.Responders {
+		responders = append(responders, Responder{Name: team, Type: "team"})
+	}
+
+	return &Notifier{
+		common: commonConfig,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		apiKey:     apiKey,
+		priority:   priority,
+		responders: responders,
+		tags:       opsgenieConfig.Tags,
+		logger:     logger,
+	}, nil
+}
+
+// GetNotifierName returns the driver's name
+func (n *Notifier) GetNotifierName() string {
+	return string(types.NotifierDriverOpsgenie)
+}
+
+// Notify creates an alert for Warning and Error notifications allowed by the disruption reporting configuration
+// and closes it once the disruption is finished
+func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType) error {
+	// the close request is sent regardless of the reporting configuration as it may have been changed since the alert creation,
+	// closing an alert which does not exist is a no-op on opsgenie side
+	if event.Reason == string(v1beta1.EventDisruptionFinished) {
+		n.logger.Debugw("notifier: closing opsgenie alert", "disruptionName", dis.Name, "alias", n.alias(dis))
+
+		return n.closeAlert(dis)
+	}
+
+	if notifType != types.NotificationWarning && notifType != types.NotificationError {
+		return nil
+	}
+
+	if !utils.MinNotificationType(dis).Allows(notifType) {
+		return nil
+	}
+
+	n.logger.Debugw("notifier: creating opsgenie alert", "disruptionName", dis.Name, "eventType", event.Type, "alias", n.alias(dis))
+
+	return n.createAlert(dis, event, notifType)
+}
+
+// alias uniquely identifies the alert of a disruption so it is deduplicated on creation and can be closed later on
+func (n *Notifier) alias(dis v1beta1.Disruption) string {
+	return fmt.Sprintf("chaos-controller/%s/%s/%s", n.common.ClusterName, dis.Namespace, dis.Name)
+}
+
+func (n *Notifier) createAlert(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType) error {
+	details := map[string]string{
+		"kind":      dis.Kind,
+		"name":      dis.Name,
+		"namespace": dis.Namespace,
+		"reason":    event.Reason,
+	}
+
+	if n.common.ClusterName != "" {
+		details["cluster"] = n.common.ClusterName
+	}
+
+	if userInfo, err := dis.UserInfo(); err == nil && userInfo.Username != "" {
+		details["author"] = userInfo.Username
+	}
+
+	if targetName, ok := event.Annotations["target_name"]; ok {
+		details["target"] = targetName
+	}
+
+	tags := append([]string{string(notifType)}, n.tags...)
+
+	return n.send(http.MethodPost, "/v2/alerts", CreateAlertRequest{
+		Message:     utils.BuildHeaderMessageFromDisruptionEvent(dis, notifType),
+		Alias:       n.alias(dis),
+		Description: utils.BuildBodyMessageFromDisruptionEvent(dis, event, false),
+		Responders:  n.responders,
+		Tags:        tags,
+		Details:     details,
+		Source:      alertSource,
+		Priority:    n.priority,
+	})
+}
+
+func (n *Notifier) closeAlert(dis v1beta1.Disruption) error {
+	path := fmt.Sprintf("/v2/alerts/%s/close?identifierType=alias", url.PathEscape(n.alias(dis)))
+
+	return n.send(http.MethodPost, path, CloseAlertRequest{
+		Source: alertSource,
+		Note:   "Disruption '" + dis.Name + "' is finished",
+	})
+}
+
+func (n *Notifier) send(method, path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("opsgenie notifier: couldn't build request: %w", err)
+	}
+
+	req, err := http.NewRequest(method, n.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("opsgenie notifier: couldn't build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+n.apiKey)
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("opsgenie notifier: error when sending request: %w", err)
+	}
+
+	if err := res.Body.Close(); err != nil {
+		return fmt.Errorf("opsgenie notifier: error when sending request: %w", err)
+	}
+
+	// opsgenie processes requests asynchronously and answers 202 when accepted
+	if res.StatusCode >= 300 || res.StatusCode < 200 {
+		return fmt.Errorf("opsgenie notifier: receiving %d status code from %s", res.StatusCode, path)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	uri           string
+	authorization string
+	body          []byte
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reporting *v1beta1.Reporting
+		reason    v1beta1.DisruptionEventReason
+		notifType types.NotificationType
+		check     func(*testing.T, []receivedRequest)
+	}{
+		{
+			name:      "warning creates an alert",
+			reason:    v1beta1.EventDisruptionStuckOnRemoval,
+			notifType: types.NotificationWarning,
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+				require.Equal(t, "/v2/alerts", requests[0].uri)
+				require.Equal(t, "GenieKey key", requests[0].authorization)
+
+				alert := CreateAlertRequest{}
+				require.NoError(t, json.Unmarshal(requests[0].body, &alert))
+				require.Equal(t, "chaos-controller/cluster/namespace/disruption", alert.Alias)
+				require.Equal(t, "P2", alert.Priority)
+				require.Equal(t, []Responder{{Name: "sre", Type: "team"}}, alert.Responders)
+				require.Equal(t, []string{"Warning", "chaos"}, alert.Tags)
+				require.Equal(t, "cluster", alert.Details["cluster"])
+			},
+		},
+		{
+			name:      "success does not create an alert",
+			reason:    v1beta1.EventDisruptionCreated,
+			notifType: types.NotificationSuccess,
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Empty(t, requests)
+			},
+		},
+		{
+			name:      "warning does not create an alert when the disruption asks for errors only",
+			reporting: &v1beta1.Reporting{MinNotificationType: types.NotificationError},
+			reason:    v1beta1.EventDisruptionStuckOnRemoval,
+			notifType: types.NotificationWarning,
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Empty(t, requests)
+			},
+		},
+		{
+			name:      "finished closes the alert",
+			reporting: &v1beta1.Reporting{MinNotificationType: types.NotificationError},
+			reason:    v1beta1.EventDisruptionFinished,
+			notifType: types.NotificationInfo,
+			check: func(t *testing.T, requests []receivedRequest) {
+				require.Len(t, requests, 1)
+				require.Equal(t, "/v2/alerts/chaos-controller%2Fcluster%2Fnamespace%2Fdisruption/close?identifierType=alias", requests[0].uri)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock     sync.Mutex
+				requests []receivedRequest
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, receivedRequest{uri: r.RequestURI, authorization: r.Header.Get("Authorization"), body: body})
+
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			keyFilepath := filepath.Join(t.TempDir(), "key")
+			require.NoError(t, os.WriteFile(keyFilepath, []byte("key\n"), 0o600))
+
+			n, err := New(types.NotifiersCommonConfig{ClusterName: "cluster"}, NotifierOpsgenieConfig{
+				APIKeyFilepath: keyFilepath,
+				APIURL:         server.URL,
+				Priority:       "P2",
+				Responders:     []string{"sre"},
+				Tags:           []string{"chaos"},
+			}, zaptest.NewLogger(t).Sugar())
+			require.NoError(t, err)
+
+			dis := v1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{Name: "disruption", Namespace: "namespace"},
+				Spec:       v1beta1.DisruptionSpec{Reporting: tt.reporting},
+			}
+			event := corev1.Event{Reason: string(tt.reason), Message: "message"}
+
+			require.NoError(t, n.Notify(dis, event, tt.notifType))
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			tt.check(t, requests)
+		})
+	}
+}
+
+func TestNew_InvalidPriority(t *testing.T) {
+	t.Parallel()
+
+	keyFilepath := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFilepath, []byte("key"), 0o600))
+
+	_, err := New(types.NotifiersCommonConfig{}, NotifierOpsgenieConfig{APIKeyFilepath: keyFilepath, Priority: "P0"}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "invalid priority P0")
+}
@@ -0,0 +1,262 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/slack-go/slack"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InteractionsPath is the path the interactive messages callbacks are served on, the slack app request URL must point to it
+	InteractionsPath = "/slack/interactions"
+	// DefaultInteractionsAddr is the address the interactive messages callbacks are served on when not configured
+	DefaultInteractionsAddr = ":8090"
+
+	stopDisruptionActionID = "stop_disruption"
+	maxInteractionBodySize = 1 << 20
+)
+
+// stopButtonBlock returns the actions block holding the button stopping the given disruption
+// the disruption UID is part of the button value so a disruption re-created with the same name can't be stopped from an old message
+func stopButtonBlock(dis v1beta1.Disruption) *slack.ActionBlock {
+	button := slack.NewButtonBlockElement(
+		stopDisruptionActionID,
+		strings.Join([]string{dis.Namespace, dis.Name, string(dis.UID)}, "/"),
+		slack.NewTextBlockObject("plain_text", "Stop disruption", false, false),
+	).WithStyle(slack.StyleDanger).WithConfirm(slack.NewConfirmationBlockObject(
+		slack.NewTextBlockObject("plain_text", "Stop disruption?", false, false),
+		slack.NewTextBlockObject("mrkdwn", "Disruption `"+dis.Name+"` will be deleted and its effects removed from all targets.", false, false),
+		slack.NewTextBlockObject("plain_text", "Stop", false, false),
+		slack.NewTextBlockObject("plain_text", "Cancel", false, false),
+	))
+
+	return slack.NewActionBlock(stopDisruptionActionID, button)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so callbacks are served by every controller replica
+func (n *Notifier) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable and serves the interactive messages callbacks until the given context is done
+func (n *Notifier) Start(ctx context.Context) error {
+	if !n.config.Interactions.Enabled {
+		return nil
+	}
+
+	addr := n.config.Interactions.Addr
+	if addr == "" {
+		addr = DefaultInteractionsAddr
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(InteractionsPath, n)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("slack notifier: error listening on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			n.logger.Errorw("slack notifier: error stopping the interactions server", "error", err)
+		}
+	}()
+
+	n.logger.Infow("slack notifier: serving interactive messages callbacks", "addr", listener.Addr().String(), "path", InteractionsPath)
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("slack notifier: error serving interactive messages callbacks: %w", err)
+	}
+
+	return nil
+}
+
+// ServeHTTP handles the interactive messages callbacks sent by slack
+// see https://api.slack.com/interactivity/handling#payloads
+func (n *Notifier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	verifier, err := slack.NewSecretsVerifier(r.Header, n.signingSecret)
+	if err != nil {
+		n.logger.Warnw("slack notifier: invalid interaction request signature headers", "error", err)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	body, err := io.ReadAll(io.TeeReader(io.LimitReader(r.Body, maxInteractionBodySize), &verifier))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	if err := verifier.Ensure(); err != nil {
+		n.logger.Warnw("slack notifier: invalid interaction request signature", "error", err)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	callback := slack.InteractionCallback{}
+	if err := json.Unmarshal([]byte(form.Get("payload")), &callback); err != nil {
+		n.logger.Warnw("slack notifier: invalid interaction payload", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	if callback.Type == slack.InteractionTypeBlockActions {
+		for _, action := range callback.ActionCallback.BlockActions {
+			if action.ActionID == stopDisruptionActionID {
+				n.stopDisruption(r.Context(), callback, action.Value)
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// stopDisruption deletes the disruption referenced by the given stop button value if the slack user who clicked it is its author
+func (n *Notifier) stopDisruption(ctx context.Context, callback slack.InteractionCallback, value string) {
+	logger := n.logger.With("slackUserID", callback.User.ID, "buttonValue", value)
+
+	parts := strings.SplitN(value, "/", 3)
+	if len(parts) != 3 {
+		logger.Warnw("slack notifier: invalid stop button value")
+
+		return
+	}
+
+	namespace, name, uid := parts[0], parts[1], k8stypes.UID(parts[2])
+	logger = logger.With("disruptionName", name, "disruptionNamespace", namespace)
+
+	dis := v1beta1.Disruption{}
+	if err := n.k8sClient.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, &dis); err != nil {
+		if apierrors.IsNotFound(err) {
+			n.replyEphemeral(callback, "Disruption `"+name+"` does not exist anymore.")
+
+			return
+		}
+
+		logger.Errorw("slack notifier: unable to get the disruption to stop", "error", err)
+		n.replyEphemeral(callback, "Disruption `"+name+"` could not be stopped, please retry or delete it manually.")
+
+		return
+	}
+
+	if dis.UID != uid {
+		n.replyEphemeral(callback, "Disruption `"+name+"` has been re-created since this message was sent, it can't be stopped from here.")
+
+		return
+	}
+
+	if !dis.DeletionTimestamp.IsZero() {
+		n.replyEphemeral(callback, "Disruption `"+name+"` is already being stopped.")
+
+		return
+	}
+
+	authorized, err := n.isDisruptionAuthor(dis, callback.User.ID)
+	if err != nil {
+		logger.Warnw("slack notifier: unable to verify the slack user against the disruption user info", "error", err)
+	}
+
+	if !authorized {
+		n.replyEphemeral(callback, "Only the author of disruption `"+name+"` can stop it from slack.")
+
+		return
+	}
+
+	if err := n.k8sClient.Delete(ctx, &dis, client.Preconditions{UID: &uid}); err != nil && !apierrors.IsNotFound(err) {
+		logger.Errorw("slack notifier: unable to delete the disruption", "error", err)
+		n.replyEphemeral(callback, "Disruption `"+name+"` could not be stopped, please retry or delete it manually.")
+
+		return
+	}
+
+	logger.Infow("slack notifier: disruption stopped from slack")
+
+	threadTS := callback.Message.ThreadTimestamp
+	if threadTS == "" {
+		threadTS = callback.Message.Timestamp
+	}
+
+	if _, _, err := n.client.PostMessage(callback.Channel.ID,
+		slack.MsgOptionText("Disruption `"+name+"` has been stopped by <@"+callback.User.ID+">.", false),
+		slack.MsgOptionUsername("Disruption Status Bot"),
+		slack.MsgOptionIconURL("https://upload.wikimedia.org/wikipedia/commons/3/39/LogoChaosMonkeysNetflix.png"),
+		slack.MsgOptionTS(threadTS),
+		slack.MsgOptionAsUser(true),
+	); err != nil {
+		logger.Warnw("slack notifier: unable to confirm the disruption has been stopped", "error", err)
+	}
+}
+
+// isDisruptionAuthor returns true if the email of the given slack user is the username of the disruption user info
+func (n *Notifier) isDisruptionAuthor(dis v1beta1.Disruption, slackUserID string) (bool, error) {
+	userInfo, err := dis.UserInfo()
+	if err != nil {
+		return false, err
+	}
+
+	authorAddr, err := mail.ParseAddress(userInfo.Username)
+	if err != nil {
+		return false, fmt.Errorf("invalid user info email: %w", err)
+	}
+
+	slackUser, err := n.client.GetUserInfo(slackUserID)
+	if err != nil {
+		return false, fmt.Errorf("unable to get slack user: %w", err)
+	}
+
+	return slackUser.Profile.Email != "" && strings.EqualFold(slackUser.Profile.Email, authorAddr.Address), nil
+}
+
+// replyEphemeral sends a message only visible to the user who triggered the given interaction
+func (n *Notifier) replyEphemeral(callback slack.InteractionCallback, text string) {
+	if _, err := n.client.PostEphemeral(callback.Channel.ID, callback.User.ID, slack.MsgOptionText(text, false)); err != nil {
+		n.logger.Warnw("slack notifier: unable to reply to the interaction", "slackUserID", callback.User.ID, "error", err)
+	}
+}
@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap/zaptest"
+	authv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningSecret = "signing-secret"
+
+func TestNotifier_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		slackEmail  string
+		buttonValue string
+		tampered    bool
+		wantStatus  int
+		wantDeleted bool
+		wantReply   bool
+	}{
+		{
+			name:        "the author stops the disruption",
+			slackEmail:  "Author@Example.com",
+			buttonValue: "namespace/disruption/disruption-uid",
+			wantStatus:  http.StatusOK,
+			wantDeleted: true,
+		},
+		{
+			name:        "another user can't stop the disruption",
+			slackEmail:  "someone@example.com",
+			buttonValue: "namespace/disruption/disruption-uid",
+			wantStatus:  http.StatusOK,
+			wantReply:   true,
+		},
+		{
+			name:        "a re-created disruption can't be stopped from an old message",
+			buttonValue: "namespace/disruption/old-uid",
+			wantStatus:  http.StatusOK,
+			wantReply:   true,
+		},
+		{
+			name:        "an invalid signature is rejected",
+			buttonValue: "namespace/disruption/disruption-uid",
+			tampered:    true,
+			wantStatus:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			dis := &v1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "disruption",
+					Namespace:   "namespace",
+					UID:         "disruption-uid",
+					Annotations: map[string]string{},
+				},
+			}
+			require.NoError(t, dis.SetUserInfo(authv1.UserInfo{Username: "author@example.com"}))
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dis).Build()
+			slackClient := newSlackNotifierMock(t)
+
+			n := &Notifier{
+				client:        slackClient,
+				k8sClient:     k8sClient,
+				signingSecret: testSigningSecret,
+				logger:        zaptest.NewLogger(t).Sugar(),
+			}
+
+			if tt.slackEmail != "" {
+				slackClient.EXPECT().GetUserInfo("U123").Return(&slack.User{ID: "U123", Profile: slack.UserProfile{Email: tt.slackEmail}}, nil).Once()
+			}
+
+			if tt.wantDeleted {
+				slackClient.EXPECT().PostMessage("C123", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("C123", "1700000000.000200", nil).Once()
+			}
+
+			if tt.wantReply {
+				slackClient.EXPECT().PostEphemeral("C123", "U123", mock.Anything).Return("", nil).Once()
+			}
+
+			callback := slack.InteractionCallback{
+				Type:    slack.InteractionTypeBlockActions,
+				User:    slack.User{ID: "U123"},
+				Channel: slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "C123"}}},
+				Message: slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000100"}},
+				ActionCallback: slack.ActionCallbacks{
+					BlockActions: []*slack.BlockAction{{ActionID: stopDisruptionActionID, Value: tt.buttonValue}},
+				},
+			}
+
+			payload, err := json.Marshal(callback)
+			require.NoError(t, err)
+
+			body := url.Values{"payload": []string{string(payload)}}.Encode()
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+			signedBody := body
+			if tt.tampered {
+				signedBody = body + "tampered"
+			}
+
+			mac := hmac.New(sha256.New, []byte(testSigningSecret))
+			mac.Write([]byte("v0:" + timestamp + ":" + signedBody))
+
+			req := httptest.NewRequest(http.MethodPost, InteractionsPath, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+			req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+			rec := httptest.NewRecorder()
+			n.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+
+			err = k8sClient.Get(context.Background(), k8stypes.NamespacedName{Namespace: "namespace", Name: "disruption"}, &v1beta1.Disruption{})
+			if tt.wantDeleted {
+				require.True(t, apierrors.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
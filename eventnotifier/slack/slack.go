@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/eventnotifier/types"
@@ -21,6 +22,8 @@ import (
 	"go.uber.org/zap"
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -29,29 +32,58 @@ const (
 
 type slackNotifier interface {
 	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
+	PostEphemeral(channelID, userID string, options ...slack.MsgOption) (string, error)
 	GetUserByEmail(email string) (*slack.User, error)
+	GetUserInfo(user string) (*slack.User, error)
 }
 
 type NotifierSlackConfig struct {
 	Enabled              bool
 	TokenFilepath        string
 	MirrorSlackChannelID string // To remove when we stop testing observer feature
+	Threads              bool
+	Interactions         NotifierSlackInteractionsConfig
+}
+
+// NotifierSlackInteractionsConfig configures the endpoint receiving the interactive messages callbacks (e.g. the stop button)
+type NotifierSlackInteractionsConfig struct {
+	Enabled               bool
+	Addr                  string
+	SigningSecretFilepath string
 }
 
 // Notifier describes a Slack notifier
 type Notifier struct {
-	client slackNotifier
-	common types.NotifiersCommonConfig
-	config NotifierSlackConfig
-	logger *zap.SugaredLogger
+	client        slackNotifier
+	k8sClient     client.Client
+	common        types.NotifiersCommonConfig
+	config        NotifierSlackConfig
+	signingSecret string
+	threads       map[k8stypes.UID]disruptionThreads
+	threadsLock   sync.Mutex
+	logger        *zap.SugaredLogger
 }
 
 // New Slack Notifier
-func New(commonConfig types.NotifiersCommonConfig, slackConfig NotifierSlackConfig, logger *zap.SugaredLogger) (*Notifier, error) {
+func New(commonConfig types.NotifiersCommonConfig, slackConfig NotifierSlackConfig, k8sClient client.Client, logger *zap.SugaredLogger) (*Notifier, error) {
 	not := &Notifier{
-		common: commonConfig,
-		config: slackConfig,
-		logger: logger,
+		k8sClient: k8sClient,
+		common:    commonConfig,
+		config:    slackConfig,
+		threads:   map[k8stypes.UID]disruptionThreads{},
+		logger:    logger,
+	}
+
+	if slackConfig.Interactions.Enabled {
+		signingSecret, err := os.ReadFile(filepath.Clean(slackConfig.Interactions.SigningSecretFilepath))
+		if err != nil {
+			return nil, fmt.Errorf("slack signing secret file could not be read: %w", err)
+		}
+
+		not.signingSecret = strings.TrimSpace(string(signingSecret))
+		if not.signingSecret == "" {
+			return nil, fmt.Errorf("slack signing secret file is read, but seemingly empty")
+		}
 	}
 
 	tokenfile, err := os.Open(filepath.Clean(not.config.TokenFilepath))
@@ -127,12 +159,17 @@ func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType
 		disruptionBlocks = append(disruptionBlocks, slack.NewTextBlockObject("mrkdwn", "*Purpose:*\n"+dis.Spec.Reporting.Purpose, false, false))
 	}
 
+	// the disruption won't emit any other event once finished, its threads don't need to be kept in memory anymore
+	if event.Reason == string(v1beta1.EventDisruptionFinished) {
+		defer n.forgetThreads(dis)
+	}
+
 	if n.config.MirrorSlackChannelID != "" {
-		n.sendMessageToChannel(userInfo, n.config.MirrorSlackChannelID, headerText, headerBlock, disruptionBlocks, bodyBlock)
+		n.sendMessageToChannel(dis, userInfo, n.config.MirrorSlackChannelID, headerText, headerBlock, disruptionBlocks, bodyBlock)
 	}
 
 	if nil != dis.Spec.Reporting && dis.Spec.Reporting.SlackChannel != "" && dis.Spec.Reporting.MinNotificationType.Allows(notifType) {
-		n.sendMessageToChannel(userInfo, dis.Spec.Reporting.SlackChannel, headerText, headerBlock, disruptionBlocks, bodyBlock)
+		n.sendMessageToChannel(dis, userInfo, dis.Spec.Reporting.SlackChannel, headerText, headerBlock, disruptionBlocks, bodyBlock)
 	}
 
 	// We expect notification equal to or above success to be sent to users
@@ -153,18 +190,12 @@ func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType
 		return nil
 	}
 
-	_, _, err = n.client.PostMessage(p1.ID,
-		slack.MsgOptionText(headerText, false),
-		slack.MsgOptionUsername("Disruption Status Bot"),
-		slack.MsgOptionIconURL("https://upload.wikimedia.org/wikipedia/commons/3/39/LogoChaosMonkeysNetflix.png"),
-		slack.MsgOptionBlocks(
-			headerBlock,
-			slack.NewDividerBlock(),
-			slack.NewSectionBlock(nil, disruptionBlocks, nil),
-			slack.NewDividerBlock(),
-			bodyBlock,
-		),
-		slack.MsgOptionAsUser(true),
+	err = n.postMessage(dis, p1.ID, headerText,
+		headerBlock,
+		slack.NewDividerBlock(),
+		slack.NewSectionBlock(nil, disruptionBlocks, nil),
+		slack.NewDividerBlock(),
+		bodyBlock,
 	)
 	if err != nil {
 		return fmt.Errorf("slack notifier: %w", err)
@@ -175,26 +206,63 @@ func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType
 	return nil
 }
 
-func (n *Notifier) sendMessageToChannel(userInfo authv1.UserInfo, slackChannel, headerText string, headerBlock *slack.HeaderBlock, disruptionBlocks []*slack.TextBlockObject, bodyBlock *slack.SectionBlock) {
+func (n *Notifier) sendMessageToChannel(dis v1beta1.Disruption, userInfo authv1.UserInfo, slackChannel, headerText string, headerBlock *slack.HeaderBlock, disruptionBlocks []*slack.TextBlockObject, bodyBlock *slack.SectionBlock) {
 	userName := infoNotAvailable
 	if userInfo.Username != "" {
 		userName = userInfo.Username
 	}
 
-	_, _, err := n.client.PostMessage(slackChannel,
+	err := n.postMessage(dis, slackChannel, headerText,
+		headerBlock,
+		slack.NewDividerBlock(),
+		slack.NewSectionBlock(nil, append(disruptionBlocks, slack.NewTextBlockObject("mrkdwn", "*Author:*\n"+userName, false, false)), nil),
+		slack.NewDividerBlock(),
+		bodyBlock,
+	)
+	if err != nil {
+		n.logger.Errorw("slack notifier: couldn't send a message to the channel", "slackChannel", slackChannel, "error", err)
+	}
+}
+
+// postMessage posts the given blocks to the given destination (a channel or a user)
+// when threads are enabled, the first message sent to a destination for a disruption opens a thread and the next ones are posted as replies
+func (n *Notifier) postMessage(dis v1beta1.Disruption, destination, headerText string, blocks ...slack.Block) error {
+	var (
+		channelID   = destination
+		thread      Thread
+		threadFound bool
+	)
+
+	if n.config.Threads {
+		thread, threadFound = n.getThread(dis, destination)
+		if threadFound {
+			channelID = thread.Channel
+		} else if n.config.Interactions.Enabled {
+			blocks = append(blocks, stopButtonBlock(dis))
+		}
+	}
+
+	options := []slack.MsgOption{
 		slack.MsgOptionText(headerText, false),
 		slack.MsgOptionUsername("Disruption Status Bot"),
 		slack.MsgOptionIconURL("https://upload.wikimedia.org/wikipedia/commons/3/39/LogoChaosMonkeysNetflix.png"),
-		slack.MsgOptionBlocks(
-			headerBlock,
-			slack.NewDividerBlock(),
-			slack.NewSectionBlock(nil, append(disruptionBlocks, slack.NewTextBlockObject("mrkdwn", "*Author:*\n"+userName, false, false)), nil),
-			slack.NewDividerBlock(),
-			bodyBlock,
-		),
+		slack.MsgOptionBlocks(blocks...),
 		slack.MsgOptionAsUser(true),
-	)
+	}
+
+	if threadFound {
+		options = append(options, slack.MsgOptionTS(thread.TS))
+	}
+
+	respChannel, respTimestamp, err := n.client.PostMessage(channelID, options...)
 	if err != nil {
-		n.logger.Errorw("slack notifier: couldn't send a message to the channel", "slackChannel", slackChannel, "error", err)
+		return err
 	}
+
+	if n.config.Threads && !threadFound {
+		// the returned channel is the one to reply to, it differs from the destination when sending a direct message to a user
+		n.saveThread(dis, destination, Thread{Channel: respChannel, TS: respTimestamp})
+	}
+
+	return nil
 }
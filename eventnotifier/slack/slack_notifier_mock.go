@@ -78,6 +78,128 @@ func (_c *slackNotifierMock_GetUserByEmail_Call) RunAndReturn(run func(string) (
 	return _c
 }
 
+// GetUserInfo provides a mock function with given fields: user
+func (_m *slackNotifierMock) GetUserInfo(user string) (*slack_goslack.User, error) {
+	ret := _m.Called(user)
+
+	var r0 *slack_goslack.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*slack_goslack.User, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(string) *slack_goslack.User); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*slack_goslack.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// slackNotifierMock_GetUserInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInfo'
+type slackNotifierMock_GetUserInfo_Call struct {
+	*mock.Call
+}
+
+// GetUserInfo is a helper method to define mock.On call
+//   - user string
+func (_e *slackNotifierMock_Expecter) GetUserInfo(user interface{}) *slackNotifierMock_GetUserInfo_Call {
+	return &slackNotifierMock_GetUserInfo_Call{Call: _e.mock.On("GetUserInfo", user)}
+}
+
+func (_c *slackNotifierMock_GetUserInfo_Call) Run(run func(user string)) *slackNotifierMock_GetUserInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *slackNotifierMock_GetUserInfo_Call) Return(_a0 *slack_goslack.User, _a1 error) *slackNotifierMock_GetUserInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *slackNotifierMock_GetUserInfo_Call) RunAndReturn(run func(string) (*slack_goslack.User, error)) *slackNotifierMock_GetUserInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostEphemeral provides a mock function with given fields: channelID, userID, options
+func (_m *slackNotifierMock) PostEphemeral(channelID string, userID string, options ...slack_goslack.MsgOption) (string, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, channelID, userID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, ...slack_goslack.MsgOption) (string, error)); ok {
+		return rf(channelID, userID, options...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, ...slack_goslack.MsgOption) string); ok {
+		r0 = rf(channelID, userID, options...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, ...slack_goslack.MsgOption) error); ok {
+		r1 = rf(channelID, userID, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// slackNotifierMock_PostEphemeral_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostEphemeral'
+type slackNotifierMock_PostEphemeral_Call struct {
+	*mock.Call
+}
+
+// PostEphemeral is a helper method to define mock.On call
+//   - channelID string
+//   - userID string
+//   - options ...slack_goslack.MsgOption
+func (_e *slackNotifierMock_Expecter) PostEphemeral(channelID interface{}, userID interface{}, options ...interface{}) *slackNotifierMock_PostEphemeral_Call {
+	return &slackNotifierMock_PostEphemeral_Call{Call: _e.mock.On("PostEphemeral",
+		append([]interface{}{channelID, userID}, options...)...)}
+}
+
+func (_c *slackNotifierMock_PostEphemeral_Call) Run(run func(channelID string, userID string, options ...slack_goslack.MsgOption)) *slackNotifierMock_PostEphemeral_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]slack_goslack.MsgOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(slack_goslack.MsgOption)
+			}
+		}
+		run(args[0].(string), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *slackNotifierMock_PostEphemeral_Call) Return(_a0 string, _a1 error) *slackNotifierMock_PostEphemeral_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *slackNotifierMock_PostEphemeral_Call) RunAndReturn(run func(string, string, ...slack_goslack.MsgOption) (string, error)) *slackNotifierMock_PostEphemeral_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PostMessage provides a mock function with given fields: channelID, options
 func (_m *slackNotifierMock) PostMessage(channelID string, options ...slack_goslack.MsgOption) (string, string, error) {
 	_va := make([]interface{}, len(options))
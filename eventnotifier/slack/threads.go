@@ -0,0 +1,103 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package slack
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Thread locates the parent message of a disruption thread
+type Thread struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// disruptionThreads are the threads of a disruption, indexed by destination (channel or user ID)
+type disruptionThreads map[string]Thread
+
+// getThread returns the thread of the given disruption for the given destination
+// threads are kept in memory as the disruption read from the cache may not contain the annotation of a thread opened for a previous event yet,
+// the annotation being used to keep posting to the same threads after a controller restart
+func (n *Notifier) getThread(dis v1beta1.Disruption, destination string) (Thread, bool) {
+	n.threadsLock.Lock()
+	defer n.threadsLock.Unlock()
+
+	if thread, found := n.threads[dis.UID][destination]; found {
+		return thread, true
+	}
+
+	threads := disruptionThreads{}
+
+	if annotation, found := dis.Annotations[chaostypes.SlackThreadsAnnotation]; found {
+		if err := json.Unmarshal([]byte(annotation), &threads); err != nil {
+			n.logger.Warnw("slack notifier: unable to unmarshal disruption threads annotation, opening new threads", "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "error", err)
+
+			return Thread{}, false
+		}
+	}
+
+	thread, found := threads[destination]
+
+	return thread, found
+}
+
+// saveThread stores the thread of the given disruption for the given destination in memory and in the disruption annotations
+func (n *Notifier) saveThread(dis v1beta1.Disruption, destination string, thread Thread) {
+	n.threadsLock.Lock()
+	defer n.threadsLock.Unlock()
+
+	if _, found := n.threads[dis.UID]; !found {
+		n.threads[dis.UID] = disruptionThreads{}
+	}
+
+	n.threads[dis.UID][destination] = thread
+
+	if n.k8sClient == nil {
+		return
+	}
+
+	// merge the threads from the annotation so threads opened before a controller restart are not lost
+	threads := disruptionThreads{}
+
+	if annotation, found := dis.Annotations[chaostypes.SlackThreadsAnnotation]; found {
+		_ = json.Unmarshal([]byte(annotation), &threads)
+	}
+
+	for threadDestination, memoryThread := range n.threads[dis.UID] {
+		threads[threadDestination] = memoryThread
+	}
+
+	marshaledThreads, err := json.Marshal(threads)
+	if err != nil {
+		n.logger.Errorw("slack notifier: unable to marshal disruption threads", "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "error", err)
+
+		return
+	}
+
+	patched := dis.DeepCopy()
+	if patched.Annotations == nil {
+		patched.Annotations = map[string]string{}
+	}
+
+	patched.Annotations[chaostypes.SlackThreadsAnnotation] = string(marshaledThreads)
+
+	if err := n.k8sClient.Patch(context.Background(), patched, client.MergeFrom(&dis)); err != nil {
+		n.logger.Warnw("slack notifier: unable to store disruption threads in annotations", "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "error", err)
+	}
+}
+
+// forgetThreads removes the threads of the given disruption from memory
+func (n *Notifier) forgetThreads(dis v1beta1.Disruption) {
+	n.threadsLock.Lock()
+	defer n.threadsLock.Unlock()
+
+	delete(n.threads, dis.UID)
+}
@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify_Threads(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	dis := v1beta1.Disruption{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "disruption",
+			Namespace:   "namespace",
+			UID:         "disruption-uid",
+			Annotations: map[string]string{},
+		},
+		Spec: v1beta1.DisruptionSpec{
+			Reporting: &v1beta1.Reporting{SlackChannel: "custom-slack-channel", MinNotificationType: types.NotificationInfo},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dis.DeepCopy()).Build()
+	require.NoError(t, k8sClient.Get(context.Background(), k8stypes.NamespacedName{Namespace: dis.Namespace, Name: dis.Name}, &dis))
+
+	slackClient := newSlackNotifierMock(t)
+
+	n := &Notifier{
+		client:    slackClient,
+		k8sClient: k8sClient,
+		config: NotifierSlackConfig{
+			Threads:      true,
+			Interactions: NotifierSlackInteractionsConfig{Enabled: true},
+		},
+		threads: map[k8stypes.UID]disruptionThreads{},
+		logger:  zaptest.NewLogger(t).Sugar(),
+	}
+
+	// the first notification opens the thread in the channel
+	openThreadCall := slackClient.EXPECT().PostMessage(
+		"custom-slack-channel",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return("C123", "1700000000.000100", nil).Once()
+
+	// the next ones are replies posted to the channel returned on thread creation
+	slackClient.EXPECT().PostMessage(
+		"C123",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return("C123", "1700000000.000200", nil).Twice().NotBefore(openThreadCall)
+
+	event := corev1.Event{Reason: string(v1beta1.EventDisruptionCreated), Message: "some message"}
+
+	require.NoError(t, n.Notify(dis, event, types.NotificationInfo))
+
+	// the thread is stored in the disruption annotations
+	stored := v1beta1.Disruption{}
+	require.NoError(t, k8sClient.Get(context.Background(), k8stypes.NamespacedName{Namespace: dis.Namespace, Name: dis.Name}, &stored))
+	require.JSONEq(t, `{"custom-slack-channel":{"channel":"C123","ts":"1700000000.000100"}}`, stored.Annotations[chaostypes.SlackThreadsAnnotation])
+
+	// the disruption read before the annotation was stored still replies in the thread thanks to the memory cache
+	require.NoError(t, n.Notify(dis, event, types.NotificationInfo))
+
+	// the annotation is used once the memory cache is dropped (e.g. after a controller restart)
+	n.forgetThreads(dis)
+	require.NoError(t, n.Notify(stored, event, types.NotificationInfo))
+}
@@ -0,0 +1,262 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package smtp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"github.com/DataDog/chaos-controller/eventnotifier/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultPort is the SMTP submission port used when none is configured
+	DefaultPort = 587
+
+	// sendMailTimeout bounds the whole SMTP exchange, a stuck server must not block the notifications
+	sendMailTimeout = 30 * time.Second
+)
+
+type NotifierSMTPConfig struct {
+	Enabled          bool
+	Host             string
+	Port             int
+	Username         string
+	PasswordFilepath string
+	From             string
+	To               []string
+	NotifyAuthor     bool
+}
+
+// sendMailFunc has the signature of smtp.SendMail so it can be replaced in tests
+type sendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// Notifier describes an SMTP (email) notifier
+type Notifier struct {
+	common       types.NotifiersCommonConfig
+	addr         string
+	auth         smtp.Auth
+	from         *mail.Address
+	to           []string
+	notifyAuthor bool
+	sendMail     sendMailFunc
+	logger       *zap.SugaredLogger
+}
+
+// New SMTP Notifier
+func New(commonConfig types.NotifiersCommonConfig, smtpConfig NotifierSMTPConfig, logger *zap.SugaredLogger) (*Notifier, error) {
+	if smtpConfig.Host == "" {
+		return nil, fmt.Errorf("smtp notifier: missing host")
+	}
+
+	from, err := mail.ParseAddress(smtpConfig.From)
+	if err != nil {
+		return nil, fmt.Errorf("smtp notifier: invalid from address %s: %w", smtpConfig.From, err)
+	}
+
+	to := []string{}
+
+	for _, recipient := range smtpConfig.To {
+		addr, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("smtp notifier: invalid recipient address %s: %w", recipient, err)
+		}
+
+		to = append(to, addr.Address)
+	}
+
+	if len(to) == 0 && !smtpConfig.NotifyAuthor {
+		return nil, fmt.Errorf("smtp notifier: no recipient configured, at least one recipient is required when the disruption author is not notified")
+	}
+
+	port := smtpConfig.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+
+	// authentication is optional, relays accepting unauthenticated mails from the cluster are common
+	var auth smtp.Auth
+
+	if smtpConfig.Username != "" {
+		if smtpConfig.PasswordFilepath == "" {
+			return nil, fmt.Errorf("smtp notifier: missing password filepath for user %s", smtpConfig.Username)
+		}
+
+		password, err := os.ReadFile(filepath.Clean(smtpConfig.PasswordFilepath))
+		if err != nil {
+			return nil, fmt.Errorf("smtp notifier: password file could not be read: %w", err)
+		}
+
+		auth = smtp.PlainAuth("", smtpConfig.Username, strings.TrimSpace(string(password)), smtpConfig.Host)
+	}
+
+	return &Notifier{
+		common:       commonConfig,
+		addr:         net.JoinHostPort(smtpConfig.Host, strconv.Itoa(port)),
+		auth:         auth,
+		from:         from,
+		to:           to,
+		notifyAuthor: smtpConfig.NotifyAuthor,
+		sendMail:     sendMailWithTimeout(sendMailTimeout),
+		logger:       logger,
+	}, nil
+}
+
+// GetNotifierName returns the driver's name
+func (n *Notifier) GetNotifierName() string {
+	return string(types.NotifierDriverSMTP)
+}
+
+// Notify sends an email to the configured recipients, and optionally the disruption author,
+// for notifications allowed by the disruption reporting configuration
+func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType) error {
+	if !utils.MinNotificationType(dis).Allows(notifType) {
+		return nil
+	}
+
+	to := append([]string{}, n.to...)
+
+	if n.notifyAuthor {
+		if userInfo, err := dis.UserInfo(); err != nil {
+			n.logger.Warnw("smtp notifier: no user info in disruption", "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "error", err)
+		} else if authorAddr, err := mail.ParseAddress(userInfo.Username); err != nil {
+			n.logger.Warnw("smtp notifier: user info username is not a valid email address", "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "error", err, "username", userInfo.Username)
+		} else {
+			to = append(to, authorAddr.Address)
+		}
+	}
+
+	if len(to) == 0 {
+		return nil
+	}
+
+	n.logger.Debugw("notifier: sending notifier event by email", "disruptionName", dis.Name, "eventType", event.Type, "recipients", to)
+
+	if err := n.sendMail(n.addr, n.auth, n.from.Address, to, n.buildMessage(dis, event, notifType, to)); err != nil {
+		return fmt.Errorf("smtp notifier: error when sending email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage returns a plain text RFC 5322 message
+func (n *Notifier) buildMessage(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType, to []string) []byte {
+	subject := utils.BuildHeaderMessageFromDisruptionEvent(dis, notifType)
+	if n.common.ClusterName != "" {
+		subject = "[" + n.common.ClusterName + "] " + subject
+	}
+
+	msg := bytes.Buffer{}
+
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+
+	fmt.Fprintf(&msg, "%s\r\n\r\n", utils.BuildBodyMessageFromDisruptionEvent(dis, event, false))
+	fmt.Fprintf(&msg, "Kind: %s\r\n", dis.Kind)
+	fmt.Fprintf(&msg, "Name: %s\r\n", dis.Name)
+	fmt.Fprintf(&msg, "Namespace: %s\r\n", dis.Namespace)
+	fmt.Fprintf(&msg, "Targets: %d\r\n", len(dis.Status.TargetInjections))
+
+	if n.common.ClusterName != "" {
+		fmt.Fprintf(&msg, "Cluster: %s\r\n", n.common.ClusterName)
+	}
+
+	if targetName, ok := event.Annotations["target_name"]; ok {
+		fmt.Fprintf(&msg, "Target: %s\r\n", targetName)
+	}
+
+	return msg.Bytes()
+}
+
+// sendMailWithTimeout returns a function behaving like smtp.SendMail, which has no timeout,
+// but bounding the connection and the whole exchange with the server to the given timeout
+func sendMailWithTimeout(timeout time.Duration) sendMailFunc {
+	return func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return err
+		}
+
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return err
+		}
+
+		if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+			_ = conn.Close()
+
+			return err
+		}
+
+		c, err := smtp.NewClient(conn, host)
+		if err != nil {
+			_ = conn.Close()
+
+			return err
+		}
+		defer c.Close()
+
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+				return err
+			}
+		}
+
+		if auth != nil {
+			if ok, _ := c.Extension("AUTH"); !ok {
+				return fmt.Errorf("server doesn't support AUTH")
+			}
+
+			if err := c.Auth(auth); err != nil {
+				return err
+			}
+		}
+
+		if err := c.Mail(from); err != nil {
+			return err
+		}
+
+		for _, recipient := range to {
+			if err := c.Rcpt(recipient); err != nil {
+				return err
+			}
+		}
+
+		w, err := c.Data()
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(msg); err != nil {
+			return err
+		}
+
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		return c.Quit()
+	}
+}
@@ -0,0 +1,239 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package smtp
+
+import (
+	"bufio"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"go.uber.org/zap/zaptest"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notifyAuthor bool
+		reporting    *v1beta1.Reporting
+		notifType    types.NotificationType
+		wantTo       []string
+	}{
+		{
+			name:      "warning is sent to the recipients",
+			notifType: types.NotificationWarning,
+			wantTo:    []string{"chaos@example.com"},
+		},
+		{
+			name:         "warning is sent to the recipients and the author",
+			notifyAuthor: true,
+			notifType:    types.NotificationWarning,
+			wantTo:       []string{"chaos@example.com", "author@example.com"},
+		},
+		{
+			name:      "info is skipped by default",
+			notifType: types.NotificationInfo,
+		},
+		{
+			name:      "warning is skipped when the disruption asks for errors only",
+			reporting: &v1beta1.Reporting{MinNotificationType: types.NotificationError},
+			notifType: types.NotificationWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := New(types.NotifiersCommonConfig{ClusterName: "cluster"}, NotifierSMTPConfig{
+				Host:         "smtp.example.com",
+				From:         "Chaos Controller <chaos-controller@example.com>",
+				To:           []string{"chaos@example.com"},
+				NotifyAuthor: tt.notifyAuthor,
+			}, zaptest.NewLogger(t).Sugar())
+			require.NoError(t, err)
+
+			sent := []sentMail{}
+			n.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+				sent = append(sent, sentMail{addr: addr, from: from, to: to, msg: string(msg)})
+
+				return nil
+			}
+
+			dis := v1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{Name: "disruption", Namespace: "namespace", Annotations: map[string]string{}},
+				Spec:       v1beta1.DisruptionSpec{Reporting: tt.reporting},
+			}
+			require.NoError(t, dis.SetUserInfo(authv1.UserInfo{Username: "author@example.com"}))
+
+			event := corev1.Event{Reason: "Stuck", Message: "the disruption is stuck"}
+
+			require.NoError(t, n.Notify(dis, event, tt.notifType))
+
+			if tt.wantTo == nil {
+				require.Empty(t, sent)
+
+				return
+			}
+
+			require.Len(t, sent, 1)
+			require.Equal(t, "smtp.example.com:587", sent[0].addr)
+			require.Equal(t, "chaos-controller@example.com", sent[0].from)
+			require.Equal(t, tt.wantTo, sent[0].to)
+			require.Contains(t, sent[0].msg, "Subject: [cluster] Disruption 'disruption' encountered an issue.\r\n")
+			require.Contains(t, sent[0].msg, "Disruption 'disruption' emitted the event Stuck: the disruption is stuck")
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(types.NotifiersCommonConfig{}, NotifierSMTPConfig{Host: "smtp.example.com", From: "not an address"}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "invalid from address")
+
+	_, err = New(types.NotifiersCommonConfig{}, NotifierSMTPConfig{Host: "smtp.example.com", From: "chaos-controller@example.com"}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "no recipient configured")
+
+	_, err = New(types.NotifiersCommonConfig{}, NotifierSMTPConfig{Host: "smtp.example.com", From: "chaos-controller@example.com", To: []string{"chaos@example.com"}, Username: "user"}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "missing password filepath")
+}
+
+// serveSMTP accepts a single connection on the returned address and answers it with a minimal SMTP dialog
+// recording the received message, or stays silent if asked to
+func serveSMTP(t *testing.T, silent bool) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if silent {
+			// keep the connection open without answering until the client gives up
+			_, _ = bufio.NewReader(conn).ReadString('\n')
+
+			return
+		}
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		msg := strings.Builder{}
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"):
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 Go ahead")
+
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					if line == ".\r\n" {
+						break
+					}
+
+					msg.WriteString(line)
+				}
+
+				received <- msg.String()
+
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSendMailWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mail is sent to the server", func(t *testing.T) {
+		t.Parallel()
+
+		addr, received := serveSMTP(t, false)
+
+		require.NoError(t, sendMailWithTimeout(5*time.Second)(addr, nil, "chaos-controller@example.com", []string{"chaos@example.com"}, []byte("Subject: test\r\n\r\nbody\r\n")))
+
+		select {
+		case msg := <-received:
+			require.Equal(t, "Subject: test\r\n\r\nbody\r\n", msg)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no mail received by the server")
+		}
+	})
+
+	t.Run("a stuck server times out", func(t *testing.T) {
+		t.Parallel()
+
+		addr, _ := serveSMTP(t, true)
+		start := time.Now()
+
+		err := sendMailWithTimeout(100*time.Millisecond)(addr, nil, "chaos-controller@example.com", []string{"chaos@example.com"}, []byte("body"))
+
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		require.True(t, netErr.Timeout())
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("authentication requires the server to support it", func(t *testing.T) {
+		t.Parallel()
+
+		addr, _ := serveSMTP(t, false)
+
+		err := sendMailWithTimeout(5*time.Second)(addr, smtp.PlainAuth("", "user", "password", "127.0.0.1"), "chaos-controller@example.com", []string{"chaos@example.com"}, []byte("body"))
+		require.ErrorContains(t, err, "server doesn't support AUTH")
+	})
+}
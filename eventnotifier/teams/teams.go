@@ -0,0 +1,209 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"github.com/DataDog/chaos-controller/eventnotifier/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+type NotifierTeamsConfig struct {
+	Enabled            bool
+	WebhookURLFilepath string
+}
+
+// Notifier describes a Microsoft Teams notifier
+type Notifier struct {
+	common     types.NotifiersCommonConfig
+	client     *http.Client
+	webhookURL string
+	logger     *zap.SugaredLogger
+}
+
+// Message is the message posted to a Teams incoming webhook, wrapping an adaptive card
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment is a card attached to a Teams message
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is the subset of the adaptive card schema used by the notifier
+type AdaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []interface{} `json:"body"`
+}
+
+// TextBlock is an adaptive card text element
+type TextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+// FactSet is an adaptive card key/value list element
+type FactSet struct {
+	Type  string `json:"type"`
+	Facts []Fact `json:"facts"`
+}
+
+// Fact is an entry of a FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// New Teams Notifier
+func New(commonConfig types.NotifiersCommonConfig, teamsConfig NotifierTeamsConfig, logger *zap.SugaredLogger) (*Notifier, error) {
+	if teamsConfig.WebhookURLFilepath == "" {
+		return nil, fmt.Errorf("teams notifier: missing webhook URL filepath")
+	}
+
+	readURL, err := os.ReadFile(filepath.Clean(teamsConfig.WebhookURLFilepath))
+	if err != nil {
+		return nil, fmt.Errorf("teams notifier: webhook URL file could not be read: %w", err)
+	}
+
+	webhookURL := strings.TrimSpace(string(readURL))
+	if webhookURL == "" {
+		return nil, fmt.Errorf("teams notifier: webhook URL file is empty")
+	}
+
+	return &Notifier{
+		common: commonConfig,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		webhookURL: webhookURL,
+		logger:     logger,
+	}, nil
+}
+
+// GetNotifierName returns the driver's name
+func (n *Notifier) GetNotifierName() string {
+	return string(types.NotifierDriverTeams)
+}
+
+// Notify posts an adaptive card to the configured Teams channel for notifications allowed by the disruption reporting configuration
+func (n *Notifier) Notify(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType) error {
+	if !utils.MinNotificationType(dis).Allows(notifType) {
+		return nil
+	}
+
+	body, err := json.Marshal(n.buildMessage(dis, event, notifType))
+	if err != nil {
+		return fmt.Errorf("teams notifier: couldn't build message: %w", err)
+	}
+
+	n.logger.Debugw("notifier: sending notifier event to teams", "disruptionName", dis.Name, "eventType", event.Type, "notificationType", notifType)
+
+	res, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("teams notifier: error when sending notification: %w", err)
+	}
+
+	if err := res.Body.Close(); err != nil {
+		return fmt.Errorf("teams notifier: error when sending notification: %w", err)
+	}
+
+	if res.StatusCode >= 300 || res.StatusCode < 200 {
+		return fmt.Errorf("teams notifier: receiving %d status code from sent notification", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (n *Notifier) buildMessage(dis v1beta1.Disruption, event corev1.Event, notifType types.NotificationType) Message {
+	facts := []Fact{
+		{Title: "Kind", Value: dis.Kind},
+		{Title: "Name", Value: dis.Name},
+		{Title: "Namespace", Value: dis.Namespace},
+		{Title: "Targets", Value: strconv.Itoa(len(dis.Status.TargetInjections))},
+	}
+
+	if n.common.ClusterName != "" {
+		facts = append(facts, Fact{Title: "Cluster", Value: n.common.ClusterName})
+	}
+
+	if userInfo, err := dis.UserInfo(); err == nil && userInfo.Username != "" {
+		facts = append(facts, Fact{Title: "Author", Value: userInfo.Username})
+	}
+
+	if targetName, ok := event.Annotations["target_name"]; ok {
+		facts = append(facts, Fact{Title: "Target", Value: targetName})
+	}
+
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content: AdaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body: []interface{}{
+						TextBlock{
+							Type:   "TextBlock",
+							Text:   utils.BuildHeaderMessageFromDisruptionEvent(dis, notifType),
+							Weight: "Bolder",
+							Size:   "Medium",
+							Color:  cardColor(notifType),
+							Wrap:   true,
+						},
+						TextBlock{
+							Type: "TextBlock",
+							Text: utils.BuildBodyMessageFromDisruptionEvent(dis, event, false),
+							Wrap: true,
+						},
+						FactSet{
+							Type:  "FactSet",
+							Facts: facts,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cardColor returns the adaptive card color matching the given notification type
+func cardColor(notifType types.NotificationType) string {
+	switch notifType {
+	case types.NotificationSuccess:
+		return "Good"
+	case types.NotificationWarning:
+		return "Warning"
+	case types.NotificationError:
+		return "Attention"
+	default:
+		return "Default"
+	}
+}
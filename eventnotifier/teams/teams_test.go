@@ -0,0 +1,125 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package teams
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/eventnotifier/types"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reporting *v1beta1.Reporting
+		notifType types.NotificationType
+		wantSent  bool
+	}{
+		{
+			name:      "warning is sent by default",
+			notifType: types.NotificationWarning,
+			wantSent:  true,
+		},
+		{
+			name:      "info is skipped by default",
+			notifType: types.NotificationInfo,
+		},
+		{
+			name:      "info is sent when the disruption asks for it",
+			reporting: &v1beta1.Reporting{MinNotificationType: types.NotificationInfo},
+			notifType: types.NotificationInfo,
+			wantSent:  true,
+		},
+		{
+			name:      "success is skipped when the disruption asks for errors only",
+			reporting: &v1beta1.Reporting{MinNotificationType: types.NotificationError},
+			notifType: types.NotificationSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock   sync.Mutex
+				bodies [][]byte
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, body)
+			}))
+			defer server.Close()
+
+			webhookFilepath := filepath.Join(t.TempDir(), "webhook")
+			require.NoError(t, os.WriteFile(webhookFilepath, []byte(server.URL+"\n"), 0o600))
+
+			n, err := New(types.NotifiersCommonConfig{ClusterName: "cluster"}, NotifierTeamsConfig{WebhookURLFilepath: webhookFilepath}, zaptest.NewLogger(t).Sugar())
+			require.NoError(t, err)
+
+			dis := v1beta1.Disruption{
+				ObjectMeta: metav1.ObjectMeta{Name: "disruption", Namespace: "namespace"},
+				Spec:       v1beta1.DisruptionSpec{Reporting: tt.reporting},
+			}
+			event := corev1.Event{Reason: "Stuck", Message: "the disruption is stuck"}
+
+			require.NoError(t, n.Notify(dis, event, tt.notifType))
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if !tt.wantSent {
+				require.Empty(t, bodies)
+
+				return
+			}
+
+			require.Len(t, bodies, 1)
+
+			message := Message{}
+			require.NoError(t, json.Unmarshal(bodies[0], &message))
+			require.Equal(t, "message", message.Type)
+			require.Len(t, message.Attachments, 1)
+			require.Equal(t, adaptiveCardContentType, message.Attachments[0].ContentType)
+			require.Contains(t, string(bodies[0]), "Disruption 'disruption' emitted the event Stuck: the disruption is stuck")
+			require.Contains(t, string(bodies[0]), `{"title":"Cluster","value":"cluster"}`)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(types.NotifiersCommonConfig{}, NotifierTeamsConfig{}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "missing webhook URL filepath")
+
+	emptyFilepath := filepath.Join(t.TempDir(), "webhook")
+	require.NoError(t, os.WriteFile(emptyFilepath, []byte("\n"), 0o600))
+
+	_, err = New(types.NotifiersCommonConfig{}, NotifierTeamsConfig{WebhookURLFilepath: emptyFilepath}, zaptest.NewLogger(t).Sugar())
+	require.ErrorContains(t, err, "webhook URL file is empty")
+}
@@ -23,6 +23,15 @@ const (
 
 	// NotifierDriverHTTP is the HTTP driver
 	NotifierDriverHTTP NotifierDriver = "http"
+
+	// NotifierDriverTeams is the Microsoft Teams driver
+	NotifierDriverTeams NotifierDriver = "teams"
+
+	// NotifierDriverOpsgenie is the Opsgenie driver
+	NotifierDriverOpsgenie NotifierDriver = "opsgenie"
+
+	// NotifierDriverSMTP is the SMTP (email) driver
+	NotifierDriverSMTP NotifierDriver = "smtp"
 )
 
 // NotificationType is the type representing all notification types level available
@@ -19,6 +19,16 @@ func BuildBodyMessageFromDisruptionEvent(dis v1beta1.Disruption, event corev1.Ev
 	return "Disruption '" + dis.Name + "' emitted the event " + event.Reason + ": " + event.Message
 }
 
+// MinNotificationType returns the minimal notification type the disruption asked to be notified for
+// it defaults to Unknown, which has the same semantics as Success
+func MinNotificationType(dis v1beta1.Disruption) types.NotificationType {
+	if dis.Spec.Reporting == nil {
+		return types.NotificationUnknown
+	}
+
+	return dis.Spec.Reporting.MinNotificationType
+}
+
 // BuildHeaderMessageFromDisruptionEvent Templated header text to send to notifiers
 func BuildHeaderMessageFromDisruptionEvent(dis v1beta1.Disruption, notifType types.NotificationType) string {
 	switch notifType {
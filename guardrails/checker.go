@@ -0,0 +1,364 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultHTTPClientTimeout is the timeout of the http client used when none is given, checks being evaluated during reconciliation
+const defaultHTTPClientTimeout = 30 * time.Second
+
+// PrometheusQueryTimeout is the timeout of a prometheus check query
+var PrometheusQueryTimeout = 10 * time.Second
+
+// ErrNoPrometheusEndpoint is returned when a prometheus check is evaluated without any endpoint to query
+var ErrNoPrometheusEndpoint = errors.New("no prometheus endpoint configured in the controller")
+
+// ErrRedirectNotAllowed is returned when a check is redirected to a host which is not allowed
+var ErrRedirectNotAllowed = errors.New("redirect not allowed")
+
+// Result is the outcome of the evaluation of a guardrail check
+type Result struct {
+	// Breached is true when the check is not satisfied
+	Breached bool
+	// Message describes the outcome of the evaluation
+	Message string
+}
+
+// Checker evaluates the guardrail checks of a disruption
+type Checker interface {
+	// Check evaluates the given check for the given disruption
+	// an error is returned when the check could not be evaluated (e.g. the prometheus endpoint is unreachable)
+	Check(ctx context.Context, disruption *v1beta1.Disruption, check v1beta1.GuardrailCheck) (Result, error)
+}
+
+type checker struct {
+	client             client.Client
+	httpClient         *http.Client
+	prometheusEndpoint string
+	allowedHosts       []string
+}
+
+// NewChecker creates a new guardrail checker
+// prometheusEndpoint is the endpoint queried by prometheus checks, it can be empty
+// allowedHosts are the only hosts http checks can query, including when being redirected
+func NewChecker(k8sClient client.Client, httpClient *http.Client, prometheusEndpoint string, allowedHosts []string) Checker {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: defaultHTTPClientTimeout,
+		}
+	}
+
+	c := &checker{
+		client:             k8sClient,
+		prometheusEndpoint: prometheusEndpoint,
+		allowedHosts:       allowedHosts,
+	}
+
+	// copy the given client so its redirect policy is not altered
+	checkedClient := *httpClient
+	checkedClient.CheckRedirect = c.checkRedirect
+	c.httpClient = &checkedClient
+
+	return c
+}
+
+// checkRedirect denies redirections to hosts which are not allowed, except to the host of the initial request
+func (c *checker) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if req.URL.Hostname() == via[0].URL.Hostname() {
+		return nil
+	}
+
+	if err := v1beta1.ValidateGuardrailURL(req.URL, c.allowedHosts); err != nil {
+		return fmt.Errorf("%w: %s", ErrRedirectNotAllowed, err)
+	}
+
+	return nil
+}
+
+// Check evaluates the given check for the given disruption
+func (c *checker) Check(ctx context.Context, disruption *v1beta1.Disruption, check v1beta1.GuardrailCheck) (Result, error) {
+	switch {
+	case check.HTTP != nil:
+		return c.checkHTTP(ctx, *check.HTTP)
+	case check.Prometheus != nil:
+		return c.checkPrometheus(ctx, *check.Prometheus)
+	case check.TargetReadiness != nil:
+		return c.checkTargetReadiness(ctx, disruption, *check.TargetReadiness)
+	}
+
+	return Result{}, fmt.Errorf("guardrail check %s does not define any check", check.Name)
+}
+
+// checkHTTP is breached when the url can't be reached or when it answers with an unexpected status code
+func (c *checker) checkHTTP(ctx context.Context, check v1beta1.GuardrailHTTPCheck) (Result, error) {
+	timeout := check.Timeout.Duration()
+	if timeout <= 0 {
+		timeout = v1beta1.DefaultGuardrailHTTPTimeout
+	}
+
+	u, err := url.ParseRequestURI(check.URL)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid http probe url: %w", err)
+	}
+
+	// the url is validated on admission, but the allowed hosts may have changed since then
+	if err := v1beta1.ValidateGuardrailURL(u, c.allowedHosts); err != nil {
+		return Result{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("error creating the http probe request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if errors.Is(err, ErrRedirectNotAllowed) {
+		return Result{}, err
+	}
+
+	if err != nil {
+		return Result{
+			Breached: true,
+			Message:  fmt.Sprintf("%s is unreachable: %s", check.URL, err),
+		}, nil
+	}
+
+	defer res.Body.Close()
+
+	if !isExpectedStatusCode(res.StatusCode, check.ExpectedStatusCodes) {
+		return Result{
+			Breached: true,
+			Message:  fmt.Sprintf("%s answered with unexpected status code %d", check.URL, res.StatusCode),
+		}, nil
+	}
+
+	return Result{
+		Message: fmt.Sprintf("%s answered with status code %d", check.URL, res.StatusCode),
+	}, nil
+}
+
+func isExpectedStatusCode(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+
+	for _, expected := range expectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prometheusResponse is the subset of the prometheus instant query API response we rely on
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// checkPrometheus is breached when any sample returned by the query matches the comparison
+// the content of the prometheus response is never part of the returned errors as they end up in the disruption status
+func (c *checker) checkPrometheus(ctx context.Context, check v1beta1.GuardrailPrometheusCheck) (Result, error) {
+	if c.prometheusEndpoint == "" {
+		return Result{}, ErrNoPrometheusEndpoint
+	}
+
+	threshold, err := strconv.ParseFloat(check.Threshold, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid threshold %s: %w", check.Threshold, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, PrometheusQueryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.prometheusEndpoint, "/")+"/api/v1/query?"+url.Values{"query": []string{check.Query}}.Encode(), nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("error creating the prometheus query request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("error querying prometheus: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("error reading the prometheus response: %w", err)
+	}
+
+	response := prometheusResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return Result{}, fmt.Errorf("error parsing the prometheus response (status code %d)", res.StatusCode)
+	}
+
+	if response.Status != "success" {
+		return Result{}, fmt.Errorf("prometheus query failed (status code %d), the query may be invalid", res.StatusCode)
+	}
+
+	values, err := parsePrometheusValues(response.Data.ResultType, response.Data.Result)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(values) == 0 {
+		return Result{Message: "query returned no data"}, nil
+	}
+
+	for _, value := range values {
+		if check.Comparison.Compare(value, threshold) {
+			return Result{
+				Breached: true,
+				Message:  fmt.Sprintf("query returned %v which is %s %s", value, check.Comparison, check.Threshold),
+			}, nil
+		}
+	}
+
+	return Result{
+		Message: fmt.Sprintf("query returned %d value(s), none %s %s", len(values), check.Comparison, check.Threshold),
+	}, nil
+}
+
+// parsePrometheusValues extracts the sample values of scalar and vector results
+func parsePrometheusValues(resultType string, result json.RawMessage) ([]float64, error) {
+	samples := [][]interface{}{}
+
+	switch resultType {
+	case "scalar":
+		sample := []interface{}{}
+		if err := json.Unmarshal(result, &sample); err != nil {
+			return nil, errors.New("error parsing the prometheus scalar result")
+		}
+
+		samples = append(samples, sample)
+	case "vector":
+		vector := []struct {
+			Value []interface{} `json:"value"`
+		}{}
+		if err := json.Unmarshal(result, &vector); err != nil {
+			return nil, errors.New("error parsing the prometheus vector result")
+		}
+
+		for _, item := range vector {
+			samples = append(samples, item.Value)
+		}
+	default:
+		return nil, errors.New("unsupported prometheus result type, the query must return a scalar or an instant vector")
+	}
+
+	values := make([]float64, 0, len(samples))
+
+	for _, sample := range samples {
+		// a sample is a [timestamp, "value"] pair
+		if len(sample) != 2 {
+			return nil, errors.New("unexpected prometheus sample")
+		}
+
+		rawValue, ok := sample[1].(string)
+		if !ok {
+			return nil, errors.New("unexpected prometheus sample value")
+		}
+
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			return nil, errors.New("error parsing the prometheus sample value")
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// checkTargetReadiness is breached when the percentage of ready targets goes below the minimum
+func (c *checker) checkTargetReadiness(ctx context.Context, disruption *v1beta1.Disruption, check v1beta1.GuardrailTargetReadinessCheck) (Result, error) {
+	targets := disruption.Status.TargetInjections.GetTargetNames()
+	if len(targets) == 0 {
+		return Result{Message: "no targets"}, nil
+	}
+
+	ready := 0
+
+	for _, target := range targets {
+		isReady, err := c.isTargetReady(ctx, disruption, target)
+		if err != nil {
+			return Result{}, err
+		}
+
+		if isReady {
+			ready++
+		}
+	}
+
+	readyPercentage := ready * 100 / len(targets)
+	message := fmt.Sprintf("%d/%d targets are ready (%d%%, minimum is %d%%)", ready, len(targets), readyPercentage, check.MinReadyPercentage)
+
+	return Result{
+		Breached: readyPercentage < check.MinReadyPercentage,
+		Message:  message,
+	}, nil
+}
+
+// isTargetReady returns true if the target pod or node has its ready condition set to true
+// a target which doesn't exist anymore is considered as not ready
+func (c *checker) isTargetReady(ctx context.Context, disruption *v1beta1.Disruption, target string) (bool, error) {
+	if disruption.Spec.Level == chaostypes.DisruptionLevelNode {
+		node := corev1.Node{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: target}, &node); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				return cond.Status == corev1.ConditionTrue, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	pod := corev1.Pod{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: disruption.Namespace, Name: target}, &pod); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,330 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package guardrails_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/guardrails"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		disruption *v1beta1.Disruption
+		k8sClient  client.Client
+		server       *httptest.Server
+		handler      http.HandlerFunc
+		allowedHosts []string
+		checker      guardrails.Checker
+	)
+
+	BeforeEach(func() {
+		disruption = &v1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "disruption",
+				Namespace: "namespace",
+			},
+			Spec: v1beta1.DisruptionSpec{
+				Level: chaostypes.DisruptionLevelPod,
+			},
+		}
+		k8sClient = fake.NewClientBuilder().Build()
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r)
+		}))
+		allowedHosts = []string{"127.0.0.1"}
+	})
+
+	JustBeforeEach(func() {
+		checker = guardrails.NewChecker(k8sClient, server.Client(), server.URL, allowedHosts)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("HTTP checks", func() {
+		var check v1beta1.GuardrailCheck
+
+		BeforeEach(func() {
+			check = v1beta1.GuardrailCheck{
+				Name: "probe",
+				HTTP: &v1beta1.GuardrailHTTPCheck{},
+			}
+		})
+
+		JustBeforeEach(func() {
+			check.HTTP.URL = server.URL + "/health"
+		})
+
+		It("should not be breached on a 2xx status code", func() {
+			result, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Breached).To(BeFalse())
+		})
+
+		When("the probe answers with an error status code", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			})
+
+			It("should be breached", func() {
+				result, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Breached).To(BeTrue())
+				Expect(result.Message).To(ContainSubstring("503"))
+			})
+
+			It("should not be breached if the status code is expected", func() {
+				check.HTTP.ExpectedStatusCodes = []int{http.StatusServiceUnavailable}
+
+				result, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Breached).To(BeFalse())
+			})
+		})
+
+		When("the probe is unreachable", func() {
+			It("should be breached", func() {
+				server.Close()
+
+				result, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Breached).To(BeTrue())
+			})
+		})
+
+		When("the probe host is not allowed", func() {
+			BeforeEach(func() {
+				allowedHosts = []string{"demo.chaos-demo.svc.cluster.local"}
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+
+					Fail("the probe should not be queried")
+				}
+			})
+
+			It("should return an error without querying the probe", func() {
+				_, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).To(MatchError(ContainSubstring("url host 127.0.0.1 is not allowed")))
+			})
+		})
+
+		When("the probe redirects to a host which is not allowed", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+				}
+			})
+
+			It("should return an error without following the redirect", func() {
+				_, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).To(MatchError(guardrails.ErrRedirectNotAllowed))
+			})
+		})
+	})
+
+	Describe("Prometheus checks", func() {
+		var (
+			check    v1beta1.GuardrailCheck
+			response string
+		)
+
+		BeforeEach(func() {
+			check = v1beta1.GuardrailCheck{
+				Name: "error-rate",
+				Prometheus: &v1beta1.GuardrailPrometheusCheck{
+					Query:      "sum(rate(errors[1m]))",
+					Comparison: v1beta1.GuardrailComparisonGreaterThan,
+					Threshold:  "0.5",
+				},
+			}
+			response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"service":"a"},"value":[1690000000,"0.1"]},{"metric":{"service":"b"},"value":[1690000000,"0.2"]}]}}`
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.URL.Path).To(Equal("/api/v1/query"))
+				Expect(r.URL.Query().Get("query")).To(Equal("sum(rate(errors[1m]))"))
+
+				_, _ = w.Write([]byte(response))
+			}
+		})
+
+		It("should not be breached when no sample matches the comparison", func() {
+			result, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Breached).To(BeFalse())
+		})
+
+		It("should be breached when a sample matches the comparison", func() {
+			response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"service":"a"},"value":[1690000000,"0.1"]},{"metric":{"service":"b"},"value":[1690000000,"0.7"]}]}}`
+
+			result, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Breached).To(BeTrue())
+		})
+
+		It("should support scalar results", func() {
+			response = `{"status":"success","data":{"resultType":"scalar","result":[1690000000,"1"]}}`
+
+			result, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Breached).To(BeTrue())
+		})
+
+		It("should not be breached when the query returns no data", func() {
+			response = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+
+			result, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Breached).To(BeFalse())
+		})
+
+		It("should return an error without the prometheus error when the query fails", func() {
+			response = `{"status":"error","errorType":"bad_data","error":"parse error"}`
+
+			_, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).ToNot(ContainSubstring("parse error"))
+		})
+
+		It("should return an error without the response body when it is not a prometheus response", func() {
+			response = `<html>internal page</html>`
+
+			_, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).ToNot(ContainSubstring("internal page"))
+		})
+
+		It("should return an error when the query times out", func() {
+			defaultTimeout := guardrails.PrometheusQueryTimeout
+			guardrails.PrometheusQueryTimeout = 100 * time.Millisecond
+			DeferCleanup(func() {
+				guardrails.PrometheusQueryTimeout = defaultTimeout
+			})
+
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			}
+
+			_, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+
+		It("should return an error when no endpoint is configured", func() {
+			checker = guardrails.NewChecker(k8sClient, server.Client(), "", allowedHosts)
+
+			_, err := checker.Check(context.Background(), disruption, check)
+
+			Expect(err).To(MatchError(guardrails.ErrNoPrometheusEndpoint))
+		})
+	})
+
+	Describe("Target readiness checks", func() {
+		var check v1beta1.GuardrailCheck
+
+		newPod := func(name string, ready corev1.ConditionStatus) client.Object {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "namespace",
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: ready,
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			check = v1beta1.GuardrailCheck{
+				Name: "readiness",
+				TargetReadiness: &v1beta1.GuardrailTargetReadinessCheck{
+					MinReadyPercentage: 50,
+				},
+			}
+			disruption.Status.TargetInjections = v1beta1.TargetInjections{
+				"pod-1": {},
+				"pod-2": {},
+				"pod-3": {},
+				"pod-4": {},
+			}
+		})
+
+		When("enough targets are ready", func() {
+			BeforeEach(func() {
+				k8sClient = fake.NewClientBuilder().WithObjects(
+					newPod("pod-1", corev1.ConditionTrue),
+					newPod("pod-2", corev1.ConditionTrue),
+					newPod("pod-3", corev1.ConditionFalse),
+					newPod("pod-4", corev1.ConditionFalse),
+				).Build()
+			})
+
+			It("should not be breached", func() {
+				result, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Breached).To(BeFalse())
+			})
+		})
+
+		When("not enough targets are ready", func() {
+			BeforeEach(func() {
+				// pod-4 does not exist anymore and is considered as not ready
+				k8sClient = fake.NewClientBuilder().WithObjects(
+					newPod("pod-1", corev1.ConditionTrue),
+					newPod("pod-2", corev1.ConditionFalse),
+					newPod("pod-3", corev1.ConditionFalse),
+				).Build()
+			})
+
+			It("should be breached", func() {
+				result, err := checker.Check(context.Background(), disruption, check)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Breached).To(BeTrue())
+				Expect(result.Message).To(ContainSubstring("1/4"))
+			})
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"math"
 	"net"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,10 +19,14 @@ import (
 	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/env"
 	"github.com/DataDog/chaos-controller/network"
+	"github.com/DataDog/chaos-controller/network/cidr"
 	"github.com/DataDog/chaos-controller/types"
+	"github.com/DataDog/chaos-controller/utils"
+	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -50,7 +55,9 @@ type networkDisruptionInjector struct {
 	config               NetworkDisruptionInjectorConfig
 	operations           []linkOperation
 	serviceWatcherCancel context.CancelFunc
+	podsWatcherCancel    context.CancelFunc
 	hostWatcherCancel    context.CancelFunc
+	sysctlsToRestore     map[string]string
 }
 
 // NetworkDisruptionInjectorConfig contains all needed drivers to create a network disruption using `tc`
@@ -60,6 +67,7 @@ type NetworkDisruptionInjectorConfig struct {
 	IPTables            network.IPTables
 	NetlinkAdapter      network.NetlinkAdapter
 	DNSClient           network.DNSClient
+	Sysctl              network.Sysctl
 	HostResolveInterval time.Duration
 }
 
@@ -86,6 +94,25 @@ func (t tcFilter) String() string {
 
 type tcFilters []tcFilter
 
+// lpmFilterEntry describes an ip range filtered by the eBPF LPM classifier and the hosts it comes from
+type lpmFilterEntry struct {
+	flow      string
+	ipNet     *net.IPNet
+	port      int
+	protocols []string
+	hosts     []v1beta1.NetworkDisruptionHostSpec
+}
+
+// String returns the entry as expected by the eBPF LPM classifier configuration program, e.g. 10.0.0.0/8;443;tcp,udp
+func (e lpmFilterEntry) String() string {
+	return fmt.Sprintf("%s;%d;%s", e.ipNet.String(), e.port, strings.Join(e.protocols, ","))
+}
+
+// sameMatch returns true if both entries match the same ports and protocols
+func (e lpmFilterEntry) sameMatch(other lpmFilterEntry) bool {
+	return e.port == other.port && strings.Join(e.protocols, ",") == strings.Join(other.protocols, ",")
+}
+
 func (t tcFilters) String() string {
 	filterStrings := []string{}
 	for _, filter := range t {
@@ -95,18 +122,29 @@ func (t tcFilters) String() string {
 	return strings.Join(filterStrings, ";")
 }
 
-// serviceWatcher
-type serviceWatcher struct {
-	// information about the service watched
-	watchedServiceSpec   v1beta1.NetworkDisruptionServiceSpec
-	servicePorts         []v1.ServicePort
-	labelServiceSelector string
+// podEndpointsWatcher keeps track of the tc filters created for a set of pods matching a label selector
+type podEndpointsWatcher struct {
+	// information about the pods watched
+	destination   string // human readable name of the watched destination, used in logs
+	namespace     string
+	ports         []v1.ServicePort
+	labelSelector string
 
-	// filters and watcher for the pods related to the service watched
+	// filters and watcher for the pods watched
 	kubernetesPodEndpointsWatcher <-chan watch.Event
 	tcFiltersFromPodEndpoints     []tcServiceFilter
 	podsWithoutIPs                []string
 	podsResourceVersion           string
+}
+
+// serviceWatcher
+type serviceWatcher struct {
+	// information about the service watched
+	watchedServiceSpec v1beta1.NetworkDisruptionServiceSpec
+
+	// filters and watcher for the pods related to the service watched
+	// ports are the service ports and the label selector is the service selector
+	podEndpointsWatcher
 
 	// filters and watcher for the kubernetes service watched
 	kubernetesServiceWatcher       <-chan watch.Event
@@ -143,10 +181,15 @@ func NewNetworkDisruptionInjector(spec v1beta1.NetworkDisruptionSpec, config Net
 		config.DNSClient = network.NewDNSClient()
 	}
 
+	if config.Sysctl == nil {
+		config.Sysctl = network.NewSysctl(config.Log, config.Disruption.DryRun)
+	}
+
 	return &networkDisruptionInjector{
-		spec:       spec,
-		config:     config,
-		operations: []linkOperation{},
+		spec:             spec,
+		config:           config,
+		operations:       []linkOperation{},
+		sysctlsToRestore: map[string]string{},
 	}, nil
 }
 
@@ -203,6 +246,13 @@ func (i *networkDisruptionInjector) Inject() error {
 		return fmt.Errorf("error injecting the conntrack reference iptables rule: %w", err)
 	}
 
+	// add tcp faults
+	if i.spec.TCPFaults != nil {
+		if err := i.applyTCPFaults(); err != nil {
+			return fmt.Errorf("error applying tcp faults: %w", err)
+		}
+	}
+
 	// mark all packets created by the targeted container with the classifying mark
 	if i.config.Disruption.Level == types.DisruptionLevelPod && !i.config.Disruption.OnInit {
 		if i.config.Cgroup.IsCgroupV2() { // cgroup v2 can rely on the single cgroup hierarchy relative path to mark packets
@@ -240,6 +290,11 @@ func (i *networkDisruptionInjector) Clean() error {
 		i.serviceWatcherCancel = nil
 	}
 
+	if i.podsWatcherCancel != nil {
+		i.podsWatcherCancel()
+		i.podsWatcherCancel = nil
+	}
+
 	if i.hostWatcherCancel != nil {
 		i.hostWatcherCancel()
 		i.hostWatcherCancel = nil
@@ -522,7 +577,7 @@ func (i *networkDisruptionInjector) applyOperations() error {
 
 	// create tc filters depending on the given hosts to match
 	// redirect all packets of all interfaces if no host is given
-	if len(i.spec.Hosts) == 0 && len(i.spec.Services) == 0 {
+	if len(i.spec.Hosts) == 0 && len(i.spec.Services) == 0 && len(i.spec.Pods) == 0 {
 		_, nullIP, _ := net.ParseCIDR("0.0.0.0/0")
 
 		for _, protocol := range network.AllProtocols(network.ALL) {
@@ -540,6 +595,11 @@ func (i *networkDisruptionInjector) applyOperations() error {
 		if err := i.handleFiltersForServices(interfaces, "1:4"); err != nil {
 			return fmt.Errorf("error adding filters for given services: %w", err)
 		}
+
+		// add or delete filters for given pods depending on changes on the pods matching the given selectors
+		if err := i.handleFiltersForPods(interfaces, "1:4"); err != nil {
+			return fmt.Errorf("error adding filters for given pods: %w", err)
+		}
 	}
 
 	return nil
@@ -746,17 +806,17 @@ func (i *networkDisruptionInjector) handleKubernetesServiceChanges(event watch.E
 
 	if isHeadless(*service) {
 		// If this is a headless service, we want to block all traffic to the endpoint IPs
-		watcher.servicePorts = append(watcher.servicePorts, v1.ServicePort{Port: 0})
+		watcher.ports = append(watcher.ports, v1.ServicePort{Port: 0})
 	} else {
-		watcher.servicePorts, _ = watcher.watchedServiceSpec.ExtractAffectedPortsInServicePorts(service)
+		watcher.ports, _ = watcher.watchedServiceSpec.ExtractAffectedPortsInServicePorts(service)
 	}
 
-	watcher.tcFiltersFromPodEndpoints, err = i.handlePodEndpointsServiceFiltersOnKubernetesServiceChanges(watcher.watchedServiceSpec, watcher.tcFiltersFromPodEndpoints, podList.Items, watcher.servicePorts, interfaces, flowid)
+	watcher.tcFiltersFromPodEndpoints, err = i.handlePodEndpointsServiceFiltersOnKubernetesServiceChanges(watcher.watchedServiceSpec, watcher.tcFiltersFromPodEndpoints, podList.Items, watcher.ports, interfaces, flowid)
 	if err != nil {
 		return err
 	}
 
-	nsServicesTcFilters := i.buildServiceFiltersFromService(*service, watcher.servicePorts)
+	nsServicesTcFilters := i.buildServiceFiltersFromService(*service, watcher.ports)
 
 	switch event.Type {
 	case watch.Added:
@@ -789,8 +849,8 @@ func (i *networkDisruptionInjector) handleKubernetesServiceChanges(event watch.E
 	return nil
 }
 
-// handleKubernetesPodsChanges for every changes happening in the pods related to the kubernetes service destination, we update the tc service filters
-func (i *networkDisruptionInjector) handleKubernetesPodsChanges(event watch.Event, watcher *serviceWatcher, interfaces []string, flowid string) error {
+// handleKubernetesPodsChanges for every changes happening in the pods related to the kubernetes service or pods destination, we update the tc service filters
+func (i *networkDisruptionInjector) handleKubernetesPodsChanges(event watch.Event, watcher *podEndpointsWatcher, interfaces []string, flowid string) error {
 	var err error
 
 	if event.Type == watch.Error {
@@ -814,9 +874,9 @@ func (i *networkDisruptionInjector) handleKubernetesPodsChanges(event watch.Even
 		return fmt.Errorf("unable to enter the given container network namespace: %w", err)
 	}
 
-	tcFiltersFromPod := i.buildServiceFiltersFromPod(*pod, watcher.servicePorts)
+	tcFiltersFromPod := i.buildServiceFiltersFromPod(*pod, watcher.ports)
 	if len(tcFiltersFromPod) == 0 {
-		return fmt.Errorf("unable to find service %s/%s endpoints to filter", watcher.watchedServiceSpec.Name, watcher.watchedServiceSpec.Namespace)
+		return fmt.Errorf("unable to find %s endpoints to filter", watcher.destination)
 	}
 
 	switch event.Type {
@@ -827,7 +887,7 @@ func (i *networkDisruptionInjector) handleKubernetesPodsChanges(event watch.Even
 		}
 
 		if pod.Status.PodIP != "" {
-			createdTcFilters, err := i.addServiceFilters(watcher.watchedServiceSpec.Name, tcFiltersFromPod, interfaces, flowid)
+			createdTcFilters, err := i.addServiceFilters(watcher.destination, tcFiltersFromPod, interfaces, flowid)
 			if err != nil {
 				return err
 			}
@@ -851,7 +911,7 @@ func (i *networkDisruptionInjector) handleKubernetesPodsChanges(event watch.Even
 		}
 
 		if podToCreateIdx > -1 {
-			tcFilters, err := i.addServiceFilters(watcher.watchedServiceSpec.Name, tcFiltersFromPod, interfaces, flowid)
+			tcFilters, err := i.addServiceFilters(watcher.destination, tcFiltersFromPod, interfaces, flowid)
 			if err != nil {
 				return err
 			}
@@ -898,22 +958,11 @@ func (i *networkDisruptionInjector) watchServiceChanges(ctx context.Context, wat
 		}
 
 		if watcher.kubernetesPodEndpointsWatcher == nil {
-			log := log.With("watcher", "kubernetesPodEndpointsWatcher")
-
-			podsWatcher, err := i.config.K8sClient.CoreV1().Pods(watcher.watchedServiceSpec.Namespace).Watch(context.Background(), metav1.ListOptions{
-				LabelSelector:       watcher.labelServiceSelector,
-				ResourceVersion:     watcher.podsResourceVersion,
-				AllowWatchBookmarks: true,
-			})
-			if err != nil {
-				log.Errorw("error watching the list of pods for the given kubernetes service", "error", err)
+			if err := i.startPodEndpointsWatch(&watcher.podEndpointsWatcher); err != nil {
+				log.Errorw("error watching the list of pods for the given kubernetes service", "error", err, "watcher", "kubernetesPodEndpointsWatcher")
 
 				return
 			}
-
-			log.Infow("starting kubernetes pods watch")
-
-			watcher.kubernetesPodEndpointsWatcher = podsWatcher.ResultChan()
 		}
 
 		select {
@@ -938,24 +987,72 @@ func (i *networkDisruptionInjector) watchServiceChanges(ctx context.Context, wat
 				}
 			}
 		case event, ok := <-watcher.kubernetesPodEndpointsWatcher: // We have changes in the pods watched
-			if !ok { // channel is closed
-				watcher.kubernetesPodEndpointsWatcher = nil
-			} else {
-				log := log.With("watcher", "kubernetesPodEndpointsWatcher")
-				log.Debugw(fmt.Sprintf("changes in pods of service %s/%s", watcher.watchedServiceSpec.Name, watcher.watchedServiceSpec.Namespace), "eventType", event.Type)
+			i.onPodEndpointsEvent(log, event, ok, &watcher.podEndpointsWatcher, interfaces, flowid)
+		}
+	}
+}
 
-				if err := i.handleKubernetesPodsChanges(event, &watcher, interfaces, flowid); err != nil {
-					log.Errorw("couldn't apply changes to tc filters: Rebuilding watcher", "error", err)
+// startPodEndpointsWatch creates the watcher channel of the pods matching the label selector of the given watcher
+func (i *networkDisruptionInjector) startPodEndpointsWatch(watcher *podEndpointsWatcher) error {
+	podsWatcher, err := i.config.K8sClient.CoreV1().Pods(watcher.namespace).Watch(context.Background(), metav1.ListOptions{
+		LabelSelector:       watcher.labelSelector,
+		ResourceVersion:     watcher.podsResourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		return err
+	}
 
-					if _, err = i.removeServiceFiltersInList(interfaces, watcher.tcFiltersFromPodEndpoints, watcher.tcFiltersFromPodEndpoints); err != nil {
-						log.Errorw("couldn't clean list of tc filters", "error", err)
-					}
+	i.config.Log.Infow("starting kubernetes pods watch", "destination", watcher.destination)
 
-					watcher.kubernetesPodEndpointsWatcher = nil // restart the watcher in case of error
-					watcher.tcFiltersFromPodEndpoints = []tcServiceFilter{}
-				}
+	watcher.kubernetesPodEndpointsWatcher = podsWatcher.ResultChan()
+
+	return nil
+}
+
+// onPodEndpointsEvent applies the given pods event to the tc filters of the given watcher, rebuilding the watcher on closed channel or error
+func (i *networkDisruptionInjector) onPodEndpointsEvent(log *zap.SugaredLogger, event watch.Event, ok bool, watcher *podEndpointsWatcher, interfaces []string, flowid string) {
+	if !ok { // channel is closed
+		watcher.kubernetesPodEndpointsWatcher = nil
+
+		return
+	}
+
+	log = log.With("watcher", "kubernetesPodEndpointsWatcher")
+	log.Debugw(fmt.Sprintf("changes in pods of %s", watcher.destination), "eventType", event.Type)
+
+	if err := i.handleKubernetesPodsChanges(event, watcher, interfaces, flowid); err != nil {
+		log.Errorw("couldn't apply changes to tc filters: Rebuilding watcher", "error", err)
+
+		if _, err = i.removeServiceFiltersInList(interfaces, watcher.tcFiltersFromPodEndpoints, watcher.tcFiltersFromPodEndpoints); err != nil {
+			log.Errorw("couldn't clean list of tc filters", "error", err)
+		}
+
+		watcher.kubernetesPodEndpointsWatcher = nil // restart the watcher in case of error
+		watcher.tcFiltersFromPodEndpoints = []tcServiceFilter{}
+	}
+}
+
+// watchPodsChanges for every changes happening in the pods matching a pods destination, we update the tc filters
+func (i *networkDisruptionInjector) watchPodsChanges(ctx context.Context, watcher podEndpointsWatcher, interfaces []string, flowid string) {
+	log := i.config.Log.With("podsNamespace", watcher.namespace, "podsSelector", watcher.labelSelector)
+
+	for {
+		// We create the watcher channel when it's closed
+		if watcher.kubernetesPodEndpointsWatcher == nil {
+			if err := i.startPodEndpointsWatch(&watcher); err != nil {
+				log.Errorw("error watching the list of pods for the given selector", "error", err, "watcher", "kubernetesPodEndpointsWatcher")
+
+				return
 			}
 		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.kubernetesPodEndpointsWatcher: // We have changes in the pods watched
+			i.onPodEndpointsEvent(log, event, ok, &watcher, interfaces, flowid)
+		}
 	}
 }
 
@@ -974,14 +1071,18 @@ func (i *networkDisruptionInjector) handleFiltersForServices(interfaces []string
 		servicePorts, _ := serviceSpec.ExtractAffectedPortsInServicePorts(k8sService)
 
 		serviceWatcher := serviceWatcher{
-			watchedServiceSpec:   serviceSpec,
-			servicePorts:         servicePorts,
-			labelServiceSelector: labels.SelectorFromValidatedSet(k8sService.Spec.Selector).String(), // keep this information to later create watchers on resources destination
-
-			kubernetesPodEndpointsWatcher: nil,                 // watch pods related to the kubernetes service filtered on
-			tcFiltersFromPodEndpoints:     []tcServiceFilter{}, // list of tc filters targeting pods related to the kubernetes service filtered on
-			podsWithoutIPs:                []string{},          // some pods are created without IPs. We keep track of them to later create a tc filter on update
-			podsResourceVersion:           "",
+			watchedServiceSpec: serviceSpec,
+			podEndpointsWatcher: podEndpointsWatcher{
+				destination:   fmt.Sprintf("service %s/%s", serviceSpec.Namespace, serviceSpec.Name),
+				namespace:     serviceSpec.Namespace,
+				ports:         servicePorts,
+				labelSelector: labels.SelectorFromValidatedSet(k8sService.Spec.Selector).String(), // keep this information to later create watchers on resources destination
+
+				kubernetesPodEndpointsWatcher: nil,                 // watch pods related to the kubernetes service filtered on
+				tcFiltersFromPodEndpoints:     []tcServiceFilter{}, // list of tc filters targeting pods related to the kubernetes service filtered on
+				podsWithoutIPs:                []string{},          // some pods are created without IPs. We keep track of them to later create a tc filter on update
+				podsResourceVersion:           "",
+			},
 
 			kubernetesServiceWatcher:       nil,                 // watch service filtered on
 			tcFiltersFromNamespaceServices: []tcServiceFilter{}, // list of tc filters targeting the service filtered on
@@ -1006,11 +1107,69 @@ func (i *networkDisruptionInjector) handleFiltersForServices(interfaces []string
 	return nil
 }
 
+// handleFiltersForPods creates tc filters on given interfaces for pods destinations in disruption spec classifying matching packets in the given flowid
+func (i *networkDisruptionInjector) handleFiltersForPods(interfaces []string, flowid string) error {
+	// build the watchers to handle changes in pods matching the given selectors
+	podsWatchers := []podEndpointsWatcher{}
+
+	for _, podSpec := range i.spec.Pods {
+		selector, err := labels.ValidatedSelectorFromSet(podSpec.Selector)
+		if err != nil {
+			return fmt.Errorf("error parsing the given pods selector (%s/%s): %w", podSpec.Namespace, podSpec.Selector, err)
+		}
+
+		// an empty list of ports means that all ports of the matching pods are disrupted
+		ports := []v1.ServicePort{}
+		for _, port := range podSpec.Ports {
+			ports = append(ports, v1.ServicePort{
+				TargetPort: intstr.FromInt(port.Port),
+				Protocol:   v1.Protocol(port.Protocol),
+			})
+		}
+
+		if len(ports) == 0 {
+			ports = append(ports, v1.ServicePort{Port: 0})
+		}
+
+		podsWatchers = append(podsWatchers, podEndpointsWatcher{
+			destination:   fmt.Sprintf("pods %s/%s", podSpec.Namespace, selector.String()),
+			namespace:     podSpec.Namespace,
+			ports:         ports,
+			labelSelector: selector.String(),
+
+			kubernetesPodEndpointsWatcher: nil,                 // watch pods matching the selector
+			tcFiltersFromPodEndpoints:     []tcServiceFilter{}, // list of tc filters targeting pods matching the selector
+			podsWithoutIPs:                []string{},          // some pods are created without IPs. We keep track of them to later create a tc filter on update
+			podsResourceVersion:           "",
+		})
+	}
+
+	if i.podsWatcherCancel != nil {
+		return fmt.Errorf("some pods watcher goroutines are already launched, call Clean on injector prior to Inject")
+	}
+
+	var ctx context.Context
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	i.podsWatcherCancel = cancelFunc
+
+	for _, podsWatcher := range podsWatchers {
+		go i.watchPodsChanges(ctx, podsWatcher, interfaces, flowid)
+	}
+
+	return nil
+}
+
 // handleFiltersForServices creates tc filters on given interfaces for hosts in disruption spec classifying matching packets in the given flowid
 func (i *networkDisruptionInjector) handleFiltersForHosts(interfaces []string, flowid string) error {
 	hosts := hostsWatcher{}
 
-	hostFilterMap, err := i.addFiltersForHosts(interfaces, i.spec.Hosts, flowid)
+	// large sets of ip ranges are filtered by the eBPF LPM classifier, remaining hosts are filtered by tc filters
+	remainingHosts, err := i.addLPMFilterForHosts(interfaces, i.spec.Hosts, flowid)
+	if err != nil {
+		return err
+	}
+
+	hostFilterMap, err := i.addFiltersForHosts(interfaces, remainingHosts, flowid)
 	if err != nil {
 		return err
 	}
@@ -1052,6 +1211,9 @@ func (i *networkDisruptionInjector) watchHostChanges(ctx context.Context, interf
 					continue
 				}
 
+				// filters are created for collapsed IPs, see addFiltersForHosts
+				newIps = cidr.Collapse(newIps)
+
 				oldIps := []*net.IPNet{}
 
 				for _, currentTcFilter := range currentTcFilters {
@@ -1132,6 +1294,9 @@ func (i *networkDisruptionInjector) addFiltersForHosts(interfaces []string, host
 
 		i.config.Log.Infof("resolved %s as %s", host.Host, ips)
 
+		// collapse adjacent resolved IPs to create as few filters as possible
+		ips = cidr.Collapse(ips)
+
 		filtersForHost := tcFilters{}
 
 		for _, ip := range ips {
@@ -1173,6 +1338,155 @@ func (i *networkDisruptionInjector) addFiltersForHosts(interfaces []string, host
 	return hostFilterMap, nil
 }
 
+// addLPMFilterForHosts filters the given hosts being ip ranges with the eBPF LPM classifier if there are more of them than the LPM filter threshold,
+// tc filters being evaluated one after the other, they can't scale to thousands of ip ranges
+// it returns the hosts which must still be filtered by tc filters
+func (i *networkDisruptionInjector) addLPMFilterForHosts(interfaces []string, hosts []v1beta1.NetworkDisruptionHostSpec, flowid string) ([]v1beta1.NetworkDisruptionHostSpec, error) {
+	remainingHosts := []v1beta1.NetworkDisruptionHostSpec{}
+	entries := []lpmFilterEntry{}
+	entriesIndex := map[string]int{} // entry index per flow and ip range
+
+	for _, host := range hosts {
+		if !host.IsLPMFilterable() {
+			remainingHosts = append(remainingHosts, host)
+
+			continue
+		}
+
+		ipNet, err := cidr.Parse(host.Host)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing host %s: %w", host.Host, err)
+		}
+
+		entry := lpmFilterEntry{
+			flow:  host.Flow,
+			ipNet: ipNet,
+			port:  host.Port,
+			hosts: []v1beta1.NetworkDisruptionHostSpec{host},
+		}
+
+		if entry.flow == "" {
+			entry.flow = v1beta1.FlowEgress
+		}
+
+		for _, protocol := range network.AllProtocols(host.Protocol) {
+			entry.protocols = append(entry.protocols, protocol.String())
+		}
+
+		key := entry.flow + ";" + ipNet.String()
+
+		index, ok := entriesIndex[key]
+		if !ok {
+			entriesIndex[key] = len(entries)
+			entries = append(entries, entry)
+
+			continue
+		}
+
+		// an ip range can only be matched on a single port by the classifier, the same ip range on another port is filtered by tc filters
+		if entries[index].port != entry.port {
+			remainingHosts = append(remainingHosts, host)
+
+			continue
+		}
+
+		// merge the protocols of the same ip range and port
+		for _, protocol := range entry.protocols {
+			if !utils.Contains(entries[index].protocols, protocol) {
+				entries[index].protocols = append(entries[index].protocols, protocol)
+			}
+		}
+
+		sort.Strings(entries[index].protocols)
+		entries[index].hosts = append(entries[index].hosts, host)
+	}
+
+	if len(entries) <= v1beta1.LPMFilterThreshold {
+		return hosts, nil
+	}
+
+	// the classifier only returns the value of the longest matching prefix, so an ip range contained in another one
+	// must match the same ports and protocols to be filtered by the classifier:
+	// - contained ip ranges matching the same ports and protocols are redundant and removed
+	// - contained ip ranges matching other ports or protocols are filtered by tc filters
+	// larger ip ranges are processed first so every kept entry is disjoint from the others
+	sort.SliceStable(entries, func(a, b int) bool {
+		aOnes, _ := entries[a].ipNet.Mask.Size()
+		bOnes, _ := entries[b].ipNet.Mask.Size()
+
+		return aOnes < bOnes
+	})
+
+	keptEntries := []lpmFilterEntry{}
+	keptIndex := map[string]lpmFilterEntry{}
+
+	for _, entry := range entries {
+		container, found := findContainingLPMFilterEntry(keptIndex, entry)
+
+		switch {
+		case !found:
+			keptEntries = append(keptEntries, entry)
+			keptIndex[entry.flow+";"+entry.ipNet.String()] = entry
+		case !container.sameMatch(entry):
+			remainingHosts = append(remainingHosts, entry.hosts...)
+		}
+	}
+
+	if len(keptEntries) > v1beta1.MaximumLPMFilterEntries {
+		return nil, fmt.Errorf("the number of ip ranges to filter (%d) exceeds the maximum number of ip ranges filterable (%d)", len(keptEntries), v1beta1.MaximumLPMFilterEntries)
+	}
+
+	// the classifier gets a priority after the previously added filters so the safeguard filters are still evaluated first
+	priority, err := i.config.TrafficController.AddPrioritizedBPFFilter(interfaces, "1:0", "/usr/local/bin/bpf-network-lpm-filter.bpf.o", flowid)
+	if err != nil {
+		return nil, fmt.Errorf("can't create the eBPF LPM filter: %w", err)
+	}
+
+	// the classifier is loaded once per interface, each instance having its own maps,
+	// so the configuration program resolves the maps to update from the filter attached to each interface
+	args := []string{"-p", strconv.FormatUint(uint64(priority), 10)}
+
+	for _, iface := range interfaces {
+		args = append(args, "-d", iface)
+	}
+
+	for _, entry := range keptEntries {
+		if entry.flow == v1beta1.FlowIngress {
+			args = append(args, "-i", entry.String())
+		} else {
+			args = append(args, "-e", entry.String())
+		}
+	}
+
+	// run the program responsible to configure the maps of the eBPF LPM filter
+	bpfConfigExecutor := network.NewBPFLPMFilterConfigExecutor(i.config.Log, i.config.Disruption.DryRun)
+	if err := i.config.TrafficController.ConfigBPFFilter(bpfConfigExecutor, args...); err != nil {
+		return nil, fmt.Errorf("could not update the configuration of the bpf-network-lpm-filter filter: %w", err)
+	}
+
+	i.config.Log.Infow("ip ranges filtered by the eBPF LPM classifier", "ipRanges", len(keptEntries), "remainingHosts", len(remainingHosts))
+
+	return remainingHosts, nil
+}
+
+// findContainingLPMFilterEntry returns the entry of the given flow containing the given entry ip range, if any
+func findContainingLPMFilterEntry(entries map[string]lpmFilterEntry, entry lpmFilterEntry) (lpmFilterEntry, bool) {
+	ones, bits := entry.ipNet.Mask.Size()
+
+	for prefix := ones; prefix >= 0; prefix-- {
+		parent := net.IPNet{
+			IP:   entry.ipNet.IP.Mask(net.CIDRMask(prefix, bits)),
+			Mask: net.CIDRMask(prefix, bits),
+		}
+
+		if container, ok := entries[entry.flow+";"+parent.String()]; ok {
+			return container, true
+		}
+	}
+
+	return lpmFilterEntry{}, false
+}
+
 // AddNetem adds network disruptions using the drivers in the networkDisruptionInjector
 func (i *networkDisruptionInjector) addNetemOperation(delay, delayJitter time.Duration, drop int, corrupt int, duplicate int) {
 	// closure which adds netem disruptions
@@ -1217,9 +1531,246 @@ func (i *networkDisruptionInjector) clearOperations() error {
 	// clear operations to avoid them to stack up
 	i.operations = []linkOperation{}
 
+	// restore kernel parameters modified by tcp faults
+	// iptables rules created by tcp faults are removed with all other injected rules
+	for name, value := range i.sysctlsToRestore {
+		if err := i.config.Sysctl.Set(name, value); err != nil {
+			return fmt.Errorf("error restoring kernel parameter %s: %w", name, err)
+		}
+
+		delete(i.sysctlsToRestore, name)
+	}
+
+	return nil
+}
+
+// tcpFaultsDestination is a destination (ip and port) the tcp faults apply to, empty values matching everything
+type tcpFaultsDestination struct {
+	ip   string
+	port string
+}
+
+// applyTCPFaults injects the iptables rules and kernel parameters needed by the tcp faults
+// faults apply to outgoing tcp connections going to the given hosts, services and pods,
+// or to all outgoing tcp connections if none of them is given
+func (i *networkDisruptionInjector) applyTCPFaults() error {
+	faults := i.spec.TCPFaults
+
+	// only match packets created by the targeted container, marked in the same way as for tc filters
+	mark := ""
+	if i.config.Disruption.Level == types.DisruptionLevelPod && !i.config.Disruption.OnInit {
+		mark = types.InjectorCgroupClassID
+	}
+
+	// build the list of destinations (ip and port) to apply the faults to
+	destinations := []tcpFaultsDestination{}
+
+	if len(i.spec.Hosts) == 0 && len(i.spec.Services) == 0 && len(i.spec.Pods) == 0 {
+		destinations = append(destinations, tcpFaultsDestination{})
+	}
+
+	for _, host := range i.spec.Hosts {
+		// tcp faults only apply to outgoing tcp connections
+		if host.Flow == v1beta1.FlowIngress || strings.ToLower(host.Protocol) == string(network.UDP) {
+			continue
+		}
+
+		port := ""
+		if host.Port != 0 {
+			port = strconv.Itoa(host.Port)
+		}
+
+		ips := []*net.IPNet{nil}
+
+		if host.Host != "" {
+			var err error
+
+			ips, err = resolveHost(i.config.DNSClient, host.Host)
+			if err != nil {
+				return fmt.Errorf("error resolving given host %s: %w", host.Host, err)
+			}
+		}
+
+		for _, ip := range ips {
+			destination := tcpFaultsDestination{port: port}
+			if ip != nil {
+				destination.ip = ip.String()
+			}
+
+			destinations = append(destinations, destination)
+		}
+	}
+
+	servicesDestinations, err := i.tcpFaultsServicesDestinations()
+	if err != nil {
+		return err
+	}
+
+	podsDestinations, err := i.tcpFaultsPodsDestinations()
+	if err != nil {
+		return err
+	}
+
+	destinations = append(destinations, servicesDestinations...)
+	destinations = append(destinations, podsDestinations...)
+
+	i.config.Log.Infow("adding tcp faults", "reset", faults.Reset, "dropSYN", faults.DropSYN, "idleTimeout", faults.IdleTimeout, "destinations", len(destinations))
+
+	for _, destination := range destinations {
+		if faults.Reset {
+			if err := i.config.IPTables.RejectWithTCPReset("ESTABLISHED", destination.ip, destination.port, mark); err != nil {
+				return fmt.Errorf("error injecting tcp reset iptables rule: %w", err)
+			}
+		}
+
+		if faults.DropSYN {
+			if err := i.config.IPTables.DropTCPSYN(destination.ip, destination.port, mark); err != nil {
+				return fmt.Errorf("error injecting tcp syn drop iptables rule: %w", err)
+			}
+		}
+
+		// connections idle for more than the conntrack timeout are forgotten by conntrack,
+		// making their next packet invalid since conntrack is not allowed to pick them up again
+		if faults.IdleTimeout > 0 {
+			if err := i.config.IPTables.RejectWithTCPReset("INVALID", destination.ip, destination.port, mark); err != nil {
+				return fmt.Errorf("error injecting tcp idle connections reset iptables rule: %w", err)
+			}
+		}
+	}
+
+	if faults.IdleTimeout > 0 {
+		if err := i.setSysctl(network.ConntrackTCPEstablishedTimeoutSysctl, strconv.Itoa(int(faults.IdleTimeout))); err != nil {
+			return err
+		}
+
+		if err := i.setSysctl(network.ConntrackTCPLooseSysctl, "0"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// tcpFaultsServicesDestinations resolves the given services into tcp faults destinations, the same way as for tc filters
+// destinations are the service cluster IP and the IPs of the pods behind the service, as resolved at injection time
+func (i *networkDisruptionInjector) tcpFaultsServicesDestinations() ([]tcpFaultsDestination, error) {
+	destinations := []tcpFaultsDestination{}
+
+	for _, serviceSpec := range i.spec.Services {
+		k8sService, err := i.config.K8sClient.CoreV1().Services(serviceSpec.Namespace).Get(context.Background(), serviceSpec.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting the given kubernetes service (%s/%s): %w", serviceSpec.Namespace, serviceSpec.Name, err)
+		}
+
+		servicePorts, _ := serviceSpec.ExtractAffectedPortsInServicePorts(k8sService)
+		destinations = append(destinations, tcpFaultsDestinationsFromFilters(i.buildServiceFiltersFromService(*k8sService, servicePorts))...)
+
+		podList, err := i.config.K8sClient.CoreV1().Pods(serviceSpec.Namespace).List(context.Background(), metav1.ListOptions{
+			LabelSelector: labels.SelectorFromValidatedSet(k8sService.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing pods of the given kubernetes service (%s/%s): %w", serviceSpec.Namespace, serviceSpec.Name, err)
+		}
+
+		for _, pod := range podList.Items {
+			if pod.Status.PodIP == "" {
+				continue
+			}
+
+			destinations = append(destinations, tcpFaultsDestinationsFromFilters(i.buildServiceFiltersFromPod(pod, servicePorts))...)
+		}
+	}
+
+	return destinations, nil
+}
+
+// tcpFaultsPodsDestinations resolves the given pods selectors into tcp faults destinations, as resolved at injection time
+func (i *networkDisruptionInjector) tcpFaultsPodsDestinations() ([]tcpFaultsDestination, error) {
+	destinations := []tcpFaultsDestination{}
+
+	for _, podSpec := range i.spec.Pods {
+		selector, err := labels.ValidatedSelectorFromSet(podSpec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the given pods selector (%s/%s): %w", podSpec.Namespace, podSpec.Selector, err)
+		}
+
+		// an empty list of ports means that all ports of the matching pods are disrupted
+		ports := []v1.ServicePort{}
+		for _, port := range podSpec.Ports {
+			ports = append(ports, v1.ServicePort{
+				TargetPort: intstr.FromInt(port.Port),
+				Protocol:   v1.Protocol(port.Protocol),
+			})
+		}
+
+		if len(ports) == 0 {
+			ports = append(ports, v1.ServicePort{Port: 0})
+		}
+
+		podList, err := i.config.K8sClient.CoreV1().Pods(podSpec.Namespace).List(context.Background(), metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing the given pods (%s/%s): %w", podSpec.Namespace, selector.String(), err)
+		}
+
+		for _, pod := range podList.Items {
+			if pod.Status.PodIP == "" {
+				continue
+			}
+
+			destinations = append(destinations, tcpFaultsDestinationsFromFilters(i.buildServiceFiltersFromPod(pod, ports))...)
+		}
+	}
+
+	return destinations, nil
+}
+
+// tcpFaultsDestinationsFromFilters converts the given service filters into tcp faults destinations, skipping non-tcp ones
+func tcpFaultsDestinationsFromFilters(filters []tcServiceFilter) []tcpFaultsDestination {
+	destinations := []tcpFaultsDestination{}
+
+	for _, filter := range filters {
+		if !isTCPFilter(filter) {
+			continue
+		}
+
+		destination := tcpFaultsDestination{ip: filter.service.ip.String()}
+		if filter.service.port != 0 {
+			destination.port = strconv.Itoa(filter.service.port)
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	return destinations
+}
+
+// isTCPFilter returns true if the given service filter matches tcp traffic, whatever the case of its protocol
+func isTCPFilter(filter tcServiceFilter) bool {
+	for _, protocol := range network.AllProtocols(filter.service.protocol) {
+		if protocol == network.TCP {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setSysctl sets the given kernel parameter, keeping its previous value to restore it on cleanup
+func (i *networkDisruptionInjector) setSysctl(name string, value string) error {
+	if _, ok := i.sysctlsToRestore[name]; !ok {
+		previousValue, err := i.config.Sysctl.Get(name)
+		if err != nil {
+			return err
+		}
+
+		i.sysctlsToRestore[name] = previousValue
+	}
+
+	return i.config.Sysctl.Set(name, value)
+}
+
 // isHeadless returns true if the service is a headless service, i.e., has no defined ClusterIP
 func isHeadless(service v1.Service) bool {
 	return service.Spec.ClusterIP == "" || strings.ToLower(service.Spec.ClusterIP) == "none"
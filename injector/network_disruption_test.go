@@ -54,6 +54,7 @@ var _ = Describe("Failure", func() {
 		nllink1TxQlenCall, nllink2TxQlenCall, nllink3TxQlenCall *network.NetlinkLinkMock_TxQLen_Call
 		nlroute1, nlroute2, nlroute3                            *network.NetlinkRouteMock
 		dns                                                     *network.DNSClientMock
+		sysctl                                                  *network.SysctlMock
 		netnsManager                                            *netns.ManagerMock
 		k8sClient                                               *kubernetes.Clientset
 		fakeService                                             *corev1.Service
@@ -94,6 +95,14 @@ var _ = Describe("Failure", func() {
 		iptables.EXPECT().MarkCgroupPath(mock.Anything, mock.Anything).Return(nil).Maybe()
 		iptables.EXPECT().MarkClassID(mock.Anything, mock.Anything).Return(nil).Maybe()
 		iptables.EXPECT().LogConntrack().Return(nil).Maybe()
+		iptables.EXPECT().RejectWithTCPReset(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		iptables.EXPECT().DropTCPSYN(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		// sysctl
+		sysctl = network.NewSysctlMock(GinkgoT())
+		sysctl.EXPECT().Get(network.ConntrackTCPEstablishedTimeoutSysctl).Return("432000", nil).Maybe()
+		sysctl.EXPECT().Get(network.ConntrackTCPLooseSysctl).Return("1", nil).Maybe()
+		sysctl.EXPECT().Set(mock.Anything, mock.Anything).Return(nil).Maybe()
 
 		// netlink
 		nllink1 = network.NewNetlinkLinkMock(GinkgoT())
@@ -237,6 +246,7 @@ var _ = Describe("Failure", func() {
 			IPTables:            iptables,
 			NetlinkAdapter:      nl,
 			DNSClient:           dns,
+			Sysctl:              sysctl,
 			HostResolveInterval: time.Millisecond * 500,
 		}
 
@@ -363,6 +373,65 @@ var _ = Describe("Failure", func() {
 			})
 		})
 
+		Context("with more ip ranges than the LPM filter threshold specified", func() {
+			BeforeEach(func() {
+				spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{
+					{
+						Host:     "10.0.0.0/8",
+						Protocol: "tcp",
+					},
+					{
+						// contained in the previous ip range with the same match, redundant
+						Host:     "10.1.0.0/16",
+						Protocol: "tcp",
+					},
+					{
+						// contained in the first ip range with another match, filtered by a tc filter
+						Host: "10.2.0.0/16",
+						Port: 443,
+					},
+					{
+						// connection state can't be matched by the classifier, filtered by a tc filter
+						Host:      "192.168.0.0/16",
+						ConnState: "new",
+						Protocol:  "tcp",
+					},
+				}
+
+				// only disjoint ip ranges are configured, larger ones first
+				configArgs := []interface{}{"-p", "1000", "-d", "lo", "-d", "eth0", "-d", "eth1", "-e", "10.0.0.0/8;0;tcp"}
+
+				for i := 0; i < v1beta1.LPMFilterThreshold; i++ {
+					spec.Hosts = append(spec.Hosts, v1beta1.NetworkDisruptionHostSpec{
+						Host:     fmt.Sprintf("172.16.%d.0/24", i),
+						Flow:     v1beta1.FlowIngress,
+						Protocol: "udp",
+					})
+
+					configArgs = append(configArgs, "-i", fmt.Sprintf("172.16.%d.0/24;0;udp", i))
+				}
+
+				tc.EXPECT().AddPrioritizedBPFFilter([]string{"lo", "eth0", "eth1"}, "1:0", "/usr/local/bin/bpf-network-lpm-filter.bpf.o", "1:4").Return(1000, nil).Once()
+				tc.EXPECT().ConfigBPFFilter(mock.Anything, configArgs...).Return(nil).Once()
+			})
+
+			It("should add an eBPF LPM filter configured with the disjoint ip ranges", func() {
+				tc.AssertCalled(GinkgoT(), "AddPrioritizedBPFFilter", []string{"lo", "eth0", "eth1"}, "1:0", "/usr/local/bin/bpf-network-lpm-filter.bpf.o", "1:4")
+				tc.AssertNumberOfCalls(GinkgoT(), "ConfigBPFFilter", 1)
+			})
+
+			It("should add tc filters for the ip ranges not filterable by the eBPF LPM filter only", func() {
+				_, sameMatchIPNet, _ := net.ParseCIDR("10.1.0.0/16")
+				_, otherMatchIPNet, _ := net.ParseCIDR("10.2.0.0/16")
+				_, connStateIPNet, _ := net.ParseCIDR("192.168.0.0/16")
+
+				tc.AssertNotCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, sameMatchIPNet, 0, 0, network.TCP, network.ConnStateUndefined, "1:4")
+				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, otherMatchIPNet, 0, 443, network.TCP, network.ConnStateUndefined, "1:4")
+				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, otherMatchIPNet, 0, 443, network.UDP, network.ConnStateUndefined, "1:4")
+				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, connStateIPNet, 0, 0, network.TCP, network.ConnStateNew, "1:4")
+			})
+		})
+
 		Context("host watcher", func() {
 			BeforeEach(func() {
 				spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{
@@ -530,6 +599,161 @@ var _ = Describe("Failure", func() {
 			})
 		})
 
+		Context("with one pods destination specified", func() {
+			var podsWatcher *watch.FakeWatcher
+
+			BeforeEach(func() {
+				spec.Pods = []v1beta1.NetworkDisruptionPodSpec{
+					{
+						Namespace: "bar",
+						Selector:  map[string]string{"app": "foo"},
+						Ports: []v1beta1.NetworkDisruptionPodPortSpec{
+							{
+								Port:     8080,
+								Protocol: "tcp",
+							},
+						},
+					},
+				}
+
+				podsWatcher = watch.NewFakeWithChanSize(2, false)
+
+				k8sClient.PrependWatchReactor("pods", testing.DefaultWatchReactor(podsWatcher, nil))
+
+				// Set up adding then deleting a pod
+				podsWatcher.Add(fakeEndpoint)
+				podsWatcher.Delete(fakeEndpoint)
+			})
+
+			It("should add a filter for the pod matching the selector on the given port and then delete it", func() {
+				WatchersAreEmpty(podsWatcher)
+
+				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, buildSingleIPNetUsingParse(podIP), 0, 8080, network.TCP, network.ConnStateUndefined, "1:4")
+				tc.AssertNotCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, buildSingleIPNetUsingParse(podIP), 0, 8080, network.UDP, network.ConnStateUndefined, "1:4")
+
+				tc.AssertCalled(GinkgoT(), "DeleteFilter", "lo", uint32(0))
+				tc.AssertCalled(GinkgoT(), "DeleteFilter", "eth0", uint32(0))
+				tc.AssertCalled(GinkgoT(), "DeleteFilter", "eth1", uint32(0))
+			})
+
+			AfterEach(func() {
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+
+		Context("with tcp faults", func() {
+			BeforeEach(func() {
+				spec.TCPFaults = &v1beta1.NetworkTCPFaults{
+					DropSYN:     true,
+					IdleTimeout: 30,
+				}
+				spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{
+					{
+						Host: "1.1.1.1",
+						Port: 443,
+					},
+					{
+						Host:     "2.2.2.2",
+						Protocol: "udp",
+					},
+				}
+			})
+
+			It("should drop syn packets going to the tcp hosts only", func() {
+				iptables.AssertCalled(GinkgoT(), "DropTCPSYN", "1.1.1.1/32", "443", chaostypes.InjectorCgroupClassID)
+				iptables.AssertNotCalled(GinkgoT(), "DropTCPSYN", "2.2.2.2/32", mock.Anything, mock.Anything)
+			})
+
+			It("should reset idle connections going to the tcp hosts", func() {
+				iptables.AssertCalled(GinkgoT(), "RejectWithTCPReset", "INVALID", "1.1.1.1/32", "443", chaostypes.InjectorCgroupClassID)
+				iptables.AssertNotCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", mock.Anything, mock.Anything, mock.Anything)
+				sysctl.AssertCalled(GinkgoT(), "Set", network.ConntrackTCPEstablishedTimeoutSysctl, "30")
+				sysctl.AssertCalled(GinkgoT(), "Set", network.ConntrackTCPLooseSysctl, "0")
+			})
+
+			It("should restore the kernel parameters on clean", func() {
+				Expect(inj.Clean()).To(Succeed())
+
+				sysctl.AssertCalled(GinkgoT(), "Set", network.ConntrackTCPEstablishedTimeoutSysctl, "432000")
+				sysctl.AssertCalled(GinkgoT(), "Set", network.ConntrackTCPLooseSysctl, "1")
+			})
+
+			Context("without hosts", func() {
+				BeforeEach(func() {
+					spec.TCPFaults = &v1beta1.NetworkTCPFaults{
+						Reset: true,
+					}
+					spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{}
+				})
+
+				It("should reset all established connections", func() {
+					iptables.AssertCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", "", "", chaostypes.InjectorCgroupClassID)
+					sysctl.AssertNotCalled(GinkgoT(), "Set", mock.Anything, mock.Anything)
+				})
+			})
+
+			Context("with services only", func() {
+				BeforeEach(func() {
+					spec.TCPFaults = &v1beta1.NetworkTCPFaults{
+						Reset: true,
+					}
+					spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{}
+					spec.Services = []v1beta1.NetworkDisruptionServiceSpec{
+						{
+							Name:      "foo",
+							Namespace: "bar",
+						},
+					}
+				})
+
+				It("should reset the established connections going to the service and its pods only", func() {
+					iptables.AssertCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", clusterIP+"/32", "80", chaostypes.InjectorCgroupClassID)
+					iptables.AssertCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", podIP+"/32", "8080", chaostypes.InjectorCgroupClassID)
+					iptables.AssertNotCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", "", mock.Anything, mock.Anything)
+					iptables.AssertNumberOfCalls(GinkgoT(), "RejectWithTCPReset", 2)
+				})
+
+				AfterEach(func() {
+					Expect(inj.Clean()).To(Succeed())
+				})
+			})
+
+			Context("with pods only", func() {
+				BeforeEach(func() {
+					spec.TCPFaults = &v1beta1.NetworkTCPFaults{
+						Reset: true,
+					}
+					spec.Hosts = []v1beta1.NetworkDisruptionHostSpec{}
+					spec.Pods = []v1beta1.NetworkDisruptionPodSpec{
+						{
+							Namespace: "bar",
+							Selector:  map[string]string{"app": "foo"},
+							Ports: []v1beta1.NetworkDisruptionPodPortSpec{
+								{
+									Port:     8080,
+									Protocol: "tcp",
+								},
+								{
+									Port:     53,
+									Protocol: "udp",
+								},
+							},
+						},
+					}
+				})
+
+				It("should reset the established connections going to the tcp ports of the pods only", func() {
+					iptables.AssertCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", podIP+"/32", "8080", chaostypes.InjectorCgroupClassID)
+					iptables.AssertNotCalled(GinkgoT(), "RejectWithTCPReset", "ESTABLISHED", "", mock.Anything, mock.Anything)
+					iptables.AssertNumberOfCalls(GinkgoT(), "RejectWithTCPReset", 1)
+				})
+
+				AfterEach(func() {
+					Expect(inj.Clean()).To(Succeed())
+				})
+			})
+		})
+
 		// safeguards
 		Context("pod level safeguards", func() {
 			It("should add a filter to redirect default gateway IP traffic on a non-disrupted band", func() {
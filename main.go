@@ -10,13 +10,17 @@ import (
 	"fmt"
 	"os"
 	"time"
+	_ "time/tzdata" // embed the time zone database so DisruptionCron time zones can be resolved in any image
 
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/audit"
+	audittypes "github.com/DataDog/chaos-controller/audit/types"
 	"github.com/DataDog/chaos-controller/cloudservice"
 	"github.com/DataDog/chaos-controller/config"
 	"github.com/DataDog/chaos-controller/controllers"
 	"github.com/DataDog/chaos-controller/ddmark"
 	"github.com/DataDog/chaos-controller/eventbroadcaster"
+	"github.com/DataDog/chaos-controller/guardrails"
 	"github.com/DataDog/chaos-controller/log"
 	"github.com/DataDog/chaos-controller/o11y/metrics"
 	metricstypes "github.com/DataDog/chaos-controller/o11y/metrics/types"
@@ -113,11 +117,11 @@ func main() {
 		}
 	}()
 
-	profilerSink, err := profiler.GetSink(logger, profilertypes.SinkDriver(cfg.Controller.ProfilerSink))
+	profilerSink, err := profiler.GetSink(logger, profilertypes.SinkDriver(cfg.Controller.ProfilerSink), cfg.Controller.ProfilerPprof)
 	if err != nil {
 		logger.Errorw("error while creating profiler sink, switching to noop", "error", err)
 
-		profilerSink, _ = profiler.GetSink(logger, profilertypes.SinkDriverNoop)
+		profilerSink, _ = profiler.GetSink(logger, profilertypes.SinkDriverNoop, cfg.Controller.ProfilerPprof)
 	}
 	// handle profiler sink close on exit
 	defer func() {
@@ -125,11 +129,11 @@ func main() {
 		profilerSink.Stop()
 	}()
 
-	tracerSink, err := tracer.GetSink(logger, tracertypes.SinkDriver(cfg.Controller.TracerSink))
+	tracerSink, err := tracer.GetSink(logger, tracertypes.SinkDriver(cfg.Controller.TracerSink), cfg.Controller.TracerOTLP)
 	if err != nil {
 		logger.Errorw("error while creating profiler sink, switching to noop", "error", err)
 
-		tracerSink, _ = tracer.GetSink(logger, tracertypes.SinkDriverNoop)
+		tracerSink, _ = tracer.GetSink(logger, tracertypes.SinkDriverNoop, cfg.Controller.TracerOTLP)
 	}
 	// handle tracer sink close on exit
 	defer func() {
@@ -140,6 +144,20 @@ func main() {
 		}
 	}()
 
+	auditSink, err := audit.GetSink(logger, audittypes.SinkDriver(cfg.Controller.AuditSink), cfg.Controller.AuditFile, cfg.Controller.AuditHTTP)
+	if err != nil {
+		// an audit sink silently switching to noop would leave the disruptions unaudited
+		logger.Fatalw("error while creating audit sink", "error", err)
+	}
+	// handle audit sink close on exit
+	defer func() {
+		logger.Infow("closing audit sink before exiting", "sink", auditSink.GetSinkName())
+
+		if err := auditSink.Close(); err != nil {
+			logger.Errorw("error closing audit sink", "sink", auditSink.GetSinkName(), "error", err)
+		}
+	}()
+
 	// initiate Open Telemetry, set it up with the sink Provider, use TraceContext for propagation through the CRD
 	otel.SetTracerProvider(tracerSink.GetProvider())
 	otel.SetTextMapPropagator(propagation.TraceContext{})
@@ -157,11 +175,16 @@ func main() {
 	}
 
 	// initialize the cloud provider manager which will handle ip ranges files updates
-	cloudProviderManager, err := cloudservice.New(logger, cfg.Controller.CloudProviders)
+	cloudProviderManager, err := cloudservice.New(logger, cfg.Controller.CloudProviders, mgr.GetAPIReader())
 	if err != nil {
 		logger.Fatalw("error initializing CloudProviderManager", "error", err)
 	}
 
+	// expose the status of the loaded ip ranges next to the metrics
+	if err = mgr.AddMetricsExtraHandler("/cloud-providers", cloudProviderManager.StatusHandler()); err != nil {
+		logger.Errorw("error exposing the cloud providers status", "error", err)
+	}
+
 	cloudProviderManager.StartPeriodicPull()
 
 	// create disruption reconciler
@@ -172,6 +195,7 @@ func main() {
 		Recorder:                              broadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: chaosv1beta1.SourceDisruptionComponent}),
 		MetricsSink:                           metricsSink,
 		TracerSink:                            tracerSink,
+		TracerOTLP:                            cfg.Controller.TracerOTLP,
 		TargetSelector:                        targetSelector,
 		InjectorAnnotations:                   cfg.Injector.Annotations,
 		InjectorLabels:                        cfg.Injector.Labels,
@@ -187,6 +211,19 @@ func main() {
 		Reader:                                mgr.GetAPIReader(),
 		EnableObserver:                        cfg.Controller.EnableObserver,
 		CloudServicesProvidersManager:         cloudProviderManager,
+		GuardrailsChecker:                     guardrails.NewChecker(mgr.GetClient(), nil, cfg.Controller.Guardrails.PrometheusEndpoint, cfg.Controller.Guardrails.AllowedHosts),
+		Reports:                               cfg.Controller.Reports,
+		AuditSink:                             auditSink,
+	}
+
+	// quotas are part of the safemode, they are enforced again by the reconciler before creating chaos pods
+	if cfg.Controller.SafeMode.Enable {
+		disruptionReconciler.Quotas = chaosv1beta1.DisruptionQuotas{
+			MaxDisruptionsPerNamespace:     cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerNamespace,
+			MaxDisruptionsPerCluster:       cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerCluster,
+			MaxDisruptionsPerKind:          cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerKind,
+			MaxWorkloadDisruptedPercentage: cfg.Controller.SafeMode.Quotas.MaxWorkloadDisruptedPercentage,
+		}
 	}
 
 	informerClient := kubernetes.NewForConfigOrDie(ctrl.GetConfigOrDie())
@@ -203,6 +240,7 @@ func main() {
 		Log:            logger,
 		MetricSink:     metricsSink,
 		Reader:         mgr.GetAPIReader(),
+		StatusWriter:   mgr.GetClient().Status(),
 		Recorder:       disruptionReconciler.Recorder,
 		ChaosNamespace: cfg.Injector.ChaosNamespace,
 	}
@@ -236,13 +274,15 @@ func main() {
 	go disruptionReconciler.ReportMetrics()
 
 	if cfg.Controller.DisruptionRolloutEnabled {
-		// create deployment and statefulset informers
+		// create deployment, statefulset and daemonset informers
 		globalInformerFactory := kubeinformers.NewSharedInformerFactory(informerClient, time.Hour*24)
 		deploymentInformer := globalInformerFactory.Apps().V1().Deployments().Informer()
 		statefulsetInformer := globalInformerFactory.Apps().V1().StatefulSets().Informer()
+		daemonsetInformer := globalInformerFactory.Apps().V1().DaemonSets().Informer()
 
 		deploymentHandler := watchers.NewDeploymentHandler(mgr.GetClient(), logger)
 		statefulsetHandler := watchers.NewStatefulSetHandler(mgr.GetClient(), logger)
+		daemonsetHandler := watchers.NewDaemonSetHandler(mgr.GetClient(), logger)
 
 		_, err = deploymentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    deploymentHandler.OnAdd,
@@ -262,7 +302,16 @@ func main() {
 			logger.Fatalw("unable to add event handler for StatefulSets", "error", err)
 		}
 
-		// wait for the deployment and statefulset informer caches to be synced
+		_, err = daemonsetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    daemonsetHandler.OnAdd,
+			UpdateFunc: daemonsetHandler.OnUpdate,
+			DeleteFunc: daemonsetHandler.OnDelete,
+		})
+		if err != nil {
+			logger.Fatalw("unable to add event handler for DaemonSets", "error", err)
+		}
+
+		// wait for the deployment, statefulset and daemonset informer caches to be synced
 		synced := globalInformerFactory.WaitForCacheSync(ctx.Done())
 		for informerType, ok := range synced {
 			if !ok {
@@ -271,14 +320,16 @@ func main() {
 			}
 		}
 
-		// start the deployment and statefulset informers
+		// start the deployment, statefulset and daemonset informers
 		globalInformerFactory.Start(stopCh)
 
 		// create disruption rollout reconciler
 		disruptionRolloutReconciler := &controllers.DisruptionRolloutReconciler{
-			Client:  mgr.GetClient(),
-			BaseLog: logger,
-			Scheme:  mgr.GetScheme(),
+			Client:      mgr.GetClient(),
+			BaseLog:     logger,
+			Scheme:      mgr.GetScheme(),
+			Recorder:    broadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: chaosv1beta1.SourceDisruptionRolloutComponent}),
+			MetricsSink: metricsSink,
 		}
 
 		if err := disruptionRolloutReconciler.SetupWithManager(mgr); err != nil {
@@ -303,38 +354,74 @@ func main() {
 	if cfg.Controller.DisruptionCronEnabled {
 		// create disruption cron reconciler
 		disruptionCronReconciler := &controllers.DisruptionCronReconciler{
+			Client:          mgr.GetClient(),
+			BaseLog:         logger,
+			Scheme:          mgr.GetScheme(),
+			BlackoutEnabled: cfg.Controller.DisruptionBlackoutEnabled,
+			Recorder:        broadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: chaosv1beta1.SourceDisruptionCronComponent}),
+			MetricsSink:     metricsSink,
+		}
+
+		if err := disruptionCronReconciler.SetupWithManager(mgr); err != nil {
+			logger.Errorw("unable to create controller", "controller", "DisruptionCron", "error", err)
+			os.Exit(1) //nolint:gocritic
+		}
+	}
+
+	if cfg.Controller.DisruptionWorkflowEnabled {
+		// create disruption workflow reconciler
+		disruptionWorkflowReconciler := &controllers.DisruptionWorkflowReconciler{
 			Client:  mgr.GetClient(),
+			Reader:  mgr.GetAPIReader(),
 			BaseLog: logger,
 			Scheme:  mgr.GetScheme(),
 		}
 
-		if err := disruptionCronReconciler.SetupWithManager(mgr); err != nil {
-			logger.Errorw("unable to create controller", "controller", "DisruptionCron", "error", err)
+		if err := disruptionWorkflowReconciler.SetupWithManager(mgr); err != nil {
+			logger.Errorw("unable to create controller", "controller", "DisruptionWorkflow", "error", err)
 			os.Exit(1) //nolint:gocritic
 		}
+
+		// register disruption workflow validating webhook
+		if err := (&chaosv1beta1.DisruptionWorkflow{}).SetupWebhookWithManager(mgr); err != nil {
+			logger.Fatalw("unable to create webhook", "webhook", "DisruptionWorkflow", "error", err)
+		}
 	}
 
 	// register disruption validating webhook
 	setupWebhookConfig := utils.SetupWebhookWithManagerConfig{
-		Manager:                       mgr,
-		Logger:                        logger,
-		MetricsSink:                   metricsSink,
-		TracerSink:                    tracerSink,
-		Recorder:                      disruptionReconciler.Recorder,
-		NamespaceThresholdFlag:        cfg.Controller.SafeMode.NamespaceThreshold,
-		ClusterThresholdFlag:          cfg.Controller.SafeMode.ClusterThreshold,
-		EnableSafemodeFlag:            cfg.Controller.SafeMode.Enable,
-		DeleteOnlyFlag:                cfg.Controller.DeleteOnly,
-		HandlerEnabledFlag:            cfg.Handler.Enabled,
-		DefaultDurationFlag:           cfg.Controller.DefaultDuration,
-		ChaosNamespace:                cfg.Injector.ChaosNamespace,
-		CloudServicesProvidersManager: cloudProviderManager,
-		Environment:                   cfg.Controller.SafeMode.Environment,
+		Manager:                            mgr,
+		Logger:                             logger,
+		MetricsSink:                        metricsSink,
+		TracerSink:                         tracerSink,
+		Recorder:                           disruptionReconciler.Recorder,
+		NamespaceThresholdFlag:             cfg.Controller.SafeMode.NamespaceThreshold,
+		ClusterThresholdFlag:               cfg.Controller.SafeMode.ClusterThreshold,
+		EnableSafemodeFlag:                 cfg.Controller.SafeMode.Enable,
+		DeleteOnlyFlag:                     cfg.Controller.DeleteOnly,
+		HandlerEnabledFlag:                 cfg.Handler.Enabled,
+		DefaultDurationFlag:                cfg.Controller.DefaultDuration,
+		ChaosNamespace:                     cfg.Injector.ChaosNamespace,
+		CloudServicesProvidersManager:      cloudProviderManager,
+		Environment:                        cfg.Controller.SafeMode.Environment,
+		DisruptionBlackoutEnabled:          cfg.Controller.DisruptionBlackoutEnabled,
+		MaxDisruptionsPerNamespaceFlag:     cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerNamespace,
+		MaxDisruptionsPerClusterFlag:       cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerCluster,
+		MaxDisruptionsPerKindFlag:          cfg.Controller.SafeMode.Quotas.MaxDisruptionsPerKind,
+		MaxWorkloadDisruptedPercentageFlag: cfg.Controller.SafeMode.Quotas.MaxWorkloadDisruptedPercentage,
+		GuardrailsAllowedHosts:             cfg.Controller.Guardrails.AllowedHosts,
 	}
 	if err = (&chaosv1beta1.Disruption{}).SetupWebhookWithManager(setupWebhookConfig); err != nil {
 		logger.Fatalw("unable to create webhook", "webhook", chaosv1beta1.DisruptionKind, "error", err)
 	}
 
+	if cfg.Controller.DisruptionBlackoutEnabled {
+		// register disruption blackout validating webhook
+		if err = (&chaosv1beta1.DisruptionBlackout{}).SetupWebhookWithManager(mgr); err != nil {
+			logger.Fatalw("unable to create webhook", "webhook", "DisruptionBlackout", "error", err)
+		}
+	}
+
 	if cfg.Handler.Enabled {
 		// register chaos handler init container mutating webhook
 		mgr.GetWebhookServer().Register("/mutate-v1-pod-chaos-handler-init-container", &webhook.Admission{
@@ -357,6 +444,14 @@ func main() {
 		})
 	}
 
+	// register audit validating webhook, it never rejects requests
+	mgr.GetWebhookServer().Register("/validate-chaos-datadoghq-com-v1beta1-disruption-audit", &webhook.Admission{
+		Handler: &chaoswebhook.AuditValidator{
+			Sink: auditSink,
+			Log:  logger,
+		},
+	})
+
 	mgr.GetWebhookServer().Register("/mutate-chaos-datadoghq-com-v1beta1-disruption-span-context", &webhook.Admission{
 		Handler: &chaoswebhook.SpanContextMutator{
 			Client: mgr.GetClient(),
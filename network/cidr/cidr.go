@@ -0,0 +1,166 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cidr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// ipv4Range is an IPv4 CIDR represented by its first address and its prefix length
+type ipv4Range struct {
+	start    uint32
+	prefix   int
+	original *net.IPNet // given CIDR, kept as is when the range is not modified
+}
+
+// last returns the last address of the range
+func (r ipv4Range) last() uint32 {
+	return r.start | (^uint32(0) >> r.prefix)
+}
+
+// contains returns true if the given range is fully part of the range
+func (r ipv4Range) contains(other ipv4Range) bool {
+	return r.prefix <= other.prefix && r.start <= other.start && other.last() <= r.last()
+}
+
+// siblingOf returns true if both ranges have the same size and can be merged into their parent range
+func (r ipv4Range) siblingOf(other ipv4Range) bool {
+	if r.prefix != other.prefix || r.prefix == 0 {
+		return false
+	}
+
+	parentMask := ^uint32(0) << (32 - (r.prefix - 1))
+
+	return r.start != other.start && r.start&parentMask == other.start&parentMask
+}
+
+func (r ipv4Range) ipNet() *net.IPNet {
+	if r.original != nil {
+		return r.original
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, r.start)
+
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(r.prefix, 32),
+	}
+}
+
+// Collapse returns the smallest list of CIDRs covering exactly the given ones,
+// removing the CIDRs contained in others and merging the adjacent ones
+// Non-IPv4 CIDRs are returned as is, after the collapsed IPv4 ones
+func Collapse(ipNets []*net.IPNet) []*net.IPNet {
+	ranges := []ipv4Range{}
+	others := []*net.IPNet{}
+
+	for _, ipNet := range ipNets {
+		ip := ipNet.IP.To4()
+		ones, bits := ipNet.Mask.Size()
+
+		if ip == nil || bits != 32 {
+			others = append(others, ipNet)
+
+			continue
+		}
+
+		r := ipv4Range{
+			start:  binary.BigEndian.Uint32(ip) & (^uint32(0) << (32 - ones)),
+			prefix: ones,
+		}
+
+		if r.start == binary.BigEndian.Uint32(ip) {
+			r.original = ipNet
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	// sort by first address, larger ranges first, so contained ranges directly follow their container
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].start != ranges[j].start {
+			return ranges[i].start < ranges[j].start
+		}
+
+		return ranges[i].prefix < ranges[j].prefix
+	})
+
+	collapsed := []ipv4Range{}
+
+	for _, r := range ranges {
+		if len(collapsed) > 0 && collapsed[len(collapsed)-1].contains(r) {
+			continue
+		}
+
+		collapsed = append(collapsed, r)
+
+		// merge the last two ranges as long as they are siblings, e.g. 10.0.0.0/25 + 10.0.0.128/25 = 10.0.0.0/24
+		for len(collapsed) > 1 && collapsed[len(collapsed)-2].siblingOf(collapsed[len(collapsed)-1]) {
+			parent := collapsed[len(collapsed)-2]
+			parent.prefix--
+			parent.original = nil
+			collapsed = append(collapsed[:len(collapsed)-2], parent)
+		}
+	}
+
+	result := make([]*net.IPNet, 0, len(collapsed)+len(others))
+	for _, r := range collapsed {
+		result = append(result, r.ipNet())
+	}
+
+	return append(result, others...)
+}
+
+// CollapseStrings parses the given IPs or CIDRs and collapses them, see Collapse
+func CollapseStrings(ipRanges []string) ([]string, error) {
+	ipNets := make([]*net.IPNet, 0, len(ipRanges))
+
+	for _, ipRange := range ipRanges {
+		ipNet, err := Parse(ipRange)
+		if err != nil {
+			return nil, err
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	collapsed := Collapse(ipNets)
+	result := make([]string, 0, len(collapsed))
+
+	for _, ipNet := range collapsed {
+		result = append(result, ipNet.String())
+	}
+
+	return result, nil
+}
+
+// Parse parses the given CIDR, or IP which is then considered as a single address CIDR
+func Parse(ipRange string) (*net.IPNet, error) {
+	if strings.Contains(ipRange, "/") {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %s: %w", ipRange, err)
+		}
+
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(ipRange)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip %s", ipRange)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
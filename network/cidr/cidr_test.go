@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cidr
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCIDR(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CIDR Suite")
+}
+
+var _ = Describe("Collapse", func() {
+	DescribeTable("should collapse the given cidrs",
+		func(ipRanges []string, expected []string) {
+			collapsed, err := CollapseStrings(ipRanges)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collapsed).To(Equal(expected))
+		},
+		Entry("with no cidr", []string{}, []string{}),
+		Entry("with distinct cidrs", []string{"10.0.0.0/24", "192.168.0.0/16"}, []string{"10.0.0.0/24", "192.168.0.0/16"}),
+		Entry("with duplicated cidrs", []string{"10.0.0.0/24", "10.0.0.0/24"}, []string{"10.0.0.0/24"}),
+		Entry("with contained cidrs", []string{"10.0.1.0/24", "10.0.0.0/16", "10.0.2.1"}, []string{"10.0.0.0/16"}),
+		Entry("with adjacent cidrs", []string{"10.0.0.128/25", "10.0.0.0/25"}, []string{"10.0.0.0/24"}),
+		Entry("with cascading adjacent cidrs", []string{"10.0.0.0/24", "10.0.1.0/25", "10.0.1.128/25", "10.0.2.0/23"}, []string{"10.0.0.0/22"}),
+		Entry("with adjacent but not mergeable cidrs", []string{"10.0.1.0/24", "10.0.2.0/24"}, []string{"10.0.1.0/24", "10.0.2.0/24"}),
+		Entry("with single ips", []string{"10.0.0.1", "10.0.0.0"}, []string{"10.0.0.0/31"}),
+		Entry("with non canonical cidrs", []string{"10.0.0.1/24"}, []string{"10.0.0.0/24"}),
+		Entry("with ipv6 cidrs", []string{"2001:db8::/32", "10.0.0.0/24"}, []string{"10.0.0.0/24", "2001:db8::/32"}),
+	)
+
+	It("should fail on invalid cidrs", func() {
+		_, err := CollapseStrings([]string{"10.0.0.0/33"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = CollapseStrings([]string{"not-an-ip"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -21,16 +21,27 @@ type defaultTcExecutor struct {
 	dryRun bool
 }
 
-type ebpfTCFilterConfigExecutor struct {
-	log    *zap.SugaredLogger
-	dryRun bool
+type ebpfConfigExecutor struct {
+	log     *zap.SugaredLogger
+	dryRun  bool
+	program string
 }
 
 // NewBPFTCFilterConfigExecutor create a new instance of an executor responsible of configure tc eBPF filter program
 func NewBPFTCFilterConfigExecutor(log *zap.SugaredLogger, dryRun bool) executor {
-	return ebpfTCFilterConfigExecutor{
-		log:    log,
-		dryRun: dryRun,
+	return ebpfConfigExecutor{
+		log:     log,
+		dryRun:  dryRun,
+		program: "/usr/local/bin/bpf-network-tc-filter",
+	}
+}
+
+// NewBPFLPMFilterConfigExecutor create a new instance of an executor responsible of configure the tc eBPF LPM filter program
+func NewBPFLPMFilterConfigExecutor(log *zap.SugaredLogger, dryRun bool) executor {
+	return ebpfConfigExecutor{
+		log:     log,
+		dryRun:  dryRun,
+		program: "/usr/local/bin/bpf-network-lpm-filter",
 	}
 }
 
@@ -61,14 +72,14 @@ func (e defaultTcExecutor) Run(args []string) (int, string, error) {
 	return cmd.ProcessState.ExitCode(), stdout.String(), err
 }
 
-// Run executes the given args using bpf-network-tc-filter program
+// Run executes the given args using the eBPF configuration program
 // and returns a wrapped error containing both the error returned by the execution and
 // the stderr content
-func (e ebpfTCFilterConfigExecutor) Run(args []string) (int, string, error) {
+func (e ebpfConfigExecutor) Run(args []string) (int, string, error) {
 	// parse args and execute
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
-	cmd := exec.Command("/usr/local/bin/bpf-network-tc-filter", args...)
+	cmd := exec.Command(e.program, args...)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
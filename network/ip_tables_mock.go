@@ -62,6 +62,50 @@ func (_c *IPTablesMock_Clear_Call) RunAndReturn(run func() error) *IPTablesMock_
 	return _c
 }
 
+// DropTCPSYN provides a mock function with given fields: destination, port, mark
+func (_m *IPTablesMock) DropTCPSYN(destination string, port string, mark string) error {
+	ret := _m.Called(destination, port, mark)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(destination, port, mark)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPTablesMock_DropTCPSYN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropTCPSYN'
+type IPTablesMock_DropTCPSYN_Call struct {
+	*mock.Call
+}
+
+// DropTCPSYN is a helper method to define mock.On call
+//   - destination string
+//   - port string
+//   - mark string
+func (_e *IPTablesMock_Expecter) DropTCPSYN(destination interface{}, port interface{}, mark interface{}) *IPTablesMock_DropTCPSYN_Call {
+	return &IPTablesMock_DropTCPSYN_Call{Call: _e.mock.On("DropTCPSYN", destination, port, mark)}
+}
+
+func (_c *IPTablesMock_DropTCPSYN_Call) Run(run func(destination string, port string, mark string)) *IPTablesMock_DropTCPSYN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IPTablesMock_DropTCPSYN_Call) Return(_a0 error) *IPTablesMock_DropTCPSYN_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPTablesMock_DropTCPSYN_Call) RunAndReturn(run func(string, string, string) error) *IPTablesMock_DropTCPSYN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Intercept provides a mock function with given fields: protocol, port, cgroupPath, cgroupClassID, injectorPodIP
 func (_m *IPTablesMock) Intercept(protocol string, port string, cgroupPath string, cgroupClassID string, injectorPodIP string) error {
 	ret := _m.Called(protocol, port, cgroupPath, cgroupClassID, injectorPodIP)
@@ -279,6 +323,51 @@ func (_c *IPTablesMock_RedirectTo_Call) RunAndReturn(run func(string, string, st
 	return _c
 }
 
+// RejectWithTCPReset provides a mock function with given fields: connState, destination, port, mark
+func (_m *IPTablesMock) RejectWithTCPReset(connState string, destination string, port string, mark string) error {
+	ret := _m.Called(connState, destination, port, mark)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+		r0 = rf(connState, destination, port, mark)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPTablesMock_RejectWithTCPReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectWithTCPReset'
+type IPTablesMock_RejectWithTCPReset_Call struct {
+	*mock.Call
+}
+
+// RejectWithTCPReset is a helper method to define mock.On call
+//   - connState string
+//   - destination string
+//   - port string
+//   - mark string
+func (_e *IPTablesMock_Expecter) RejectWithTCPReset(connState interface{}, destination interface{}, port interface{}, mark interface{}) *IPTablesMock_RejectWithTCPReset_Call {
+	return &IPTablesMock_RejectWithTCPReset_Call{Call: _e.mock.On("RejectWithTCPReset", connState, destination, port, mark)}
+}
+
+func (_c *IPTablesMock_RejectWithTCPReset_Call) Run(run func(connState string, destination string, port string, mark string)) *IPTablesMock_RejectWithTCPReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *IPTablesMock_RejectWithTCPReset_Call) Return(_a0 error) *IPTablesMock_RejectWithTCPReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPTablesMock_RejectWithTCPReset_Call) RunAndReturn(run func(string, string, string, string) error) *IPTablesMock_RejectWithTCPReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewIPTablesMock interface {
 	mock.TestingT
 	Cleanup(func())
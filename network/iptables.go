@@ -21,6 +21,8 @@ type IPTables interface {
 	Intercept(protocol string, port string, cgroupPath string, cgroupClassID string, injectorPodIP string) error
 	MarkCgroupPath(cgroupPath string, mark string) error
 	MarkClassID(classid string, mark string) error
+	RejectWithTCPReset(connState string, destination string, port string, mark string) error
+	DropTCPSYN(destination string, port string, mark string) error
 }
 
 type iptables struct {
@@ -164,6 +166,41 @@ func (i *iptables) MarkClassID(classID string, mark string) error {
 	return i.insert("mangle", "OUTPUT", "-m", "cgroup", "--cgroup", classID, "-j", "MARK", "--set-mark", mark)
 }
 
+// RejectWithTCPReset rejects outgoing tcp packets in the given conntrack state (ESTABLISHED, INVALID, etc.) with a tcp reset,
+// optionally matching the given destination, destination port and packet mark
+func (i *iptables) RejectWithTCPReset(connState string, destination string, port string, mark string) error {
+	rulespec := append(tcpFilterRulespec(destination, port, mark), "-m", "conntrack", "--ctstate", connState, "-j", "REJECT", "--reject-with", "tcp-reset")
+
+	return i.insert("filter", "OUTPUT", rulespec...)
+}
+
+// DropTCPSYN drops outgoing tcp packets opening a new connection,
+// optionally matching the given destination, destination port and packet mark
+func (i *iptables) DropTCPSYN(destination string, port string, mark string) error {
+	rulespec := append(tcpFilterRulespec(destination, port, mark), "--syn", "-j", "DROP")
+
+	return i.insert("filter", "OUTPUT", rulespec...)
+}
+
+// tcpFilterRulespec builds the common part of a rule matching tcp packets going to the given destination and port with the given mark
+func tcpFilterRulespec(destination string, port string, mark string) []string {
+	rulespec := []string{"-p", "tcp"}
+
+	if destination != "" {
+		rulespec = append(rulespec, "-d", destination)
+	}
+
+	if port != "" {
+		rulespec = append(rulespec, "--dport", port)
+	}
+
+	if mark != "" {
+		rulespec = append(rulespec, "-m", "mark", "--mark", mark)
+	}
+
+	return rulespec
+}
+
 // insert creates a new iptables rule definition, stores it
 // for further cleanup and inserts the rule in the given table and chain
 // at the first position
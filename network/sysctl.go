@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ConntrackTCPEstablishedTimeoutSysctl is the number of seconds an idle established tcp connection is kept by conntrack
+	ConntrackTCPEstablishedTimeoutSysctl = "net.netfilter.nf_conntrack_tcp_timeout_established"
+	// ConntrackTCPLooseSysctl defines if conntrack picks up already established connections (1) or considers them invalid (0)
+	ConntrackTCPLooseSysctl = "net.netfilter.nf_conntrack_tcp_loose"
+)
+
+// Sysctl is an interface for reading and writing kernel parameters
+// network related parameters are read and written in the network namespace of the calling thread
+type Sysctl interface {
+	Get(name string) (string, error)
+	Set(name string, value string) error
+}
+
+type sysctl struct {
+	log    *zap.SugaredLogger
+	dryRun bool
+	root   string
+}
+
+// NewSysctl returns an implementation of the Sysctl interface relying on the /proc/sys filesystem
+func NewSysctl(log *zap.SugaredLogger, dryRun bool) Sysctl {
+	return &sysctl{
+		log:    log,
+		dryRun: dryRun,
+		root:   "/proc/sys",
+	}
+}
+
+// Get returns the current value of the given kernel parameter
+func (s *sysctl) Get(name string) (string, error) {
+	value, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return "", fmt.Errorf("error reading kernel parameter %s: %w", name, err)
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+// Set writes the given value to the given kernel parameter
+func (s *sysctl) Set(name string, value string) error {
+	s.log.Infow("setting kernel parameter", "name", name, "value", value)
+
+	if s.dryRun {
+		return nil
+	}
+
+	if err := os.WriteFile(s.path(name), []byte(value), 0o644); err != nil {
+		return fmt.Errorf("error writing kernel parameter %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// path converts a dotted kernel parameter name to its path in the /proc/sys filesystem
+func (s *sysctl) path(name string) string {
+	return filepath.Join(s.root, strings.ReplaceAll(name, ".", "/"))
+}
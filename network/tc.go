@@ -63,6 +63,7 @@ type TrafficController interface {
 	DeleteFilter(iface string, priority uint32) error
 	AddFwFilter(ifaces []string, parent string, handle string, flowid string) error
 	AddBPFFilter(ifaces []string, parent string, obj string, flowid string) error
+	AddPrioritizedBPFFilter(ifaces []string, parent string, obj string, flowid string) (uint32, error)
 	ConfigBPFFilter(cmd executor, args ...string) error
 	AddOutputLimit(ifaces []string, parent string, handle string, bytesPerSec uint) error
 	ClearQdisc(ifaces []string) error
@@ -236,6 +237,23 @@ func (t *tc) AddBPFFilter(ifaces []string, parent string, obj string, flowid str
 	return nil
 }
 
+// AddPrioritizedBPFFilter generates an eBPF filter like AddBPFFilter but with an explicit priority,
+// allocated the same way as flower filters, so it is evaluated after the previously added filters
+func (t *tc) AddPrioritizedBPFFilter(ifaces []string, parent string, obj string, flowid string) (uint32, error) {
+	priority, err := t.getNewPriority()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, iface := range ifaces {
+		if _, _, err := t.executer.Run(buildCmd("filter", iface, parent, "ip", priority, "", "bpf", "obj "+obj+" flowid "+flowid)); err != nil {
+			return 0, err
+		}
+	}
+
+	return priority, nil
+}
+
 func (t *tc) DeleteFilter(iface string, priority uint32) error {
 	if _, _, err := t.executer.Run([]string{"filter", "delete", "dev", iface, "priority", fmt.Sprintf("%d", priority)}); err != nil {
 		return err
@@ -190,6 +190,36 @@ var _ = Describe("Tc", func() {
 		})
 	})
 
+	Describe("AddPrioritizedBPFFilter", func() {
+		var (
+			err      error
+			priority uint32
+		)
+
+		JustBeforeEach(func() {
+			priority, err = tcRunner.AddPrioritizedBPFFilter(ifaces, parent, "file.bpf.obj", flowid)
+		})
+
+		Context("add an eBPF filter with a priority", func() {
+			It("should load the eBPF program with the next priority", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(priority).To(Equal(uint32(1001)))
+				tcExecuter.AssertCalled(GinkgoT(), "Run", []string{"filter", "add", "dev", "lo", "protocol", "ip", "priority", "1001", "root", "bpf", "obj", "file.bpf.obj", "flowid", "1:2"})
+				tcExecuter.AssertCalled(GinkgoT(), "Run", []string{"filter", "add", "dev", "eth0", "protocol", "ip", "priority", "1001", "root", "bpf", "obj", "file.bpf.obj", "flowid", "1:2"})
+			})
+
+			When("the Run function of the executor return an error", func() {
+				BeforeEach(func() {
+					tcExecuterRunCall.Return(1, "", fmt.Errorf("error")).Once()
+				})
+
+				It("should propagate the error", func() {
+					Expect(err).Should(HaveOccurred())
+				})
+			})
+		})
+	})
+
 	Describe("AddFwFilter", func() {
 		JustBeforeEach(func() {
 			Expect(tcRunner.AddFwFilter(ifaces, parent, handle, flowid)).Should(Succeed())
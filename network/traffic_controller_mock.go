@@ -318,6 +318,61 @@ func (_c *TrafficControllerMock_AddPrio_Call) RunAndReturn(run func([]string, st
 	return _c
 }
 
+// AddPrioritizedBPFFilter provides a mock function with given fields: ifaces, parent, obj, flowid
+func (_m *TrafficControllerMock) AddPrioritizedBPFFilter(ifaces []string, parent string, obj string, flowid string) (uint32, error) {
+	ret := _m.Called(ifaces, parent, obj, flowid)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, string, string, string) (uint32, error)); ok {
+		return rf(ifaces, parent, obj, flowid)
+	}
+	if rf, ok := ret.Get(0).(func([]string, string, string, string) uint32); ok {
+		r0 = rf(ifaces, parent, obj, flowid)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, string, string, string) error); ok {
+		r1 = rf(ifaces, parent, obj, flowid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrafficControllerMock_AddPrioritizedBPFFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPrioritizedBPFFilter'
+type TrafficControllerMock_AddPrioritizedBPFFilter_Call struct {
+	*mock.Call
+}
+
+// AddPrioritizedBPFFilter is a helper method to define mock.On call
+//   - ifaces []string
+//   - parent string
+//   - obj string
+//   - flowid string
+func (_e *TrafficControllerMock_Expecter) AddPrioritizedBPFFilter(ifaces interface{}, parent interface{}, obj interface{}, flowid interface{}) *TrafficControllerMock_AddPrioritizedBPFFilter_Call {
+	return &TrafficControllerMock_AddPrioritizedBPFFilter_Call{Call: _e.mock.On("AddPrioritizedBPFFilter", ifaces, parent, obj, flowid)}
+}
+
+func (_c *TrafficControllerMock_AddPrioritizedBPFFilter_Call) Run(run func(ifaces []string, parent string, obj string, flowid string)) *TrafficControllerMock_AddPrioritizedBPFFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *TrafficControllerMock_AddPrioritizedBPFFilter_Call) Return(_a0 uint32, _a1 error) *TrafficControllerMock_AddPrioritizedBPFFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TrafficControllerMock_AddPrioritizedBPFFilter_Call) RunAndReturn(run func([]string, string, string, string) (uint32, error)) *TrafficControllerMock_AddPrioritizedBPFFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearQdisc provides a mock function with given fields: ifaces
 func (_m *TrafficControllerMock) ClearQdisc(ifaces []string) error {
 	ret := _m.Called(ifaces)
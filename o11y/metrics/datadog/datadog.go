@@ -184,6 +184,24 @@ func (d Sink) MetricOrphanFound(tags []string) error {
 	return d.metricWithStatus(metricPrefixController+"orphan.found", tags)
 }
 
+// MetricRunScheduled increments when a DisruptionCron or a DisruptionRollout run creates its disruption
+func (d Sink) MetricRunScheduled(tags []string) error {
+	return d.metricWithStatus(metricPrefixController+"runs.scheduled", tags)
+}
+
+// MetricRunSkipped increments when a DisruptionCron or a DisruptionRollout run is skipped, and tags the reason
+func (d Sink) MetricRunSkipped(reason string, tags []string) error {
+	t := []string{"reason:" + reason}
+	t = append(t, tags...)
+
+	return d.metricWithStatus(metricPrefixController+"runs.skipped", t)
+}
+
+// MetricRunFailed increments when a DisruptionCron or a DisruptionRollout run fails to create its disruption
+func (d Sink) MetricRunFailed(tags []string) error {
+	return d.metricWithStatus(metricPrefixController+"runs.failed", tags)
+}
+
 // MetricWatcherCalls is a counter of watcher calls.
 func (d Sink) MetricWatcherCalls(tags []string) error {
 	return d.metricWithStatus(metricPrefixController+"watcher.calls_total", tags)
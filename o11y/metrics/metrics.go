@@ -11,6 +11,7 @@ import (
 
 	"github.com/DataDog/chaos-controller/o11y/metrics/datadog"
 	"github.com/DataDog/chaos-controller/o11y/metrics/noop"
+	"github.com/DataDog/chaos-controller/o11y/metrics/prometheus"
 	"github.com/DataDog/chaos-controller/o11y/metrics/types"
 	chaostypes "github.com/DataDog/chaos-controller/types"
 	"go.uber.org/zap"
@@ -45,6 +46,9 @@ type Sink interface {
 	MetricValidationDeleted(tags []string) error
 	MetricInformed(tags []string) error
 	MetricOrphanFound(tags []string) error
+	MetricRunScheduled(tags []string) error
+	MetricRunSkipped(reason string, tags []string) error
+	MetricRunFailed(tags []string) error
 }
 
 // GetSink returns an initiated sink
@@ -52,6 +56,8 @@ func GetSink(log *zap.SugaredLogger, driver types.SinkDriver, app types.SinkApp)
 	switch driver {
 	case types.SinkDriverDatadog:
 		return datadog.New(app)
+	case types.SinkDriverPrometheus:
+		return prometheus.New(app)
 	case types.SinkDriverNoop:
 		return noop.New(log), nil
 	default:
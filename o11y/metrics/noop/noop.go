@@ -198,6 +198,27 @@ func (n Sink) MetricWatcherCalls(tags []string) error {
 	return nil
 }
 
+// MetricRunScheduled increments when a DisruptionCron or a DisruptionRollout run creates its disruption
+func (n Sink) MetricRunScheduled(tags []string) error {
+	n.log.Debugf("NOOP: MetricRunScheduled %s\n", tags)
+
+	return nil
+}
+
+// MetricRunSkipped increments when a DisruptionCron or a DisruptionRollout run is skipped
+func (n Sink) MetricRunSkipped(reason string, tags []string) error {
+	n.log.Debugf("NOOP: MetricRunSkipped %s %s\n", reason, tags)
+
+	return nil
+}
+
+// MetricRunFailed increments when a DisruptionCron or a DisruptionRollout run fails to create its disruption
+func (n Sink) MetricRunFailed(tags []string) error {
+	n.log.Debugf("NOOP: MetricRunFailed %s\n", tags)
+
+	return nil
+}
+
 // MetricSelectorCacheGauge reports how many caches are still in the cache array to prevent leaks
 func (n Sink) MetricSelectorCacheGauge(gauge float64) error {
 	n.log.Debugf("NOOP: MetricSelectorCacheGauge %f\n", gauge)
@@ -0,0 +1,430 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/o11y/metrics/types"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricPrefixInjector   = "chaos_injector_"
+	metricPrefixController = "chaos_controller_"
+
+	// InjectorScrapePort is the port of the scrape endpoint served by the injector
+	InjectorScrapePort = 9091
+	// InjectorScrapePath is the path of the scrape endpoint served by the injector
+	InjectorScrapePath = "/metrics"
+)
+
+// tagLabels maps the tags keys given to the sink to the prometheus labels names
+// tags which are not part of a metric labels are dropped to keep the labels cardinality under control
+var tagLabels = map[string]string{
+	"disruptionName":  "disruption",
+	"disruption":      "disruption",
+	"namespace":       "namespace",
+	"kind":            "kind",
+	"disruption_kind": "kind",
+	"status":          "status",
+	"targetKind":      "target_kind",
+	"operation":       "operation",
+	"controller":      "controller",
+	"reason":          "reason",
+	"name":            "name",
+}
+
+// Sink describes a Prometheus sink, exposing metrics on the controller-runtime metrics endpoint for the controller
+// and on a dedicated scrape endpoint for the injector
+type Sink struct {
+	server *http.Server
+
+	injected              *prometheus.CounterVec
+	reinjected            *prometheus.CounterVec
+	cleaned               *prometheus.CounterVec
+	cleanedForReinjection *prometheus.CounterVec
+
+	reconcile                   prometheus.Counter
+	reconcileDuration           *prometheus.HistogramVec
+	cleanupDuration             *prometheus.HistogramVec
+	injectDuration              *prometheus.HistogramVec
+	disruptionCompletedDuration *prometheus.HistogramVec
+	disruptionOngoingDuration   *prometheus.HistogramVec
+	podsCreated                 *prometheus.CounterVec
+	stuckOnRemoval              *prometheus.CounterVec
+	stuckOnRemovalGauge         prometheus.Gauge
+	disruptionsGauge            prometheus.Gauge
+	disruptionsCount            *prometheus.CounterVec
+	podsGauge                   prometheus.Gauge
+	selectorCacheGauge          prometheus.Gauge
+	restart                     prometheus.Counter
+	validation                  *prometheus.CounterVec
+	informed                    prometheus.Counter
+	orphanFound                 *prometheus.CounterVec
+	watcherCalls                *prometheus.CounterVec
+	runsScheduled               *prometheus.CounterVec
+	runsSkipped                 *prometheus.CounterVec
+	runsFailed                  *prometheus.CounterVec
+}
+
+// New instantiates a new prometheus sink for the given app
+// the controller metrics are registered on the controller-runtime registry, served by the manager metrics endpoint,
+// while the injector metrics are served on a dedicated endpoint
+func New(app types.SinkApp) (*Sink, error) {
+	if app != types.SinkAppInjector {
+		return NewWithRegisterer(ctrlmetrics.Registry)
+	}
+
+	registry := prometheus.NewRegistry()
+
+	sink, err := NewWithRegisterer(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(InjectorScrapePath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	sink.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", InjectorScrapePort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	listenErr := make(chan error, 1)
+
+	go func() {
+		if err := sink.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			listenErr <- err
+		}
+	}()
+
+	// give the server a moment to fail on an unavailable port
+	select {
+	case err := <-listenErr:
+		return nil, fmt.Errorf("error serving the prometheus scrape endpoint: %w", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	return sink, nil
+}
+
+// NewWithRegisterer instantiates a new prometheus sink registering its metrics on the given registerer
+func NewWithRegisterer(registerer prometheus.Registerer) (*Sink, error) {
+	counterVec := func(prefix, name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: prefix + name, Help: help}, labels)
+	}
+
+	histogramVec := func(name, help string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricPrefixController + name,
+			Help:    help,
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 16), // from 100ms to ~55m
+		}, []string{"namespace"})
+	}
+
+	gauge := func(name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Name: metricPrefixController + name, Help: help})
+	}
+
+	s := &Sink{
+		injected:              counterVec(metricPrefixInjector, "injected_total", "Number of injections", "status", "kind"),
+		reinjected:            counterVec(metricPrefixInjector, "reinjected_total", "Number of reinjections", "status", "kind"),
+		cleaned:               counterVec(metricPrefixInjector, "cleaned_total", "Number of cleanings", "status", "kind"),
+		cleanedForReinjection: counterVec(metricPrefixInjector, "cleaned_for_reinjection_total", "Number of cleanings before a reinjection", "status", "kind"),
+
+		reconcile:                   prometheus.NewCounter(prometheus.CounterOpts{Name: metricPrefixController + "reconcile_total", Help: "Number of reconcile loops"}),
+		reconcileDuration:           histogramVec("reconcile_duration_seconds", "Duration of the reconcile loops"),
+		cleanupDuration:             histogramVec("cleanup_duration_seconds", "Time between a disruption deletion and the end of its cleanup"),
+		injectDuration:              histogramVec("inject_duration_seconds", "Time between a disruption creation and its injection"),
+		disruptionCompletedDuration: histogramVec("disruption_completed_duration_seconds", "Lifetime of completed disruptions"),
+		disruptionOngoingDuration:   histogramVec("disruption_ongoing_duration_seconds", "Lifetime of ongoing disruptions so far"),
+		podsCreated:                 counterVec(metricPrefixController, "pods_created_total", "Number of chaos pods created", "status", "namespace"),
+		stuckOnRemoval:              counterVec(metricPrefixController, "disruptions_stuck_on_removal_total", "Number of times disruptions were found stuck on removal", "disruption", "namespace"),
+		stuckOnRemovalGauge:         gauge("disruptions_stuck_on_removal", "Number of disruptions stuck on removal"),
+		disruptionsGauge:            gauge("disruptions", "Number of ongoing disruptions"),
+		disruptionsCount:            counterVec(metricPrefixController, "disruptions_total", "Number of created disruptions", "kind", "namespace"),
+		podsGauge:                   gauge("pods", "Number of existing chaos pods"),
+		selectorCacheGauge:          gauge("selector_cache", "Number of selector caches"),
+		restart:                     prometheus.NewCounter(prometheus.CounterOpts{Name: metricPrefixController + "restart_total", Help: "Number of controller restarts"}),
+		validation:                  counterVec(metricPrefixController, "validation_total", "Number of disruptions validations", "operation", "namespace"),
+		informed:                    prometheus.NewCounter(prometheus.CounterOpts{Name: metricPrefixController + "informed_total", Help: "Number of events received by the chaos pods informer"}),
+		orphanFound:                 counterVec(metricPrefixController, "orphan_found_total", "Number of chaos pods found without disruption", "namespace"),
+		watcherCalls:                counterVec(metricPrefixController, "watcher_calls_total", "Number of disruptions watchers calls", "target_kind"),
+		runsScheduled:               counterVec(metricPrefixController, "runs_scheduled_total", "Number of DisruptionCron and DisruptionRollout runs which created their disruption", "controller", "name", "namespace"),
+		runsSkipped:                 counterVec(metricPrefixController, "runs_skipped_total", "Number of skipped DisruptionCron and DisruptionRollout runs", "controller", "name", "namespace", "reason"),
+		runsFailed:                  counterVec(metricPrefixController, "runs_failed_total", "Number of DisruptionCron and DisruptionRollout runs which failed to create their disruption", "controller", "name", "namespace"),
+	}
+
+	collectors := []prometheus.Collector{
+		s.injected, s.reinjected, s.cleaned, s.cleanedForReinjection,
+		s.reconcile, s.reconcileDuration, s.cleanupDuration, s.injectDuration, s.disruptionCompletedDuration, s.disruptionOngoingDuration,
+		s.podsCreated, s.stuckOnRemoval, s.stuckOnRemovalGauge, s.disruptionsGauge, s.disruptionsCount, s.podsGauge, s.selectorCacheGauge,
+		s.restart, s.validation, s.informed, s.orphanFound, s.watcherCalls,
+		s.runsScheduled, s.runsSkipped, s.runsFailed,
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("error registering prometheus metric: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// Close stops the injector scrape endpoint, if any
+func (s *Sink) Close() error {
+	if s.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(ctx)
+}
+
+// GetSinkName returns the name of the sink
+func (s *Sink) GetSinkName() string {
+	return string(types.SinkDriverPrometheus)
+}
+
+// MetricInjected increments the injected metric
+func (s *Sink) MetricInjected(succeed bool, kind string, tags []string) error {
+	return s.incWithStatus(s.injected, succeed, kind)
+}
+
+// MetricReinjected increments the reinjected metric
+func (s *Sink) MetricReinjected(succeed bool, kind string, tags []string) error {
+	return s.incWithStatus(s.reinjected, succeed, kind)
+}
+
+// MetricCleanedForReinjection increments the cleanedForReinjection metric
+func (s *Sink) MetricCleanedForReinjection(succeed bool, kind string, tags []string) error {
+	return s.incWithStatus(s.cleanedForReinjection, succeed, kind)
+}
+
+// MetricCleaned increments the cleaned metric
+func (s *Sink) MetricCleaned(succeed bool, kind string, tags []string) error {
+	return s.incWithStatus(s.cleaned, succeed, kind)
+}
+
+// MetricReconcile increment reconcile metric
+func (s *Sink) MetricReconcile() error {
+	s.reconcile.Inc()
+
+	return nil
+}
+
+// MetricReconcileDuration observes the reconcile loop duration
+func (s *Sink) MetricReconcileDuration(duration time.Duration, tags []string) error {
+	return observe(s.reconcileDuration, duration, tags)
+}
+
+// MetricCleanupDuration observes the cleanup duration
+func (s *Sink) MetricCleanupDuration(duration time.Duration, tags []string) error {
+	return observe(s.cleanupDuration, duration, tags)
+}
+
+// MetricInjectDuration observes the inject duration
+func (s *Sink) MetricInjectDuration(duration time.Duration, tags []string) error {
+	return observe(s.injectDuration, duration, tags)
+}
+
+// MetricDisruptionCompletedDuration observes the entire disruption duration
+func (s *Sink) MetricDisruptionCompletedDuration(duration time.Duration, tags []string) error {
+	return observe(s.disruptionCompletedDuration, duration, tags)
+}
+
+// MetricDisruptionOngoingDuration observes the disruption duration so far
+func (s *Sink) MetricDisruptionOngoingDuration(duration time.Duration, tags []string) error {
+	return observe(s.disruptionOngoingDuration, duration, tags)
+}
+
+// MetricPodsCreated increments the pods created metric
+func (s *Sink) MetricPodsCreated(target, instanceName, namespace string, succeed bool) error {
+	return inc(s.podsCreated, []string{"status:" + boolToStatus(succeed), "namespace:" + namespace}, "status", "namespace")
+}
+
+// MetricStuckOnRemoval increments the disruptions stuck on removal metric
+func (s *Sink) MetricStuckOnRemoval(tags []string) error {
+	return inc(s.stuckOnRemoval, tags, "disruption", "namespace")
+}
+
+// MetricStuckOnRemovalGauge sets the gauge of stuck disruptions
+func (s *Sink) MetricStuckOnRemovalGauge(gauge float64) error {
+	s.stuckOnRemovalGauge.Set(gauge)
+
+	return nil
+}
+
+// MetricDisruptionsGauge sets the gauge of ongoing disruptions
+func (s *Sink) MetricDisruptionsGauge(gauge float64) error {
+	s.disruptionsGauge.Set(gauge)
+
+	return nil
+}
+
+// MetricDisruptionsCount counts finished disruptions, and labels the disruption kind
+func (s *Sink) MetricDisruptionsCount(kind chaostypes.DisruptionKindName, tags []string) error {
+	return inc(s.disruptionsCount, append([]string{"kind:" + string(kind)}, tags...), "kind", "namespace")
+}
+
+// MetricPodsGauge sets the gauge of existing chaos pods
+func (s *Sink) MetricPodsGauge(gauge float64) error {
+	s.podsGauge.Set(gauge)
+
+	return nil
+}
+
+// MetricRestart increments the controller restart metric
+func (s *Sink) MetricRestart() error {
+	s.restart.Inc()
+
+	return nil
+}
+
+// MetricValidationFailed increments the failed validation metric
+func (s *Sink) MetricValidationFailed(tags []string) error {
+	return inc(s.validation, append([]string{"operation:failed"}, tags...), "operation", "namespace")
+}
+
+// MetricValidationCreated increments the created validation metric
+func (s *Sink) MetricValidationCreated(tags []string) error {
+	return inc(s.validation, append([]string{"operation:created"}, tags...), "operation", "namespace")
+}
+
+// MetricValidationUpdated increments the updated validation metric
+func (s *Sink) MetricValidationUpdated(tags []string) error {
+	return inc(s.validation, append([]string{"operation:updated"}, tags...), "operation", "namespace")
+}
+
+// MetricValidationDeleted increments the deleted validation metric
+func (s *Sink) MetricValidationDeleted(tags []string) error {
+	return inc(s.validation, append([]string{"operation:deleted"}, tags...), "operation", "namespace")
+}
+
+// MetricInformed increments when the pod informer receives an event to process before reconciliation
+func (s *Sink) MetricInformed(tags []string) error {
+	s.informed.Inc()
+
+	return nil
+}
+
+// MetricOrphanFound increments when a chaos pod without a corresponding disruption resource is found
+func (s *Sink) MetricOrphanFound(tags []string) error {
+	return inc(s.orphanFound, tags, "namespace")
+}
+
+// MetricWatcherCalls is a counter of watcher calls
+func (s *Sink) MetricWatcherCalls(tags []string) error {
+	return inc(s.watcherCalls, tags, "target_kind")
+}
+
+// MetricRunScheduled increments when a DisruptionCron or a DisruptionRollout run creates its disruption
+func (s *Sink) MetricRunScheduled(tags []string) error {
+	return inc(s.runsScheduled, tags, "controller", "name", "namespace")
+}
+
+// MetricRunSkipped increments when a DisruptionCron or a DisruptionRollout run is skipped, and labels the reason
+func (s *Sink) MetricRunSkipped(reason string, tags []string) error {
+	return inc(s.runsSkipped, append([]string{"reason:" + reason}, tags...), "controller", "name", "namespace", "reason")
+}
+
+// MetricRunFailed increments when a DisruptionCron or a DisruptionRollout run fails to create its disruption
+func (s *Sink) MetricRunFailed(tags []string) error {
+	return inc(s.runsFailed, tags, "controller", "name", "namespace")
+}
+
+// MetricSelectorCacheGauge reports how many caches are still in the cache array to prevent leaks
+func (s *Sink) MetricSelectorCacheGauge(gauge float64) error {
+	s.selectorCacheGauge.Set(gauge)
+
+	return nil
+}
+
+func (s *Sink) incWithStatus(counter *prometheus.CounterVec, succeed bool, kind string) error {
+	return inc(counter, []string{"status:" + boolToStatus(succeed), "kind:" + kind}, "status", "kind")
+}
+
+func inc(counter *prometheus.CounterVec, tags []string, labelNames ...string) error {
+	c, err := counter.GetMetricWith(tagsToLabels(tags, labelNames...))
+	if err != nil {
+		return err
+	}
+
+	c.Inc()
+
+	return nil
+}
+
+func observe(histogram *prometheus.HistogramVec, duration time.Duration, tags []string) error {
+	o, err := histogram.GetMetricWith(tagsToLabels(tags, "namespace"))
+	if err != nil {
+		return err
+	}
+
+	o.Observe(duration.Seconds())
+
+	return nil
+}
+
+// tagsToLabels converts "key:value" tags to the given prometheus labels, the first value of a tag wins
+// labels without any matching tag are set to an empty value since a metric requires the exact set of its labels
+func tagsToLabels(tags []string, labelNames ...string) prometheus.Labels {
+	labels := prometheus.Labels{}
+	wanted := make(map[string]struct{}, len(labelNames))
+
+	for _, name := range labelNames {
+		wanted[name] = struct{}{}
+	}
+
+	for _, tag := range tags {
+		key, value, found := strings.Cut(tag, ":")
+		if !found {
+			continue
+		}
+
+		label, ok := tagLabels[key]
+		if !ok {
+			continue
+		}
+
+		if _, ok := wanted[label]; !ok {
+			continue
+		}
+
+		if _, exists := labels[label]; !exists {
+			labels[label] = value
+		}
+	}
+
+	for _, name := range labelNames {
+		if _, exists := labels[name]; !exists {
+			labels[name] = ""
+		}
+	}
+
+	return labels
+}
+
+func boolToStatus(succeed bool) string {
+	if succeed {
+		return "succeed"
+	}
+
+	return "failed"
+}
@@ -0,0 +1,252 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package prometheus
+
+import (
+	"time"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// gatheredValue returns the value of the metric with the given name and the exact given labels, gathered from the registry
+// the value of a histogram is its samples count
+func gatheredValue(registry *prometheus.Registry, name string, labels prometheus.Labels) (float64, bool) {
+	families, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if !labelsMatch(metric.GetLabel(), labels) {
+				continue
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				return metric.GetCounter().GetValue(), true
+			case dto.MetricType_GAUGE:
+				return metric.GetGauge().GetValue(), true
+			case dto.MetricType_HISTOGRAM:
+				return float64(metric.GetHistogram().GetSampleCount()), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func labelsMatch(pairs []*dto.LabelPair, labels prometheus.Labels) bool {
+	if len(pairs) != len(labels) {
+		return false
+	}
+
+	for _, pair := range pairs {
+		if value, ok := labels[pair.GetName()]; !ok || value != pair.GetValue() {
+			return false
+		}
+	}
+
+	return true
+}
+
+var _ = Describe("Prometheus sink", func() {
+	DescribeTable("tagsToLabels",
+		func(tags []string, labelNames []string, expected prometheus.Labels) {
+			Expect(tagsToLabels(tags, labelNames...)).To(Equal(expected))
+		},
+		Entry("maps the tags keys to their labels",
+			[]string{"disruptionName:foo", "namespace:bar"},
+			[]string{"disruption", "namespace"},
+			prometheus.Labels{"disruption": "foo", "namespace": "bar"},
+		),
+		Entry("maps aliased tags keys to the same label",
+			[]string{"disruption_kind:network-disruption", "targetKind:pod"},
+			[]string{"kind", "target_kind"},
+			prometheus.Labels{"kind": "network-disruption", "target_kind": "pod"},
+		),
+		Entry("keeps the first value of a label",
+			[]string{"disruption:first", "disruptionName:second"},
+			[]string{"disruption"},
+			prometheus.Labels{"disruption": "first"},
+		),
+		Entry("drops the tags which are not part of the labels",
+			[]string{"namespace:bar", "kind:dns-disruption", "target:node-1"},
+			[]string{"namespace"},
+			prometheus.Labels{"namespace": "bar"},
+		),
+		Entry("drops the malformed tags",
+			[]string{"namespace", "status:succeed"},
+			[]string{"namespace", "status"},
+			prometheus.Labels{"namespace": "", "status": "succeed"},
+		),
+		Entry("keeps the colons of a tag value",
+			[]string{"namespace:foo:bar"},
+			[]string{"namespace"},
+			prometheus.Labels{"namespace": "foo:bar"},
+		),
+		Entry("sets the labels without any matching tag to an empty value",
+			nil,
+			[]string{"controller", "namespace", "reason"},
+			prometheus.Labels{"controller": "", "namespace": "", "reason": ""},
+		),
+	)
+
+	Describe("inc", func() {
+		var counter *prometheus.CounterVec
+
+		BeforeEach(func() {
+			counter = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total", Help: "test"}, []string{"namespace"})
+		})
+
+		It("should increment the counter of the mapped labels", func() {
+			Expect(inc(counter, []string{"namespace:foo", "disruptionName:bar"}, "namespace")).To(Succeed())
+			Expect(inc(counter, []string{"namespace:foo"}, "namespace")).To(Succeed())
+
+			c, err := counter.GetMetricWith(prometheus.Labels{"namespace": "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			m := &dto.Metric{}
+			Expect(c.Write(m)).To(Succeed())
+			Expect(m.GetCounter().GetValue()).To(Equal(2.0))
+		})
+
+		It("should return an error when the labels do not match the counter ones", func() {
+			Expect(inc(counter, []string{"namespace:foo"}, "namespace", "status")).ToNot(Succeed())
+		})
+	})
+
+	Describe("metric families", func() {
+		var (
+			registry *prometheus.Registry
+			sink     *Sink
+		)
+
+		expectValue := func(name string, labels prometheus.Labels, expected float64) {
+			GinkgoHelper()
+
+			value, found := gatheredValue(registry, name, labels)
+			Expect(found).To(BeTrue(), "metric %s with labels %v is not found", name, labels)
+			Expect(value).To(Equal(expected))
+		}
+
+		BeforeEach(func() {
+			var err error
+
+			registry = prometheus.NewRegistry()
+			sink, err = NewWithRegisterer(registry)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to register the metrics twice on the same registerer", func() {
+			_, err := NewWithRegisterer(registry)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not serve any scrape endpoint for the controller", func() {
+			Expect(sink.Close()).To(Succeed())
+		})
+
+		It("should label the injector counters with the status and the kind", func() {
+			Expect(sink.MetricInjected(true, "network-disruption", []string{"disruptionName:foo"})).To(Succeed())
+			Expect(sink.MetricReinjected(false, "network-disruption", nil)).To(Succeed())
+			Expect(sink.MetricCleaned(true, "dns-disruption", nil)).To(Succeed())
+			Expect(sink.MetricCleanedForReinjection(false, "cpu-pressure", nil)).To(Succeed())
+
+			expectValue("chaos_injector_injected_total", prometheus.Labels{"status": "succeed", "kind": "network-disruption"}, 1)
+			expectValue("chaos_injector_reinjected_total", prometheus.Labels{"status": "failed", "kind": "network-disruption"}, 1)
+			expectValue("chaos_injector_cleaned_total", prometheus.Labels{"status": "succeed", "kind": "dns-disruption"}, 1)
+			expectValue("chaos_injector_cleaned_for_reinjection_total", prometheus.Labels{"status": "failed", "kind": "cpu-pressure"}, 1)
+		})
+
+		It("should label the duration histograms with the namespace", func() {
+			tags := []string{"disruptionName:foo", "namespace:bar"}
+
+			Expect(sink.MetricReconcileDuration(time.Second, tags)).To(Succeed())
+			Expect(sink.MetricCleanupDuration(time.Second, tags)).To(Succeed())
+			Expect(sink.MetricInjectDuration(time.Second, tags)).To(Succeed())
+			Expect(sink.MetricDisruptionCompletedDuration(time.Second, tags)).To(Succeed())
+			Expect(sink.MetricDisruptionOngoingDuration(time.Second, tags)).To(Succeed())
+
+			for _, name := range []string{"reconcile", "cleanup", "inject", "disruption_completed", "disruption_ongoing"} {
+				expectValue("chaos_controller_"+name+"_duration_seconds", prometheus.Labels{"namespace": "bar"}, 1)
+			}
+		})
+
+		It("should set the gauges", func() {
+			Expect(sink.MetricStuckOnRemovalGauge(1)).To(Succeed())
+			Expect(sink.MetricDisruptionsGauge(2)).To(Succeed())
+			Expect(sink.MetricPodsGauge(3)).To(Succeed())
+			Expect(sink.MetricSelectorCacheGauge(4)).To(Succeed())
+
+			expectValue("chaos_controller_disruptions_stuck_on_removal", prometheus.Labels{}, 1)
+			expectValue("chaos_controller_disruptions", prometheus.Labels{}, 2)
+			expectValue("chaos_controller_pods", prometheus.Labels{}, 3)
+			expectValue("chaos_controller_selector_cache", prometheus.Labels{}, 4)
+		})
+
+		It("should increment the unlabeled counters", func() {
+			Expect(sink.MetricReconcile()).To(Succeed())
+			Expect(sink.MetricRestart()).To(Succeed())
+			Expect(sink.MetricInformed([]string{"namespace:bar"})).To(Succeed())
+
+			expectValue("chaos_controller_reconcile_total", prometheus.Labels{}, 1)
+			expectValue("chaos_controller_restart_total", prometheus.Labels{}, 1)
+			expectValue("chaos_controller_informed_total", prometheus.Labels{}, 1)
+		})
+
+		It("should label the chaos pods counters", func() {
+			Expect(sink.MetricPodsCreated("target", "foo", "bar", false)).To(Succeed())
+			Expect(sink.MetricOrphanFound([]string{"disruption:foo", "namespace:bar"})).To(Succeed())
+
+			expectValue("chaos_controller_pods_created_total", prometheus.Labels{"status": "failed", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_orphan_found_total", prometheus.Labels{"namespace": "bar"}, 1)
+		})
+
+		It("should label the disruptions counters", func() {
+			Expect(sink.MetricStuckOnRemoval([]string{"disruptionName:foo", "namespace:bar"})).To(Succeed())
+			Expect(sink.MetricDisruptionsCount(chaostypes.DisruptionKindNetworkDisruption, []string{"namespace:bar"})).To(Succeed())
+			Expect(sink.MetricWatcherCalls([]string{"targetKind:pod", "namespace:bar"})).To(Succeed())
+
+			expectValue("chaos_controller_disruptions_stuck_on_removal_total", prometheus.Labels{"disruption": "foo", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_disruptions_total", prometheus.Labels{"kind": string(chaostypes.DisruptionKindNetworkDisruption), "namespace": "bar"}, 1)
+			expectValue("chaos_controller_watcher_calls_total", prometheus.Labels{"target_kind": "pod"}, 1)
+		})
+
+		It("should label the validation counter with the operation", func() {
+			tags := []string{"namespace:bar"}
+
+			Expect(sink.MetricValidationCreated(tags)).To(Succeed())
+			Expect(sink.MetricValidationUpdated(tags)).To(Succeed())
+			Expect(sink.MetricValidationDeleted(tags)).To(Succeed())
+			Expect(sink.MetricValidationFailed(tags)).To(Succeed())
+			Expect(sink.MetricValidationFailed(tags)).To(Succeed())
+
+			expectValue("chaos_controller_validation_total", prometheus.Labels{"operation": "created", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_validation_total", prometheus.Labels{"operation": "updated", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_validation_total", prometheus.Labels{"operation": "deleted", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_validation_total", prometheus.Labels{"operation": "failed", "namespace": "bar"}, 2)
+		})
+
+		It("should label the runs counters with the controller and the name", func() {
+			tags := []string{"controller:disruption-rollout", "name:foo", "namespace:bar"}
+
+			Expect(sink.MetricRunScheduled(tags)).To(Succeed())
+			Expect(sink.MetricRunSkipped("target_missing", tags)).To(Succeed())
+			Expect(sink.MetricRunFailed(tags)).To(Succeed())
+
+			expectValue("chaos_controller_runs_scheduled_total", prometheus.Labels{"controller": "disruption-rollout", "name": "foo", "namespace": "bar"}, 1)
+			expectValue("chaos_controller_runs_skipped_total", prometheus.Labels{"controller": "disruption-rollout", "name": "foo", "namespace": "bar", "reason": "target_missing"}, 1)
+			expectValue("chaos_controller_runs_failed_total", prometheus.Labels{"controller": "disruption-rollout", "name": "foo", "namespace": "bar"}, 1)
+		})
+	})
+})
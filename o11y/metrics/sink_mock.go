@@ -838,6 +838,133 @@ func (_c *SinkMock_MetricRestart_Call) RunAndReturn(run func() error) *SinkMock_
 	return _c
 }
 
+// MetricRunFailed provides a mock function with given fields: tags
+func (_m *SinkMock) MetricRunFailed(tags []string) error {
+	ret := _m.Called(tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SinkMock_MetricRunFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MetricRunFailed'
+type SinkMock_MetricRunFailed_Call struct {
+	*mock.Call
+}
+
+// MetricRunFailed is a helper method to define mock.On call
+//   - tags []string
+func (_e *SinkMock_Expecter) MetricRunFailed(tags interface{}) *SinkMock_MetricRunFailed_Call {
+	return &SinkMock_MetricRunFailed_Call{Call: _e.mock.On("MetricRunFailed", tags)}
+}
+
+func (_c *SinkMock_MetricRunFailed_Call) Run(run func(tags []string)) *SinkMock_MetricRunFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *SinkMock_MetricRunFailed_Call) Return(_a0 error) *SinkMock_MetricRunFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SinkMock_MetricRunFailed_Call) RunAndReturn(run func([]string) error) *SinkMock_MetricRunFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MetricRunScheduled provides a mock function with given fields: tags
+func (_m *SinkMock) MetricRunScheduled(tags []string) error {
+	ret := _m.Called(tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SinkMock_MetricRunScheduled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MetricRunScheduled'
+type SinkMock_MetricRunScheduled_Call struct {
+	*mock.Call
+}
+
+// MetricRunScheduled is a helper method to define mock.On call
+//   - tags []string
+func (_e *SinkMock_Expecter) MetricRunScheduled(tags interface{}) *SinkMock_MetricRunScheduled_Call {
+	return &SinkMock_MetricRunScheduled_Call{Call: _e.mock.On("MetricRunScheduled", tags)}
+}
+
+func (_c *SinkMock_MetricRunScheduled_Call) Run(run func(tags []string)) *SinkMock_MetricRunScheduled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *SinkMock_MetricRunScheduled_Call) Return(_a0 error) *SinkMock_MetricRunScheduled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SinkMock_MetricRunScheduled_Call) RunAndReturn(run func([]string) error) *SinkMock_MetricRunScheduled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MetricRunSkipped provides a mock function with given fields: reason, tags
+func (_m *SinkMock) MetricRunSkipped(reason string, tags []string) error {
+	ret := _m.Called(reason, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(reason, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SinkMock_MetricRunSkipped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MetricRunSkipped'
+type SinkMock_MetricRunSkipped_Call struct {
+	*mock.Call
+}
+
+// MetricRunSkipped is a helper method to define mock.On call
+//   - reason string
+//   - tags []string
+func (_e *SinkMock_Expecter) MetricRunSkipped(reason interface{}, tags interface{}) *SinkMock_MetricRunSkipped_Call {
+	return &SinkMock_MetricRunSkipped_Call{Call: _e.mock.On("MetricRunSkipped", reason, tags)}
+}
+
+func (_c *SinkMock_MetricRunSkipped_Call) Run(run func(reason string, tags []string)) *SinkMock_MetricRunSkipped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *SinkMock_MetricRunSkipped_Call) Return(_a0 error) *SinkMock_MetricRunSkipped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SinkMock_MetricRunSkipped_Call) RunAndReturn(run func(string, []string) error) *SinkMock_MetricRunSkipped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MetricSelectorCacheGauge provides a mock function with given fields: gauge
 func (_m *SinkMock) MetricSelectorCacheGauge(gauge float64) error {
 	ret := _m.Called(gauge)
@@ -0,0 +1,89 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package pprof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/DataDog/chaos-controller/o11y/profiler/types"
+	"go.uber.org/zap"
+)
+
+// DefaultAddr is the address the pprof endpoints are served on when not configured
+const DefaultAddr = "localhost:6060"
+
+// Config represents the pprof profiler configuration
+type Config struct {
+	Addr string `json:"addr"`
+}
+
+// GetAddr returns the address the pprof endpoints are served on, defaulting to DefaultAddr
+func (c Config) GetAddr() string {
+	if c.Addr != "" {
+		return c.Addr
+	}
+
+	return DefaultAddr
+}
+
+// Sink describes a pprof profiler sink, serving the runtime profiles over HTTP
+type Sink struct {
+	log    *zap.SugaredLogger
+	server *http.Server
+}
+
+// New initiated pprof profiler sink, serving the profiles under /debug/pprof/ on the configured address
+func New(log *zap.SugaredLogger, config Config) (Sink, error) {
+	addr := config.GetAddr()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return Sink{}, fmt.Errorf("error listening on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorw("error serving pprof endpoints", "error", err)
+		}
+	}()
+
+	log.Infow("serving pprof endpoints", "addr", listener.Addr().String())
+
+	return Sink{log: log, server: server}, nil
+}
+
+// Stop stops serving the pprof endpoints
+func (s Sink) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.log.Errorw("error stopping the pprof server", "error", err)
+	}
+}
+
+// GetSinkName returns the name of the sink
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverPprof)
+}
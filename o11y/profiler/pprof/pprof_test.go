@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package pprof_test
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/chaos-controller/o11y/profiler/pprof"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+var _ = Describe("Pprof profiler sink", func() {
+	var (
+		log    *zap.SugaredLogger
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		log = zaptest.NewLogger(GinkgoT()).Sugar()
+		client = &http.Client{Timeout: 5 * time.Second}
+	})
+
+	// freeAddr returns a local address which is not listened on
+	freeAddr := func() string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		addr := listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		return addr
+	}
+
+	get := func(addr, path string) (int, error) {
+		resp, err := client.Get("http://" + addr + path)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}
+
+	It("should serve the profiles on the configured address until stopped", func() {
+		addr := freeAddr()
+
+		sink, err := pprof.New(log, pprof.Config{Addr: addr})
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/symbol"} {
+			Expect(get(addr, path)).To(Equal(http.StatusOK), "path %s", path)
+		}
+
+		sink.Stop()
+
+		_, err = get(addr, "/debug/pprof/")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should default the address when not configured", func() {
+		Expect(pprof.Config{}.GetAddr()).To(Equal(pprof.DefaultAddr))
+		Expect(pprof.Config{Addr: "127.0.0.1:7070"}.GetAddr()).To(Equal("127.0.0.1:7070"))
+	})
+
+	It("should return an error when the address is already in use", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		DeferCleanup(listener.Close)
+
+		_, err = pprof.New(log, pprof.Config{Addr: listener.Addr().String()})
+		Expect(err).To(MatchError(ContainSubstring("error listening on")))
+	})
+
+	It("should return an error when the address is invalid", func() {
+		_, err := pprof.New(log, pprof.Config{Addr: "not-an-address"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return its name", func() {
+		Expect(pprof.Sink{}.GetSinkName()).To(Equal("pprof"))
+	})
+})
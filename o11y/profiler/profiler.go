@@ -10,6 +10,7 @@ import (
 
 	"github.com/DataDog/chaos-controller/o11y/profiler/datadog"
 	"github.com/DataDog/chaos-controller/o11y/profiler/noop"
+	"github.com/DataDog/chaos-controller/o11y/profiler/pprof"
 	"github.com/DataDog/chaos-controller/o11y/profiler/types"
 	"go.uber.org/zap"
 )
@@ -21,10 +22,13 @@ type Sink interface {
 }
 
 // GetSink returns an initiated profiler sink
-func GetSink(log *zap.SugaredLogger, driver types.SinkDriver) (Sink, error) {
+// the pprof configuration is only used by the pprof driver
+func GetSink(log *zap.SugaredLogger, driver types.SinkDriver, pprofConfig pprof.Config) (Sink, error) {
 	switch driver {
 	case types.SinkDriverDatadog:
 		return datadog.New()
+	case types.SinkDriverPprof:
+		return pprof.New(log, pprofConfig)
 	case types.SinkDriverNoop:
 		return noop.New(log), nil
 	default:
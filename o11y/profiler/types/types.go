@@ -12,6 +12,9 @@ const (
 	// SinkDriverDatadog is the Datadog driver
 	SinkDriverDatadog SinkDriver = "datadog"
 
+	// SinkDriverPprof is the pprof driver, serving the runtime profiles over HTTP
+	SinkDriverPprof SinkDriver = "pprof"
+
 	// SinkDriverNoop is a noop driver mainly used for testing
 	SinkDriverNoop SinkDriver = "noop"
 )
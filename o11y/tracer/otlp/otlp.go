@@ -0,0 +1,132 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DataDog/chaos-controller/o11y/tracer/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ProtocolGRPC exports spans using OTLP over gRPC
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports spans using OTLP over HTTP (protobuf encoded)
+	ProtocolHTTP = "http"
+
+	serviceName = "chaos-controller"
+)
+
+// Config represents the OTLP exporter configuration
+// when not set, the endpoint and headers fall back to the standard OTEL_EXPORTER_OTLP_* environment variables
+type Config struct {
+	Protocol      string            `json:"protocol"`
+	Endpoint      string            `json:"endpoint"`
+	Insecure      bool              `json:"insecure"`
+	Headers       map[string]string `json:"headers"`
+	SamplingRatio float64           `json:"samplingRatio"`
+}
+
+// Sink describes an OTLP tracer sink
+type Sink struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New initiated OTLP tracer sink, exporting spans with the configured protocol
+func New(config Config) (Sink, error) {
+	if config.SamplingRatio < 0 || config.SamplingRatio > 1 {
+		return Sink{}, fmt.Errorf("the sampling ratio must be between 0 and 1, got %f", config.SamplingRatio)
+	}
+
+	exporter, err := newExporter(config)
+	if err != nil {
+		return Sink{}, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		// spans whose parent is sampled, like the ones propagated through the disruption span context, are always sampled
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRatio))),
+	)
+
+	return Sink{provider: provider}, nil
+}
+
+func newExporter(config Config) (*otlptrace.Exporter, error) {
+	switch config.Protocol {
+	case ProtocolGRPC, "":
+		opts := []otlptracegrpc.Option{}
+
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		if len(config.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+		}
+
+		return otlptracegrpc.New(context.Background(), opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{}
+
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.Endpoint))
+		}
+
+		if config.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		if len(config.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+		}
+
+		return otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %s, must be either %s or %s", config.Protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+}
+
+// GetLoggableTraceContext returns the W3C trace and span IDs for logs
+// It allows to connect logs with corresponding traces and spans.
+func (Sink) GetLoggableTraceContext(span trace.Span) []interface{} {
+	return []interface{}{
+		"trace_id", span.SpanContext().TraceID().String(),
+		"span_id", span.SpanContext().SpanID().String(),
+	}
+}
+
+// GetProvider returns this sink's provider for otel
+func (s Sink) GetProvider() trace.TracerProvider {
+	return s.provider
+}
+
+// Stop flushes the remaining spans and shutdowns the OTLP provider
+func (s Sink) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.provider.Shutdown(ctx)
+}
+
+// GetSinkName returns the name of the sink (otlp)
+func (s Sink) GetSinkName() string {
+	return string(types.SinkDriverOTLP)
+}
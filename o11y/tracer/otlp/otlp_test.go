@@ -0,0 +1,152 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package otlp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// exportRequest is a request received by the test collector
+type exportRequest struct {
+	path    string
+	headers http.Header
+}
+
+var _ = Describe("OTLP tracer sink", func() {
+	var (
+		collector *httptest.Server
+		requests  chan exportRequest
+	)
+
+	BeforeEach(func() {
+		requests = make(chan exportRequest, 10)
+		collector = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests <- exportRequest{path: r.URL.Path, headers: r.Header.Clone()}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		DeferCleanup(collector.Close)
+	})
+
+	// exportSpan starts and ends a span on the sink provider before stopping the sink, flushing the span
+	exportSpan := func(sink otlp.Sink) {
+		_, span := sink.GetProvider().Tracer("test").Start(context.Background(), "span")
+		span.End()
+
+		Expect(sink.Stop()).To(Succeed())
+	}
+
+	DescribeTable("sampling ratio validation",
+		func(ratio float64, valid bool) {
+			sink, err := otlp.New(otlp.Config{Protocol: otlp.ProtocolHTTP, SamplingRatio: ratio})
+
+			if !valid {
+				Expect(err).To(MatchError(ContainSubstring("the sampling ratio must be between 0 and 1")))
+
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Stop()).To(Succeed())
+		},
+		Entry("accepts a zero ratio", 0.0, true),
+		Entry("accepts a partial ratio", 0.5, true),
+		Entry("accepts a full ratio", 1.0, true),
+		Entry("rejects a negative ratio", -0.1, false),
+		Entry("rejects a ratio above 1", 1.1, false),
+	)
+
+	DescribeTable("protocol validation",
+		func(protocol string, valid bool) {
+			sink, err := otlp.New(otlp.Config{Protocol: protocol, SamplingRatio: 1})
+
+			if !valid {
+				Expect(err).To(MatchError(ContainSubstring("unsupported OTLP protocol")))
+
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Stop()).To(Succeed())
+		},
+		Entry("defaults to grpc", "", true),
+		Entry("accepts grpc", otlp.ProtocolGRPC, true),
+		Entry("accepts http", otlp.ProtocolHTTP, true),
+		Entry("rejects any other protocol", "thrift", false),
+	)
+
+	It("should export the spans with the configured endpoint and headers on stop", func() {
+		endpoint, err := url.Parse(collector.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		sink, err := otlp.New(otlp.Config{
+			Protocol:      otlp.ProtocolHTTP,
+			Endpoint:      endpoint.Host,
+			Insecure:      true,
+			Headers:       map[string]string{"x-api-key": "secret"},
+			SamplingRatio: 1,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		exportSpan(sink)
+
+		var request exportRequest
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.path).To(Equal("/v1/traces"))
+		Expect(request.headers.Get("x-api-key")).To(Equal("secret"))
+	})
+
+	It("should fall back to the standard environment variables when the endpoint is not configured", func() {
+		previous, wasSet := os.LookupEnv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		Expect(os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)).To(Succeed())
+
+		DeferCleanup(func() {
+			if wasSet {
+				Expect(os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", previous)).To(Succeed())
+			} else {
+				Expect(os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")).To(Succeed())
+			}
+		})
+
+		sink, err := otlp.New(otlp.Config{Protocol: otlp.ProtocolHTTP, SamplingRatio: 1})
+		Expect(err).ToNot(HaveOccurred())
+
+		exportSpan(sink)
+
+		var request exportRequest
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.path).To(Equal("/v1/traces"))
+	})
+
+	It("should not export the spans when the sampling ratio is zero", func() {
+		endpoint, err := url.Parse(collector.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		sink, err := otlp.New(otlp.Config{Protocol: otlp.ProtocolHTTP, Endpoint: endpoint.Host, Insecure: true})
+		Expect(err).ToNot(HaveOccurred())
+
+		exportSpan(sink)
+
+		Consistently(requests).ShouldNot(Receive())
+	})
+
+	It("should return its name", func() {
+		sink, err := otlp.New(otlp.Config{Protocol: otlp.ProtocolHTTP})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(sink.GetSinkName()).To(Equal("otlp"))
+		Expect(sink.Stop()).To(Succeed())
+	})
+})
@@ -10,6 +10,7 @@ import (
 
 	"github.com/DataDog/chaos-controller/o11y/tracer/datadog"
 	"github.com/DataDog/chaos-controller/o11y/tracer/noop"
+	"github.com/DataDog/chaos-controller/o11y/tracer/otlp"
 	"github.com/DataDog/chaos-controller/o11y/tracer/types"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -28,10 +29,13 @@ type Sink interface {
 }
 
 // GetSink returns a new initiated tracer sink from the given SinkDriver provider
-func GetSink(log *zap.SugaredLogger, driver types.SinkDriver) (Sink, error) {
+// the OTLP configuration is only used by the otlp driver
+func GetSink(log *zap.SugaredLogger, driver types.SinkDriver, otlpConfig otlp.Config) (Sink, error) {
 	switch driver {
 	case types.SinkDriverDatadog:
 		return datadog.New(), nil
+	case types.SinkDriverOTLP:
+		return otlp.New(otlpConfig)
 	case types.SinkDriverNoop:
 		return noop.New(log), nil
 	default:
@@ -12,6 +12,9 @@ const (
 	// SinkDriverDatadog is the Datadog driver
 	SinkDriverDatadog SinkDriver = "datadog"
 
+	// SinkDriverOTLP is the OpenTelemetry protocol driver, exporting spans to any OTLP compatible backend (e.g. Jaeger, Tempo)
+	SinkDriverOTLP SinkDriver = "otlp"
+
 	// SinkDriverNoop is a noop driver mainly used for testing
 	SinkDriverNoop SinkDriver = "noop"
 )
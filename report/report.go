@@ -0,0 +1,321 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConfigMapNamePrefix is the prefix of the name of the ConfigMaps holding the reports, followed by the disruption UID
+	ConfigMapNamePrefix = "disruption-report-"
+	// JSONKey is the ConfigMap key holding the JSON report
+	JSONKey = "report.json"
+	// MarkdownKey is the ConfigMap key holding the Markdown report
+	MarkdownKey = "report.md"
+
+	// maxEvents is the maximum number of events kept in each list of the report, the most recent ones are kept
+	// it keeps the report way below the 1MiB ConfigMap size limit
+	maxEvents = 200
+)
+
+// Config represents the disruption reports configuration
+type Config struct {
+	Enabled   bool   `json:"enabled"`
+	Namespace string `json:"namespace"`
+}
+
+// Report is the record of a finished disruption
+type Report struct {
+	Name                   string                               `json:"name"`
+	Namespace              string                               `json:"namespace"`
+	UID                    string                               `json:"uid"`
+	User                   string                               `json:"user,omitempty"`
+	Kinds                  []chaostypes.DisruptionKindName      `json:"kinds"`
+	CreatedAt              time.Time                            `json:"createdAt"`
+	DeletedAt              *time.Time                           `json:"deletedAt,omitempty"`
+	FinishedAt             time.Time                            `json:"finishedAt"`
+	InjectionStatus        chaostypes.DisruptionInjectionStatus `json:"injectionStatus"`
+	Health                 *v1beta1.TargetsHealth               `json:"health,omitempty"`
+	Spec                   v1beta1.DisruptionSpec               `json:"spec"`
+	Targets                []Target                             `json:"targets"`
+	Events                 []Event                              `json:"events"`
+	TargetStateTransitions []Event                              `json:"targetStateTransitions"`
+}
+
+// Target is the record of a disruption target
+type Target struct {
+	Name            string                                     `json:"name"`
+	InjectorPodName string                                     `json:"injectorPodName,omitempty"`
+	InjectionStatus chaostypes.DisruptionTargetInjectionStatus `json:"injectionStatus"`
+	Since           time.Time                                  `json:"since"`
+	Timeline        []Event                                    `json:"timeline"`
+}
+
+// Event is the record of a kubernetes event
+type Event struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+	Count   int32     `json:"count,omitempty"`
+	Target  string    `json:"target,omitempty"`
+}
+
+// New assembles the report of the given disruption from its events and the events of its targets, indexed by target name
+func New(dis v1beta1.Disruption, disruptionEvents []corev1.Event, targetsEvents map[string][]corev1.Event, finishedAt time.Time) Report {
+	report := Report{
+		Name:                   dis.Name,
+		Namespace:              dis.Namespace,
+		UID:                    string(dis.UID),
+		Kinds:                  dis.Spec.KindNames(),
+		CreatedAt:              dis.CreationTimestamp.Time,
+		FinishedAt:             finishedAt,
+		InjectionStatus:        dis.Status.InjectionStatus,
+		Health:                 dis.Status.Health,
+		Spec:                   dis.Spec,
+		Targets:                []Target{},
+		Events:                 []Event{},
+		TargetStateTransitions: []Event{},
+	}
+
+	if userInfo, err := dis.UserInfo(); err == nil {
+		report.User = userInfo.Username
+	}
+
+	if dis.DeletionTimestamp != nil {
+		deletedAt := dis.DeletionTimestamp.Time
+		report.DeletedAt = &deletedAt
+	}
+
+	// events sent on the disruption about a target are part of the target timeline
+	disruptionEventsByTarget := map[string][]Event{}
+
+	for _, event := range disruptionEvents {
+		reportEvent := newEvent(event)
+
+		report.Events = append(report.Events, reportEvent)
+
+		// the watchers send the target state transitions (warning states, recoveries) on the disruption
+		if v1beta1.IsTargetEvent(event) {
+			report.TargetStateTransitions = append(report.TargetStateTransitions, reportEvent)
+		}
+
+		if reportEvent.Target != "" {
+			disruptionEventsByTarget[reportEvent.Target] = append(disruptionEventsByTarget[reportEvent.Target], reportEvent)
+		}
+	}
+
+	for _, targetName := range dis.Status.TargetInjections.GetTargetNames() {
+		injection := dis.Status.TargetInjections[targetName]
+
+		target := Target{
+			Name:            targetName,
+			InjectorPodName: injection.InjectorPodName,
+			InjectionStatus: injection.InjectionStatus,
+			Since:           injection.Since.Time,
+			Timeline:        disruptionEventsByTarget[targetName],
+		}
+
+		for _, event := range targetsEvents[targetName] {
+			reportEvent := newEvent(event)
+			reportEvent.Target = targetName
+
+			target.Timeline = append(target.Timeline, reportEvent)
+		}
+
+		target.Timeline = sortAndTruncate(target.Timeline)
+		report.Targets = append(report.Targets, target)
+	}
+
+	sort.Slice(report.Targets, func(i, j int) bool {
+		return report.Targets[i].Name < report.Targets[j].Name
+	})
+
+	report.Events = sortAndTruncate(report.Events)
+	report.TargetStateTransitions = sortAndTruncate(report.TargetStateTransitions)
+
+	return report
+}
+
+// newEvent converts the given kubernetes event to a report event
+func newEvent(event corev1.Event) Event {
+	eventTime := event.LastTimestamp.Time
+
+	if eventTime.IsZero() {
+		eventTime = event.EventTime.Time
+	}
+
+	if eventTime.IsZero() {
+		eventTime = event.CreationTimestamp.Time
+	}
+
+	return Event{
+		Time:    eventTime,
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Message: event.Message,
+		Count:   event.Count,
+		Target:  event.Annotations["target_name"],
+	}
+}
+
+// sortAndTruncate orders the given events chronologically and keeps the most recent ones
+func sortAndTruncate(events []Event) []Event {
+	if events == nil {
+		return []Event{}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	if len(events) > maxEvents {
+		return events[len(events)-maxEvents:]
+	}
+
+	return events
+}
+
+// JSON returns the JSON representation of the report
+func (r Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown returns the Markdown representation of the report, meant to be pasted in postmortems
+func (r Report) Markdown() string {
+	md := strings.Builder{}
+
+	fmt.Fprintf(&md, "# Disruption report: %s/%s\n\n", r.Namespace, r.Name)
+
+	user := r.User
+	if user == "" {
+		user = "n/a"
+	}
+
+	kinds := []string{}
+	for _, kind := range r.Kinds {
+		kinds = append(kinds, string(kind))
+	}
+
+	md.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&md, "| User | %s |\n", user)
+	fmt.Fprintf(&md, "| Kinds | %s |\n", strings.Join(kinds, ", "))
+	fmt.Fprintf(&md, "| Level | %s |\n", r.Spec.Level)
+	fmt.Fprintf(&md, "| Dry run | %t |\n", r.Spec.DryRun)
+	fmt.Fprintf(&md, "| Duration | %s |\n", r.Spec.Duration.Duration())
+	fmt.Fprintf(&md, "| Created at | %s |\n", formatTime(r.CreatedAt))
+
+	if r.DeletedAt != nil {
+		fmt.Fprintf(&md, "| Deleted at | %s |\n", formatTime(*r.DeletedAt))
+	}
+
+	fmt.Fprintf(&md, "| Finished at | %s |\n", formatTime(r.FinishedAt))
+	fmt.Fprintf(&md, "| Injection status | %s |\n", r.InjectionStatus)
+	fmt.Fprintf(&md, "| Targets | %d |\n", len(r.Targets))
+
+	if r.Spec.Reporting != nil && r.Spec.Reporting.Purpose != "" {
+		fmt.Fprintf(&md, "\n## Purpose\n\n%s\n", r.Spec.Reporting.Purpose)
+	}
+
+	if r.Health != nil {
+		timeToRecover, unhealthyTargets := "n/a", "none"
+		if r.Health.TimeToRecover != "" {
+			timeToRecover = string(r.Health.TimeToRecover)
+		}
+
+		if len(r.Health.UnhealthyTargets) > 0 {
+			unhealthyTargets = strings.Join(r.Health.UnhealthyTargets, ", ")
+		}
+
+		md.WriteString("\n## Targets health\n\n| | |\n|---|---|\n")
+		fmt.Fprintf(&md, "| Container restarts | %d |\n", r.Health.ContainerRestarts)
+		fmt.Fprintf(&md, "| Readiness lost | %d |\n", r.Health.ReadinessLostCount)
+		fmt.Fprintf(&md, "| Node pressures | %d |\n", r.Health.NodePressureCount)
+		fmt.Fprintf(&md, "| Unhealthy targets | %s |\n", unhealthyTargets)
+		fmt.Fprintf(&md, "| Time to recover after cleanup | %s |\n", timeToRecover)
+	}
+
+	md.WriteString("\n## Targets\n")
+
+	if len(r.Targets) == 0 {
+		md.WriteString("\nNo target.\n")
+	}
+
+	for _, target := range r.Targets {
+		fmt.Fprintf(&md, "\n### %s\n\n", target.Name)
+		fmt.Fprintf(&md, "Injector pod `%s`, %s since %s.\n", target.InjectorPodName, target.InjectionStatus, formatTime(target.Since))
+		writeEvents(&md, target.Timeline)
+	}
+
+	md.WriteString("\n## Target state transitions\n")
+	writeEvents(&md, r.TargetStateTransitions)
+
+	md.WriteString("\n## Disruption events\n")
+	writeEvents(&md, r.Events)
+
+	return md.String()
+}
+
+func writeEvents(md *strings.Builder, events []Event) {
+	if len(events) == 0 {
+		md.WriteString("\nNo event.\n")
+
+		return
+	}
+
+	md.WriteString("\n| Time | Type | Reason | Message |\n|---|---|---|---|\n")
+
+	for _, event := range events {
+		message := strings.ReplaceAll(strings.ReplaceAll(event.Message, "\n", " "), "|", "\\|")
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, event.Count)
+		}
+
+		fmt.Fprintf(md, "| %s | %s | %s | %s |\n", formatTime(event.Time), event.Type, event.Reason, message)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ConfigMap returns the ConfigMap holding the JSON and Markdown representations of the report in the given namespace
+// it is not owned by the disruption so it outlives it, and is labeled with the disruption name and namespace to be found easily
+func (r Report) ConfigMap(namespace string) (*corev1.ConfigMap, error) {
+	jsonReport, err := r.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling the report: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapNamePrefix + r.UID,
+			Namespace: namespace,
+			Labels: map[string]string{
+				chaostypes.DisruptionNameLabel:      r.Name,
+				chaostypes.DisruptionNamespaceLabel: r.Namespace,
+			},
+		},
+		Data: map[string]string{
+			JSONKey:     string(jsonReport),
+			MarkdownKey: r.Markdown(),
+		},
+	}, nil
+}
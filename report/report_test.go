@@ -0,0 +1,143 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package report_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/report"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Report", func() {
+	var (
+		start            time.Time
+		disruption       v1beta1.Disruption
+		disruptionEvents []corev1.Event
+		targetsEvents    map[string][]corev1.Event
+	)
+
+	event := func(reason v1beta1.DisruptionEventReason, eventType string, at time.Time, targetName string) corev1.Event {
+		e := corev1.Event{
+			Type:          eventType,
+			Reason:        string(reason),
+			Message:       "message of " + string(reason),
+			LastTimestamp: metav1.NewTime(at),
+			Source:        corev1.EventSource{Component: v1beta1.SourceDisruptionComponent},
+		}
+
+		if targetName != "" {
+			e.Annotations = map[string]string{"target_name": targetName}
+		}
+
+		return e
+	}
+
+	BeforeEach(func() {
+		start = time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+		disruption = v1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "disruption",
+				Namespace:         "namespace",
+				UID:               "disruption-uid",
+				CreationTimestamp: metav1.NewTime(start),
+				Annotations:       map[string]string{},
+			},
+			Spec: v1beta1.DisruptionSpec{
+				Level:     chaostypes.DisruptionLevelPod,
+				Duration:  "10m",
+				Reporting: &v1beta1.Reporting{Purpose: "game day"},
+				NodeFailure: &v1beta1.NodeFailureSpec{
+					Shutdown: false,
+				},
+			},
+			Status: v1beta1.DisruptionStatus{
+				InjectionStatus: chaostypes.DisruptionInjectionStatusInjected,
+				TargetInjections: v1beta1.TargetInjections{
+					"pod-b": {InjectorPodName: "injector-b", InjectionStatus: chaostypes.DisruptionTargetInjectionStatusInjected, Since: metav1.NewTime(start.Add(time.Minute))},
+					"pod-a": {InjectorPodName: "injector-a", InjectionStatus: chaostypes.DisruptionTargetInjectionStatusInjected, Since: metav1.NewTime(start.Add(time.Minute))},
+				},
+				Health: &v1beta1.TargetsHealth{ContainerRestarts: 3, ReadinessLostCount: 1, TimeToRecover: "1m30s"},
+			},
+		}
+		Expect(disruption.SetUserInfo(authv1.UserInfo{Username: "author@example.com"})).To(Succeed())
+
+		// events are given most recent first, as returned by the watchers
+		disruptionEvents = []corev1.Event{
+			event(v1beta1.EventTargetPodRecoveredState, corev1.EventTypeNormal, start.Add(4*time.Minute), "pod-a"),
+			event(v1beta1.EventTargetPodWarningState, corev1.EventTypeWarning, start.Add(3*time.Minute), "pod-a"),
+			event(v1beta1.EventDisruptionCreated, corev1.EventTypeNormal, start, ""),
+		}
+
+		targetsEvents = map[string][]corev1.Event{
+			"pod-a": {{Type: corev1.EventTypeWarning, Reason: "BackOff", Message: "Back-off | restarting", Count: 3, LastTimestamp: metav1.NewTime(start.Add(2 * time.Minute))}},
+		}
+	})
+
+	Describe("New", func() {
+		It("assembles the disruption, targets and events", func() {
+			r := report.New(disruption, disruptionEvents, targetsEvents, start.Add(15*time.Minute))
+
+			Expect(r.User).To(Equal("author@example.com"))
+			Expect(r.Kinds).To(ConsistOf(chaostypes.DisruptionKindName(chaostypes.DisruptionKindNodeFailure)))
+			Expect(r.FinishedAt).To(Equal(start.Add(15 * time.Minute)))
+
+			By("ordering events chronologically")
+			Expect(r.Events).To(HaveLen(3))
+			Expect(r.Events[0].Reason).To(Equal(string(v1beta1.EventDisruptionCreated)))
+
+			By("keeping the target state transitions")
+			Expect(r.TargetStateTransitions).To(HaveLen(2))
+			Expect(r.TargetStateTransitions[0].Reason).To(Equal(string(v1beta1.EventTargetPodWarningState)))
+
+			By("building a timeline per target, sorted by name")
+			Expect(r.Targets).To(HaveLen(2))
+			Expect(r.Targets[0].Name).To(Equal("pod-a"))
+			Expect(r.Targets[0].InjectorPodName).To(Equal("injector-a"))
+			Expect(r.Targets[0].Timeline).To(HaveLen(3))
+			Expect(r.Targets[0].Timeline[0].Reason).To(Equal("BackOff"))
+			Expect(r.Targets[0].Timeline[0].Target).To(Equal("pod-a"))
+			Expect(r.Targets[1].Name).To(Equal("pod-b"))
+			Expect(r.Targets[1].Timeline).To(BeEmpty())
+		})
+	})
+
+	Describe("ConfigMap", func() {
+		It("holds the JSON and markdown reports", func() {
+			configMap, err := report.New(disruption, disruptionEvents, targetsEvents, start.Add(15*time.Minute)).ConfigMap("chaos-engineering")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(configMap.Name).To(Equal("disruption-report-disruption-uid"))
+			Expect(configMap.Namespace).To(Equal("chaos-engineering"))
+			Expect(configMap.Labels).To(HaveKeyWithValue(chaostypes.DisruptionNameLabel, "disruption"))
+			Expect(configMap.Labels).To(HaveKeyWithValue(chaostypes.DisruptionNamespaceLabel, "namespace"))
+
+			decoded := report.Report{}
+			Expect(json.Unmarshal([]byte(configMap.Data[report.JSONKey]), &decoded)).To(Succeed())
+			Expect(decoded.Name).To(Equal("disruption"))
+			Expect(decoded.Targets).To(HaveLen(2))
+			Expect(decoded.Health).To(Equal(disruption.Status.Health))
+
+			markdown := configMap.Data[report.MarkdownKey]
+			Expect(markdown).To(HavePrefix("# Disruption report: namespace/disruption\n"))
+			Expect(markdown).To(ContainSubstring("| User | author@example.com |"))
+			Expect(markdown).To(ContainSubstring("## Purpose\n\ngame day\n"))
+			Expect(markdown).To(ContainSubstring("| Container restarts | 3 |"))
+			Expect(markdown).To(ContainSubstring("| Unhealthy targets | none |"))
+			Expect(markdown).To(ContainSubstring("| Time to recover after cleanup | 1m30s |"))
+			Expect(markdown).To(ContainSubstring("### pod-a\n\nInjector pod `injector-a`, Injected since 2023-06-01T10:01:00Z."))
+			Expect(markdown).To(ContainSubstring("| 2023-06-01T10:02:00Z | Warning | BackOff | Back-off \\| restarting (x3) |"))
+		})
+	})
+})
@@ -0,0 +1,243 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package targetselector
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// strategyTarget is a target along with the groups the selection strategy limits it by
+type strategyTarget struct {
+	name    string
+	owner   string
+	domain  string
+	budgets []string
+}
+
+// strategyState holds how many targets are picked per owner and per topology domain, and how many disruptions are left per pod disruption budget
+type strategyState struct {
+	strategy   chaosv1beta1.SelectionStrategy
+	owners     map[string]int
+	domains    map[string]int
+	budgetLeft map[string]int32
+}
+
+// PickTargets picks up to count targets among the eligible ones following the selection strategy of the given disruption
+// the current targets of the disruption are accounted for in the owners, topology domains and pod disruption budgets limits
+// it returns less targets than the given count if not enough eligible targets satisfy the strategy
+func PickTargets(c client.Client, instance *chaosv1beta1.Disruption, eligibleTargets []string, count int) ([]string, error) {
+	if instance.Spec.SelectionStrategy == nil {
+		return nil, fmt.Errorf("the disruption has no selection strategy")
+	}
+
+	state := strategyState{
+		strategy:   *instance.Spec.SelectionStrategy,
+		owners:     map[string]int{},
+		domains:    map[string]int{},
+		budgetLeft: map[string]int32{},
+	}
+
+	currentTargets := instance.Status.TargetInjections.GetTargetNames()
+
+	targets, err := state.getStrategyTargets(c, instance, append(currentTargets, eligibleTargets...))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range currentTargets {
+		if t, ok := targets[target]; ok {
+			state.add(t)
+		}
+	}
+
+	pool := make([]strategyTarget, 0, len(eligibleTargets))
+
+	for _, target := range eligibleTargets {
+		if t, ok := targets[target]; ok {
+			pool = append(pool, t)
+		}
+	}
+
+	// shuffle targets so ties are broken randomly
+	rand.Shuffle(len(pool), func(i, j int) { //nolint:gosec
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	picked := []string{}
+
+	for len(picked) < count {
+		best := -1
+
+		for i, t := range pool {
+			if !state.allows(t) {
+				continue
+			}
+
+			if best == -1 || state.prefers(t, pool[best]) {
+				best = i
+			}
+		}
+
+		// no target left satisfies the strategy
+		if best == -1 {
+			break
+		}
+
+		state.add(pool[best])
+		picked = append(picked, pool[best].name)
+		pool = append(pool[:best], pool[best+1:]...)
+	}
+
+	return picked, nil
+}
+
+// allows returns true if picking the given target doesn't exceed the owner and pod disruption budgets limits
+func (s strategyState) allows(t strategyTarget) bool {
+	if s.strategy.MaxPerOwner > 0 && t.owner != "" && s.owners[t.owner] >= s.strategy.MaxPerOwner {
+		return false
+	}
+
+	for _, budget := range t.budgets {
+		if s.budgetLeft[budget] <= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// prefers returns true if the first target should be picked before the second one depending on the topology mode
+func (s strategyState) prefers(a, b strategyTarget) bool {
+	if s.strategy.TopologyKey == "" {
+		return false
+	}
+
+	if s.strategy.GetTopologyMode() == chaosv1beta1.TopologyModeConcentrate {
+		return s.domains[a.domain] > s.domains[b.domain]
+	}
+
+	return s.domains[a.domain] < s.domains[b.domain]
+}
+
+// add accounts for the given target being picked
+func (s strategyState) add(t strategyTarget) {
+	s.owners[t.owner]++
+	s.domains[t.domain]++
+
+	for _, budget := range t.budgets {
+		s.budgetLeft[budget]--
+	}
+}
+
+// getStrategyTargets returns the given targets along with their owner, topology domain and pod disruption budgets when required by the strategy
+func (s strategyState) getStrategyTargets(c client.Client, instance *chaosv1beta1.Disruption, names []string) (map[string]strategyTarget, error) {
+	targets := make(map[string]strategyTarget, len(names))
+	nodeDomains := map[string]string{}
+
+	getNodeDomain := func(nodeName string) (string, error) {
+		if s.strategy.TopologyKey == "" || nodeName == "" {
+			return "", nil
+		}
+
+		if domain, ok := nodeDomains[nodeName]; ok {
+			return domain, nil
+		}
+
+		node := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: nodeName}, node); err != nil {
+			return "", fmt.Errorf("error getting node %s: %w", nodeName, err)
+		}
+
+		nodeDomains[nodeName] = node.Labels[s.strategy.TopologyKey]
+
+		return nodeDomains[nodeName], nil
+	}
+
+	if instance.Spec.Level == chaostypes.DisruptionLevelNode {
+		for _, name := range names {
+			domain, err := getNodeDomain(name)
+			if err != nil {
+				return nil, err
+			}
+
+			targets[name] = strategyTarget{name: name, domain: domain}
+		}
+
+		return targets, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(context.Background(), pods, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+
+	podsByName := make(map[string]corev1.Pod, len(pods.Items))
+	for _, pod := range pods.Items {
+		podsByName[pod.Name] = pod
+	}
+
+	budgets := []policyv1.PodDisruptionBudget{}
+
+	if s.strategy.RespectPodDisruptionBudgets {
+		pdbs := &policyv1.PodDisruptionBudgetList{}
+		if err := c.List(context.Background(), pdbs, client.InNamespace(instance.Namespace)); err != nil {
+			return nil, fmt.Errorf("error listing pod disruption budgets: %w", err)
+		}
+
+		budgets = pdbs.Items
+		for _, pdb := range budgets {
+			s.budgetLeft[pdb.Name] = pdb.Status.DisruptionsAllowed
+		}
+	}
+
+	for _, name := range names {
+		pod, ok := podsByName[name]
+		if !ok {
+			continue
+		}
+
+		target := strategyTarget{name: name}
+
+		if owner := metav1.GetControllerOf(&pod); owner != nil {
+			target.owner = owner.Kind + "/" + owner.Name
+		}
+
+		domain, err := getNodeDomain(pod.Spec.NodeName)
+		if err != nil {
+			return nil, err
+		}
+
+		target.domain = domain
+
+		for _, pdb := range budgets {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing pod disruption budget %s selector: %w", pdb.Name, err)
+			}
+
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+
+			target.budgets = append(target.budgets, pdb.Name)
+		}
+
+		targets[name] = target
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,142 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package targetselector
+
+import (
+	"fmt"
+	"strings"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("PickTargets", func() {
+	var (
+		instance *chaosv1beta1.Disruption
+		objects  []client.Object
+		eligible []string
+	)
+
+	newStrategyPod := func(name, owner, node string) *corev1.Pod {
+		controller := true
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "chaos-demo",
+				Labels:          map[string]string{"app": owner},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &controller}},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+		}
+	}
+
+	BeforeEach(func() {
+		instance = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{Name: "strategy", Namespace: "chaos-demo"},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Level:             chaostypes.DisruptionLevelPod,
+				SelectionStrategy: &chaosv1beta1.SelectionStrategy{},
+			},
+			Status: chaosv1beta1.DisruptionStatus{TargetInjections: chaosv1beta1.TargetInjections{}},
+		}
+		objects = []client.Object{
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"zone": "b"}}},
+		}
+		eligible = []string{}
+
+		// 4 pods of the "big" replicaset in zone a, 2 pods of the "small" replicaset in zone b
+		for i := 0; i < 4; i++ {
+			objects = append(objects, newStrategyPod(fmt.Sprintf("big-%d", i), "big", "node-a"))
+			eligible = append(eligible, fmt.Sprintf("big-%d", i))
+		}
+
+		for i := 0; i < 2; i++ {
+			objects = append(objects, newStrategyPod(fmt.Sprintf("small-%d", i), "small", "node-b"))
+			eligible = append(eligible, fmt.Sprintf("small-%d", i))
+		}
+	})
+
+	pickTargets := func(count int) []string {
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+
+		picked, err := PickTargets(c, instance, eligible, count)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return picked
+	}
+
+	countPrefix := func(targets []string, prefix string) int {
+		count := 0
+
+		for _, target := range targets {
+			if strings.HasPrefix(target, prefix) {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	It("should pick at most maxPerOwner targets per owner", func() {
+		instance.Spec.SelectionStrategy.MaxPerOwner = 1
+
+		picked := pickTargets(4)
+
+		Expect(picked).To(HaveLen(2))
+		Expect(countPrefix(picked, "big-")).To(Equal(1))
+		Expect(countPrefix(picked, "small-")).To(Equal(1))
+	})
+
+	It("should account for the current targets of the disruption", func() {
+		instance.Spec.SelectionStrategy.MaxPerOwner = 1
+		instance.Status.TargetInjections["small-0"] = chaosv1beta1.TargetInjection{}
+		eligible = append(eligible[:4], "small-1")
+
+		Expect(pickTargets(2)).To(ConsistOf(HavePrefix("big-")))
+	})
+
+	It("should spread targets across topology domains", func() {
+		instance.Spec.SelectionStrategy.TopologyKey = "zone"
+
+		picked := pickTargets(4)
+
+		Expect(countPrefix(picked, "big-")).To(Equal(2))
+		Expect(countPrefix(picked, "small-")).To(Equal(2))
+	})
+
+	It("should concentrate targets in as few topology domains as possible", func() {
+		instance.Spec.SelectionStrategy.TopologyKey = "zone"
+		instance.Spec.SelectionStrategy.TopologyMode = chaosv1beta1.TopologyModeConcentrate
+		instance.Status.TargetInjections["big-0"] = chaosv1beta1.TargetInjection{}
+		eligible = eligible[1:]
+
+		Expect(pickTargets(3)).To(ConsistOf("big-1", "big-2", "big-3"))
+	})
+
+	It("should respect pod disruption budgets", func() {
+		instance.Spec.SelectionStrategy.RespectPodDisruptionBudgets = true
+		objects = append(objects, &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "chaos-demo"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "small"}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		})
+
+		picked := pickTargets(6)
+
+		Expect(picked).To(HaveLen(5))
+		Expect(countPrefix(picked, "small-")).To(Equal(1))
+	})
+})
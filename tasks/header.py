@@ -31,6 +31,7 @@ files_to_skip = [
     "chart/templates/generated/chaos.datadoghq.com_disruptions.yaml",
     "chart/templates/generated/chaos.datadoghq.com_disruptioncrons.yaml",
     "chart/templates/generated/chaos.datadoghq.com_disruptionrollouts.yaml",
+    "chart/templates/generated/chaos.datadoghq.com_disruptionworkflows.yaml",
     "chart/templates/generated/role.yaml",
     "cpuset/cpuset.go",
     "grpc/disruptionlistener/disruptionlistener_grpc.pb.go",
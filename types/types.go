@@ -60,6 +60,10 @@ const (
 
 	// MultiDistruptionAllowed is the expected annotation to put on a pod to enable multi disruption
 	MultiDistruptionAllowed = GroupName + "/multi-disruption-allowed"
+	// PausedAnnotation is the annotation set on chaos pods of a paused disruption, the injector cleans the disruption while it's present
+	PausedAnnotation = GroupName + "/paused"
+	// SlackThreadsAnnotation is the annotation set on a disruption holding the slack threads its notifications are posted to
+	SlackThreadsAnnotation = GroupName + "/slack-threads"
 
 	// DisruptionKindLabel is the label used to identify the disruption kind for a chaos pod
 	DisruptionKindLabel = GroupName + "/disruption-kind"
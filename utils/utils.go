@@ -40,18 +40,24 @@ func Contains(s []string, str string) bool {
 }
 
 type SetupWebhookWithManagerConfig struct {
-	Manager                       ctrl.Manager
-	Logger                        *zap.SugaredLogger
-	MetricsSink                   metrics.Sink
-	TracerSink                    tracer.Sink
-	Recorder                      record.EventRecorder
-	NamespaceThresholdFlag        int
-	ClusterThresholdFlag          int
-	EnableSafemodeFlag            bool
-	DeleteOnlyFlag                bool
-	HandlerEnabledFlag            bool
-	DefaultDurationFlag           time.Duration
-	ChaosNamespace                string
-	CloudServicesProvidersManager *cloudservice.CloudServicesProvidersManager
-	Environment                   string
+	Manager                            ctrl.Manager
+	Logger                             *zap.SugaredLogger
+	MetricsSink                        metrics.Sink
+	TracerSink                         tracer.Sink
+	Recorder                           record.EventRecorder
+	NamespaceThresholdFlag             int
+	ClusterThresholdFlag               int
+	EnableSafemodeFlag                 bool
+	DeleteOnlyFlag                     bool
+	HandlerEnabledFlag                 bool
+	DefaultDurationFlag                time.Duration
+	ChaosNamespace                     string
+	CloudServicesProvidersManager      *cloudservice.CloudServicesProvidersManager
+	Environment                        string
+	DisruptionBlackoutEnabled          bool
+	MaxDisruptionsPerNamespaceFlag     int
+	MaxDisruptionsPerClusterFlag       int
+	MaxDisruptionsPerKindFlag          int
+	MaxWorkloadDisruptedPercentageFlag int
+	GuardrailsAllowedHosts             []string
 }
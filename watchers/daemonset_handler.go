@@ -0,0 +1,160 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+package watchers
+
+import (
+	context "context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DaemonSetHandler struct {
+	Client client.Client
+	log    *zap.SugaredLogger
+}
+
+func NewDaemonSetHandler(client client.Client, logger *zap.SugaredLogger) DaemonSetHandler {
+	return DaemonSetHandler{
+		Client: client,
+		log:    logger,
+	}
+}
+
+// OnAdd is a handler function for the add of a daemonset
+func (h DaemonSetHandler) OnAdd(obj interface{}) {
+	daemonset, ok := obj.(*appsv1.DaemonSet)
+
+	// If the object is not a daemonset, do nothing
+	if !ok {
+		return
+	}
+
+	// If daemonset doesn't have associated disruption rollout, do nothing
+	hasDisruptionRollout, err := h.HasAssociatedDisruptionRollout(daemonset)
+	if err != nil {
+		return
+	}
+
+	if !hasDisruptionRollout {
+		return
+	}
+
+	initContainersHash, containersHash, err := HashPodSpec(&daemonset.Spec.Template.Spec)
+	if err != nil {
+		return
+	}
+
+	err = h.UpdateDisruptionRolloutStatus(daemonset, nil, &daemonset.Spec.Template, initContainersHash, containersHash)
+	if err != nil {
+		return
+	}
+}
+
+// OnUpdate is a handler function for the update of a daemonset
+func (h DaemonSetHandler) OnUpdate(oldObj, newObj interface{}) {
+	// Convert oldObj and newObj to Deployment objects
+	oldDaemonSet, okOldDaemonSet := oldObj.(*appsv1.DaemonSet)
+	newDaemonSet, okNewDaemonSet := newObj.(*appsv1.DaemonSet)
+
+	// If both old and new are not daemonsets, do nothing
+	if !okOldDaemonSet || !okNewDaemonSet {
+		return
+	}
+
+	// If daemonset doesn't have associated disruption rollout, do nothing
+	hasDisruptionRollout, err := h.HasAssociatedDisruptionRollout(newDaemonSet)
+	if !hasDisruptionRollout || err != nil {
+		return
+	}
+
+	// If the pod template hasn't changed, do nothing
+	if equality.Semantic.DeepEqual(oldDaemonSet.Spec.Template, newDaemonSet.Spec.Template) {
+		return
+	}
+
+	initContainersHash, containersHash, err := HashPodSpec(&newDaemonSet.Spec.Template.Spec)
+	if err != nil {
+		return
+	}
+
+	// The rollouts whose triggers don't match the changes are left untouched
+	err = h.UpdateDisruptionRolloutStatus(newDaemonSet, &oldDaemonSet.Spec.Template, &newDaemonSet.Spec.Template, initContainersHash, containersHash)
+	if err != nil {
+		return
+	}
+}
+
+// OnDelete is a handler function for the delete of a daemonset
+func (h DaemonSetHandler) OnDelete(_ interface{}) {
+	// Do nothing on delete event
+}
+
+func (h DaemonSetHandler) FetchAssociatedDisruptionRollouts(daemonset *appsv1.DaemonSet) (*chaosv1beta1.DisruptionRolloutList, error) {
+	indexedValue := "daemonset" + "-" + daemonset.Namespace + "-" + daemonset.Name
+
+	// It would be more efficient to use label selectors,
+	// however it would require a webhook to add those labels when new rollouts are created
+	disruptionRollouts := &chaosv1beta1.DisruptionRolloutList{}
+	err := h.Client.List(context.Background(), disruptionRollouts, client.MatchingFields{"targetResource": indexedValue})
+
+	if err != nil {
+		h.log.Errorw("unable to fetch DisruptionRollouts using index", "error", err, "indexedValue", indexedValue)
+		return nil, err
+	}
+
+	return disruptionRollouts, nil
+}
+
+func (h DaemonSetHandler) HasAssociatedDisruptionRollout(daemonset *appsv1.DaemonSet) (bool, error) {
+	disruptionRollouts, err := h.FetchAssociatedDisruptionRollouts(daemonset)
+	if err != nil {
+		h.log.Errorw("unable to check for associated DisruptionRollout", "DaemonSet", daemonset.Name, "error", err)
+		return false, err
+	}
+
+	return len(disruptionRollouts.Items) > 0, nil
+}
+
+// UpdateDisruptionRolloutStatus records the change of the daemonset in the status of the associated DisruptionRollouts whose triggers match the change.
+// oldTemplate is nil when the daemonset has just been added, in which case all the associated DisruptionRollouts are updated.
+func (h DaemonSetHandler) UpdateDisruptionRolloutStatus(daemonset *appsv1.DaemonSet, oldTemplate, newTemplate *corev1.PodTemplateSpec, initContainersHash, containersHash map[string]string) error {
+	disruptionRollouts, err := h.FetchAssociatedDisruptionRollouts(daemonset)
+	if err != nil {
+		return err
+	}
+
+	for _, dr := range disruptionRollouts.Items {
+		if oldTemplate != nil {
+			triggered, err := RolloutTriggered(dr.Spec.Triggers, oldTemplate, newTemplate, h.log)
+			if err != nil {
+				h.log.Errorw("unable to evaluate DisruptionRollout triggers", "DisruptionRollout", dr.Name, "error", err)
+				return err
+			}
+
+			if !triggered {
+				continue
+			}
+		}
+
+		dr.Status.LatestInitContainersHash = initContainersHash
+		dr.Status.LatestContainersHash = containersHash
+		dr.Status.LastContainerChangeTime = &metav1.Time{Time: time.Now()}
+
+		err = h.Client.Status().Update(context.Background(), &dr)
+		if err != nil {
+			h.log.Errorw("unable to update DisruptionRollout status", "DisruptionRollout", dr.Name, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -51,7 +53,7 @@ func (h DeploymentHandler) OnAdd(obj interface{}) {
 		return
 	}
 
-	err = h.UpdateDisruptionRolloutStatus(deployment, initContainersHash, containersHash)
+	err = h.UpdateDisruptionRolloutStatus(deployment, nil, &deployment.Spec.Template, initContainersHash, containersHash)
 	if err != nil {
 		return
 	}
@@ -74,13 +76,18 @@ func (h DeploymentHandler) OnUpdate(oldObj, newObj interface{}) {
 		return
 	}
 
-	// If containers have't changed, do nothing
-	containersChanged, initContainersHash, containersHash, err := ContainersChanged(&oldDeployment.Spec.Template.Spec, &newDeployment.Spec.Template.Spec, h.log)
-	if !containersChanged || err != nil {
+	// If the pod template hasn't changed, do nothing
+	if equality.Semantic.DeepEqual(oldDeployment.Spec.Template, newDeployment.Spec.Template) {
 		return
 	}
 
-	err = h.UpdateDisruptionRolloutStatus(newDeployment, initContainersHash, containersHash)
+	initContainersHash, containersHash, err := HashPodSpec(&newDeployment.Spec.Template.Spec)
+	if err != nil {
+		return
+	}
+
+	// The rollouts whose triggers don't match the changes are left untouched
+	err = h.UpdateDisruptionRolloutStatus(newDeployment, &oldDeployment.Spec.Template, &newDeployment.Spec.Template, initContainersHash, containersHash)
 	if err != nil {
 		return
 	}
@@ -117,13 +124,27 @@ func (h DeploymentHandler) HasAssociatedDisruptionRollout(deployment *appsv1.Dep
 	return len(disruptionRollouts.Items) > 0, nil
 }
 
-func (h DeploymentHandler) UpdateDisruptionRolloutStatus(deployment *appsv1.Deployment, initContainersHash, containersHash map[string]string) error {
+// UpdateDisruptionRolloutStatus records the change of the deployment in the status of the associated DisruptionRollouts whose triggers match the change.
+// oldTemplate is nil when the deployment has just been added, in which case all the associated DisruptionRollouts are updated.
+func (h DeploymentHandler) UpdateDisruptionRolloutStatus(deployment *appsv1.Deployment, oldTemplate, newTemplate *corev1.PodTemplateSpec, initContainersHash, containersHash map[string]string) error {
 	disruptionRollouts, err := h.FetchAssociatedDisruptionRollouts(deployment)
 	if err != nil {
 		return err
 	}
 
 	for _, dr := range disruptionRollouts.Items {
+		if oldTemplate != nil {
+			triggered, err := RolloutTriggered(dr.Spec.Triggers, oldTemplate, newTemplate, h.log)
+			if err != nil {
+				h.log.Errorw("unable to evaluate DisruptionRollout triggers", "DisruptionRollout", dr.Name, "error", err)
+				return err
+			}
+
+			if !triggered {
+				continue
+			}
+		}
+
 		dr.Status.LatestInitContainersHash = initContainersHash
 		dr.Status.LatestContainersHash = containersHash
 		dr.Status.LastContainerChangeTime = &metav1.Time{Time: time.Now()}
@@ -10,8 +10,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 func ContainersChanged(oldPodSpec, newPodSpec *corev1.PodSpec, log *zap.SugaredLogger) (bool, map[string]string, map[string]string, error) {
@@ -102,3 +104,96 @@ func HashesChanged(oldHashes, newHashes map[string]string, log *zap.SugaredLogge
 
 	return false
 }
+
+// RolloutTriggered returns true if the changes between the old and the new pod templates match the given DisruptionRollout triggers
+func RolloutTriggered(triggers *chaosv1beta1.DisruptionRolloutTriggers, oldTemplate, newTemplate *corev1.PodTemplateSpec, log *zap.SugaredLogger) (bool, error) {
+	if triggers != nil {
+		for _, annotation := range triggers.AnnotationChanges {
+			if oldTemplate.Annotations[annotation] != newTemplate.Annotations[annotation] {
+				log.Infof("pod template annotation %s has changed", annotation)
+				return true, nil
+			}
+		}
+	}
+
+	// any change of any container triggers the rollout by default
+	if triggers == nil || (triggers.GetContainerChanges() && len(triggers.Containers) == 0) {
+		changed, _, _, err := ContainersChanged(&oldTemplate.Spec, &newTemplate.Spec, log)
+
+		return changed, err
+	}
+
+	oldContainers := containersByName(&oldTemplate.Spec)
+	newContainers := containersByName(&newTemplate.Spec)
+
+	for name := range mergeContainerNames(oldContainers, newContainers) {
+		if !triggers.WatchesContainer(name) {
+			continue
+		}
+
+		oldContainer, okOld := oldContainers[name]
+		newContainer, okNew := newContainers[name]
+
+		if okOld != okNew {
+			log.Infof("container %s has been added or removed", name)
+			return true, nil
+		}
+
+		if triggers.GetContainerChanges() {
+			oldHash, err := Hash(&oldContainer)
+			if err != nil {
+				return false, err
+			}
+
+			newHash, err := Hash(&newContainer)
+			if err != nil {
+				return false, err
+			}
+
+			if oldHash != newHash {
+				log.Infof("container %s has changed", name)
+				return true, nil
+			}
+		}
+
+		if triggers.ImageChanges && oldContainer.Image != newContainer.Image {
+			log.Infof("image of container %s has changed", name)
+			return true, nil
+		}
+
+		if triggers.EnvChanges && (!equality.Semantic.DeepEqual(oldContainer.Env, newContainer.Env) || !equality.Semantic.DeepEqual(oldContainer.EnvFrom, newContainer.EnvFrom)) {
+			log.Infof("environment variables of container %s have changed", name)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// containersByName indexes the containers and init containers of the given pod spec by their name
+func containersByName(podSpec *corev1.PodSpec) map[string]corev1.Container {
+	containers := make(map[string]corev1.Container, len(podSpec.InitContainers)+len(podSpec.Containers))
+
+	for _, container := range podSpec.InitContainers {
+		containers[container.Name] = container
+	}
+
+	for _, container := range podSpec.Containers {
+		containers[container.Name] = container
+	}
+
+	return containers
+}
+
+// mergeContainerNames returns the names of the containers present in any of the given sets
+func mergeContainerNames(containerSets ...map[string]corev1.Container) map[string]struct{} {
+	names := map[string]struct{}{}
+
+	for _, containers := range containerSets {
+		for name := range containers {
+			names[name] = struct{}{}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+package watchers_test
+
+import (
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/watchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RolloutTriggered", func() {
+	newTemplate := func(annotations map[string]string, containers ...corev1.Container) *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				Containers: containers,
+			},
+		}
+	}
+
+	app := corev1.Container{Name: "app", Image: "app:1"}
+	appNewImage := corev1.Container{Name: "app", Image: "app:2"}
+	appNewEnv := corev1.Container{Name: "app", Image: "app:1", Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}}
+	appNewArgs := corev1.Container{Name: "app", Image: "app:1", Args: []string{"--verbose"}}
+	sidecar := corev1.Container{Name: "sidecar", Image: "sidecar:1"}
+	sidecarNewImage := corev1.Container{Name: "sidecar", Image: "sidecar:2"}
+
+	DescribeTable("",
+		func(triggers *v1beta1.DisruptionRolloutTriggers, oldTemplate, newTemplate *corev1.PodTemplateSpec, expectedTriggered bool) {
+			// Action
+			triggered, err := watchers.RolloutTriggered(triggers, oldTemplate, newTemplate, logger)
+
+			// Assert
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(triggered).To(Equal(expectedTriggered))
+		},
+		Entry("without triggers and a container change",
+			nil,
+			newTemplate(nil, app), newTemplate(nil, appNewArgs),
+			true,
+		),
+		Entry("without triggers and an annotation change",
+			nil,
+			newTemplate(map[string]string{"version": "1"}, app), newTemplate(map[string]string{"version": "2"}, app),
+			false,
+		),
+		Entry("with image changes trigger and an image change",
+			&v1beta1.DisruptionRolloutTriggers{ImageChanges: true},
+			newTemplate(nil, app), newTemplate(nil, appNewImage),
+			true,
+		),
+		Entry("with image changes trigger and an args change",
+			&v1beta1.DisruptionRolloutTriggers{ImageChanges: true},
+			newTemplate(nil, app), newTemplate(nil, appNewArgs),
+			false,
+		),
+		Entry("with env changes trigger and an env change",
+			&v1beta1.DisruptionRolloutTriggers{EnvChanges: true},
+			newTemplate(nil, app), newTemplate(nil, appNewEnv),
+			true,
+		),
+		Entry("with env changes trigger and an image change",
+			&v1beta1.DisruptionRolloutTriggers{EnvChanges: true},
+			newTemplate(nil, app), newTemplate(nil, appNewImage),
+			false,
+		),
+		Entry("with image changes trigger restricted to a container and a change of another container",
+			&v1beta1.DisruptionRolloutTriggers{ImageChanges: true, Containers: []string{"app"}},
+			newTemplate(nil, app, sidecar), newTemplate(nil, app, sidecarNewImage),
+			false,
+		),
+		Entry("with container changes trigger restricted to a container and a change of this container",
+			&v1beta1.DisruptionRolloutTriggers{Containers: []string{"app"}},
+			newTemplate(nil, app, sidecar), newTemplate(nil, appNewArgs, sidecar),
+			true,
+		),
+		Entry("with a watched container added",
+			&v1beta1.DisruptionRolloutTriggers{ImageChanges: true},
+			newTemplate(nil, app), newTemplate(nil, app, sidecar),
+			true,
+		),
+		Entry("with annotation changes trigger and a watched annotation change",
+			&v1beta1.DisruptionRolloutTriggers{AnnotationChanges: []string{"version"}},
+			newTemplate(map[string]string{"version": "1"}, app), newTemplate(map[string]string{"version": "2"}, app),
+			true,
+		),
+		Entry("with annotation changes trigger and another annotation change",
+			&v1beta1.DisruptionRolloutTriggers{AnnotationChanges: []string{"version"}},
+			newTemplate(map[string]string{"team": "a"}, app), newTemplate(map[string]string{"team": "b"}, app),
+			false,
+		),
+		Entry("with annotation changes trigger only and a container change",
+			&v1beta1.DisruptionRolloutTriggers{AnnotationChanges: []string{"version"}},
+			newTemplate(nil, app), newTemplate(nil, appNewImage),
+			false,
+		),
+		Entry("with annotation and container changes triggers and a container change",
+			&v1beta1.DisruptionRolloutTriggers{AnnotationChanges: []string{"version"}, ContainerChanges: true},
+			newTemplate(nil, app), newTemplate(nil, appNewImage),
+			true,
+		),
+	)
+})
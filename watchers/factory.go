@@ -36,6 +36,7 @@ type FactoryConfig struct {
 	Log            *zap.SugaredLogger
 	MetricSink     metrics.Sink
 	Reader         client.Reader
+	StatusWriter   client.SubResourceWriter
 	Recorder       record.EventRecorder
 	ChaosNamespace string
 }
@@ -81,11 +82,13 @@ func (f factory) NewDisruptionTargetWatcher(name string, enableObserver bool, di
 	handler := DisruptionTargetHandler{
 		recorder:       f.config.Recorder,
 		reader:         f.config.Reader,
+		statusWriter:   f.config.StatusWriter,
 		enableObserver: enableObserver,
 		disruption:     disruption,
 		metricsAdapter: NewWatcherMetricsAdapter(f.config.MetricSink, f.config.Log),
 		log:            f.config.Log,
 	}
+	handler.healthUpdater = &healthUpdater{update: handler.patchHealth}
 
 	// targetObjectType can either be a pod or a node
 	var targetObjectType client.Object = &corev1.Pod{}
@@ -11,6 +11,8 @@ import (
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -51,7 +53,7 @@ func (h StatefulSetHandler) OnAdd(obj interface{}) {
 		return
 	}
 
-	err = h.UpdateDisruptionRolloutStatus(statefulset, initContainersHash, containersHash)
+	err = h.UpdateDisruptionRolloutStatus(statefulset, nil, &statefulset.Spec.Template, initContainersHash, containersHash)
 	if err != nil {
 		return
 	}
@@ -74,13 +76,18 @@ func (h StatefulSetHandler) OnUpdate(oldObj, newObj interface{}) {
 		return
 	}
 
-	// If containers have't changed, do nothing
-	containersChanged, initContainersHash, containersHash, err := ContainersChanged(&oldStatefulSet.Spec.Template.Spec, &newStatefulSet.Spec.Template.Spec, h.log)
-	if !containersChanged || err != nil {
+	// If the pod template hasn't changed, do nothing
+	if equality.Semantic.DeepEqual(oldStatefulSet.Spec.Template, newStatefulSet.Spec.Template) {
 		return
 	}
 
-	err = h.UpdateDisruptionRolloutStatus(newStatefulSet, initContainersHash, containersHash)
+	initContainersHash, containersHash, err := HashPodSpec(&newStatefulSet.Spec.Template.Spec)
+	if err != nil {
+		return
+	}
+
+	// The rollouts whose triggers don't match the changes are left untouched
+	err = h.UpdateDisruptionRolloutStatus(newStatefulSet, &oldStatefulSet.Spec.Template, &newStatefulSet.Spec.Template, initContainersHash, containersHash)
 	if err != nil {
 		return
 	}
@@ -117,13 +124,27 @@ func (h StatefulSetHandler) HasAssociatedDisruptionRollout(statefulset *appsv1.S
 	return len(disruptionRollouts.Items) > 0, nil
 }
 
-func (h StatefulSetHandler) UpdateDisruptionRolloutStatus(statefulset *appsv1.StatefulSet, initContainersHash, containersHash map[string]string) error {
+// UpdateDisruptionRolloutStatus records the change of the statefulset in the status of the associated DisruptionRollouts whose triggers match the change.
+// oldTemplate is nil when the statefulset has just been added, in which case all the associated DisruptionRollouts are updated.
+func (h StatefulSetHandler) UpdateDisruptionRolloutStatus(statefulset *appsv1.StatefulSet, oldTemplate, newTemplate *corev1.PodTemplateSpec, initContainersHash, containersHash map[string]string) error {
 	disruptionRollouts, err := h.FetchAssociatedDisruptionRollouts(statefulset)
 	if err != nil {
 		return err
 	}
 
 	for _, dr := range disruptionRollouts.Items {
+		if oldTemplate != nil {
+			triggered, err := RolloutTriggered(dr.Spec.Triggers, oldTemplate, newTemplate, h.log)
+			if err != nil {
+				h.log.Errorw("unable to evaluate DisruptionRollout triggers", "DisruptionRollout", dr.Name, "error", err)
+				return err
+			}
+
+			if !triggered {
+				continue
+			}
+		}
+
 		dr.Status.LatestInitContainersHash = initContainersHash
 		dr.Status.LatestContainersHash = containersHash
 		dr.Status.LastContainerChangeTime = &metav1.Time{Time: time.Now()}
@@ -0,0 +1,231 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package watchers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxHealthUpdateAttempts is the number of times the health update is retried when the disruption has been modified concurrently
+const maxHealthUpdateAttempts = 5
+
+// nodePressureConditions are the node conditions counted as node pressures when they are raised
+var nodePressureConditions = map[corev1.NodeConditionType]bool{
+	corev1.NodeDiskPressure:       true,
+	corev1.NodeMemoryPressure:     true,
+	corev1.NodePIDPressure:        true,
+	corev1.NodeNetworkUnavailable: true,
+}
+
+// newPodHealthSignal computes the health signal of the given updated pod from the comparison of its statuses and the events to send about it
+func newPodHealthSignal(oldPod, newPod corev1.Pod, eventsToSend map[v1beta1.DisruptionEventReason]bool) v1beta1.TargetHealthSignal {
+	signal := newHealthSignal(newPod.Name, eventsToSend, v1beta1.EventTargetPodRecoveredState)
+
+	for _, container := range newPod.Status.ContainerStatuses {
+		for _, oldContainer := range oldPod.Status.ContainerStatuses {
+			if container.Name == oldContainer.Name && container.RestartCount > oldContainer.RestartCount {
+				signal.ContainerRestarts += int(container.RestartCount - oldContainer.RestartCount)
+			}
+		}
+	}
+
+	wasReady, isReady := isPodReady(oldPod), isPodReady(newPod)
+
+	switch {
+	case wasReady && !isReady:
+		signal.ReadinessLost = true
+		signal.Unhealthy = true
+	case !wasReady && isReady && !signal.Unhealthy:
+		signal.Recovered = true
+	}
+
+	return signal
+}
+
+// newNodeHealthSignal computes the health signal of the given updated node from the comparison of its conditions and the events to send about it
+func newNodeHealthSignal(oldNode, newNode corev1.Node, eventsToSend map[v1beta1.DisruptionEventReason]bool) v1beta1.TargetHealthSignal {
+	signal := newHealthSignal(newNode.Name, eventsToSend, v1beta1.EventTargetNodeRecoveredState)
+
+	for _, newCondition := range newNode.Status.Conditions {
+		if !nodePressureConditions[newCondition.Type] || newCondition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if oldStatus := getNodeConditionStatus(oldNode, newCondition.Type); oldStatus != corev1.ConditionTrue {
+			signal.NodePressures++
+		}
+	}
+
+	wasReady := getNodeConditionStatus(oldNode, corev1.NodeReady) == corev1.ConditionTrue
+	isReady := getNodeConditionStatus(newNode, corev1.NodeReady) == corev1.ConditionTrue
+
+	switch {
+	case wasReady && !isReady:
+		signal.ReadinessLost = true
+		signal.Unhealthy = true
+	case !wasReady && isReady && !signal.Unhealthy:
+		signal.Recovered = true
+	}
+
+	return signal
+}
+
+// newHealthSignal initializes the health signal of the given target from the events to send about it,
+// the target being unhealthy if any warning event is sent and recovered if the given recovered event is sent
+func newHealthSignal(targetName string, eventsToSend map[v1beta1.DisruptionEventReason]bool, recoveredReason v1beta1.DisruptionEventReason) v1beta1.TargetHealthSignal {
+	signal := v1beta1.TargetHealthSignal{
+		Target: targetName,
+	}
+
+	for eventReason, toSend := range eventsToSend {
+		if toSend && v1beta1.Events[eventReason].Type == corev1.EventTypeWarning {
+			signal.Unhealthy = true
+		}
+	}
+
+	signal.Recovered = eventsToSend[recoveredReason] && !signal.Unhealthy
+
+	return signal
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func getNodeConditionStatus(node corev1.Node, conditionType corev1.NodeConditionType) corev1.ConditionStatus {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+
+	return corev1.ConditionUnknown
+}
+
+// healthUpdater serializes the health updates of a disruption out of the informer event handlers,
+// the signals received while an update is in progress are aggregated in the next one
+type healthUpdater struct {
+	mu      sync.Mutex
+	pending []v1beta1.TargetHealthSignal
+	running bool
+	update  func(signals []v1beta1.TargetHealthSignal)
+}
+
+// enqueue adds the given signal to the pending ones and starts a worker to record them if none is running
+func (u *healthUpdater) enqueue(signal v1beta1.TargetHealthSignal) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.pending = append(u.pending, signal)
+
+	if u.running {
+		return
+	}
+
+	u.running = true
+
+	go u.run()
+}
+
+// run records the pending signals until there is none left
+func (u *healthUpdater) run() {
+	for {
+		u.mu.Lock()
+
+		signals := u.pending
+		u.pending = nil
+
+		if len(signals) == 0 {
+			u.running = false
+			u.mu.Unlock()
+
+			return
+		}
+
+		u.mu.Unlock()
+
+		u.update(signals)
+	}
+}
+
+// updateHealth queues the given health signal to be aggregated in the disruption status,
+// the API calls are made asynchronously so the informer event handlers are never blocked by them
+func (d DisruptionTargetHandler) updateHealth(signal v1beta1.TargetHealthSignal) {
+	if d.statusWriter == nil || d.healthUpdater == nil || signal.IsEmpty() {
+		return
+	}
+
+	d.healthUpdater.enqueue(signal)
+}
+
+// patchHealth aggregates the given health signals in the disruption status
+// the disruption is read from the API server and patched with an optimistic lock to not lose any signal on concurrent updates
+func (d DisruptionTargetHandler) patchHealth(signals []v1beta1.TargetHealthSignal) {
+	for attempt := 0; attempt < maxHealthUpdateAttempts; attempt++ {
+		disruption := v1beta1.Disruption{}
+
+		if err := d.reader.Get(context.Background(), types.NamespacedName{Namespace: d.disruption.Namespace, Name: d.disruption.Name}, &disruption); err != nil {
+			if !apierrors.IsNotFound(err) {
+				d.log.Warnw("couldn't get the disruption to update the targets health", "error", err, "signals", len(signals))
+			}
+
+			return
+		}
+
+		// the disruption has been re-created since the watcher started
+		if disruption.UID != d.disruption.UID {
+			return
+		}
+
+		patch := client.MergeFromWithOptions(disruption.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		if disruption.Status.Health == nil {
+			disruption.Status.Health = &v1beta1.TargetsHealth{}
+		}
+
+		updated := false
+		now := time.Now()
+
+		for _, signal := range signals {
+			if disruption.Status.Health.Record(signal, disruption.DeletionTimestamp, now) {
+				updated = true
+			}
+		}
+
+		if !updated {
+			return
+		}
+
+		err := d.statusWriter.Patch(context.Background(), &disruption, patch)
+		if err == nil {
+			d.log.Debugw("targets health updated", "signals", len(signals), "health", disruption.Status.Health)
+
+			return
+		}
+
+		if !apierrors.IsConflict(err) {
+			d.log.Warnw("couldn't update the targets health", "error", err, "signals", len(signals))
+
+			return
+		}
+	}
+
+	d.log.Warnw("couldn't update the targets health, the disruption kept being modified concurrently", "signals", len(signals))
+}
@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package watchers_test
+
+import (
+	"context"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/o11y/metrics/noop"
+	"github.com/DataDog/chaos-controller/watchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Targets health", func() {
+	var (
+		disruption     *chaosv1beta1.Disruption
+		k8sClient      client.Client
+		enableObserver bool
+		handler        watchers.DisruptionTargetHandler
+	)
+
+	newPod := func(ready bool, restarts int32) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "namespace"},
+			Status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+			},
+		}
+	}
+
+	getHealth := func() *chaosv1beta1.TargetsHealth {
+		stored := chaosv1beta1.Disruption{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "namespace", Name: "disruption"}, &stored)).To(Succeed())
+
+		return stored.Status.Health
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		disruption = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{Name: "disruption", Namespace: "namespace", UID: "uid"},
+			Spec:       chaosv1beta1.DisruptionSpec{Selector: labels.Set{"app": "demo"}},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(disruption.DeepCopy()).Build()
+		enableObserver = true
+	})
+
+	JustBeforeEach(func() {
+		noopSink := noop.New(logger)
+		watcherFactory := watchers.NewWatcherFactory(watchers.FactoryConfig{
+			Log:          logger,
+			MetricSink:   &noopSink,
+			Reader:       k8sClient,
+			StatusWriter: k8sClient.Status(),
+			Recorder:     record.NewFakeRecorder(100),
+		})
+
+		watcher, err := watcherFactory.NewDisruptionTargetWatcher(watcherName, enableObserver, disruption, &CacheMock{})
+		Expect(err).ToNot(HaveOccurred())
+
+		handlerPtr, ok := watcher.GetConfig().Handler.(*watchers.DisruptionTargetHandler)
+		Expect(ok).To(BeTrue())
+
+		handler = *handlerPtr
+	})
+
+	It("should aggregate the health signals of the updated targets in the disruption status", func() {
+		handler.OnUpdate(newPod(true, 0), newPod(false, 1))
+
+		Eventually(getHealth).Should(Equal(&chaosv1beta1.TargetsHealth{
+			ContainerRestarts:  1,
+			ReadinessLostCount: 1,
+			UnhealthyTargets:   []string{"target"},
+		}))
+	})
+
+	It("should not lose any signal of successive updates", func() {
+		for restarts := int32(0); restarts < 5; restarts++ {
+			handler.OnUpdate(newPod(true, restarts), newPod(true, restarts+1))
+		}
+
+		Eventually(func() int {
+			if health := getHealth(); health != nil {
+				return health.ContainerRestarts
+			}
+
+			return 0
+		}).Should(Equal(5))
+	})
+
+	Context("when the disruption has been re-created since the watcher started", func() {
+		BeforeEach(func() {
+			disruption.UID = "previous-uid"
+		})
+
+		It("should not update the new disruption", func() {
+			handler.OnUpdate(newPod(true, 0), newPod(false, 1))
+
+			Consistently(getHealth).Should(BeNil())
+		})
+	})
+
+	Context("when the observer is disabled", func() {
+		BeforeEach(func() {
+			enableObserver = false
+		})
+
+		It("should not update the disruption", func() {
+			handler.OnUpdate(newPod(true, 0), newPod(false, 1))
+
+			Consistently(getHealth).Should(BeNil())
+		})
+	})
+})
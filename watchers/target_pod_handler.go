@@ -26,6 +26,8 @@ import (
 type DisruptionTargetHandler struct {
 	recorder       record.EventRecorder
 	reader         client.Reader
+	statusWriter   client.SubResourceWriter
+	healthUpdater  *healthUpdater
 	enableObserver bool
 	disruption     *v1beta1.Disruption
 	log            *zap.SugaredLogger
@@ -117,6 +119,9 @@ func (d DisruptionTargetHandler) OnChangeHandleNotifierSink(oldPod, newPod *core
 
 		// we detect and compute the error / warning events, status changes, conditions of the updated pod
 		eventsToSend = d.buildPodEventsToSend(*oldPod, *newPod, disruptionEvents)
+
+		// aggregate the restarts, readiness changes and warning states of the pod in the disruption health
+		d.updateHealth(newPodHealthSignal(*oldPod, *newPod, eventsToSend))
 	case okNewNode && okOldNode:
 		objectToNotify, name = newNode, newNode.Name
 
@@ -127,6 +132,9 @@ func (d DisruptionTargetHandler) OnChangeHandleNotifierSink(oldPod, newPod *core
 
 		// we detect and compute the error / warning events, status changes, conditions of the updated node
 		eventsToSend = d.buildNodeEventsToSend(*oldNode, *newNode, disruptionEvents)
+
+		// aggregate the pressures, readiness changes and warning states of the node in the disruption health
+		d.updateHealth(newNodeHealthSignal(*oldNode, *newNode, eventsToSend))
 	default:
 		d.log.Warnw("target observer couldn't detect what type of changes happened on the targets")
 	}
@@ -158,13 +166,18 @@ func (d DisruptionTargetHandler) OnChangeHandleNotifierSink(oldPod, newPod *core
 }
 
 func (d DisruptionTargetHandler) getEventsFromCurrentDisruption(kind string, objectMeta metav1.ObjectMeta, disruptionStartTime time.Time) ([]corev1.Event, error) {
+	return GetEventsFromCurrentDisruption(d.reader, kind, objectMeta, disruptionStartTime)
+}
+
+// GetEventsFromCurrentDisruption returns the events of the given object sent since the disruption started, ordered by last timestamp (most recent first)
+func GetEventsFromCurrentDisruption(reader client.Reader, kind string, objectMeta metav1.ObjectMeta, disruptionStartTime time.Time) ([]corev1.Event, error) {
 	eventList := &corev1.EventList{}
 	fieldSelector := fields.Set{
 		"involvedObject.kind": kind,
 		"involvedObject.name": objectMeta.Name,
 	}
 
-	err := d.reader.List(context.Background(), eventList, &client.ListOptions{
+	err := reader.List(context.Background(), eventList, &client.ListOptions{
 		FieldSelector: fieldSelector.AsSelector(),
 		Namespace:     objectMeta.GetNamespace(),
 	})
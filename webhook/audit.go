@@ -0,0 +1,120 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/audit"
+	audittypes "github.com/DataDog/chaos-controller/audit/types"
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-disruption-audit,mutating=false,failurePolicy=ignore,sideEffects=NoneOnDryRun,groups=chaos.datadoghq.com,resources=disruptions,verbs=create;update;delete,versions=v1beta1,name=vdisruption-audit.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// AuditValidator writes an audit entry for each disruption creation, deletion, count change, pause or resume requested by a user
+// it never rejects a request, the validation being done by the disruption validating webhook, so its entries are marked as requested
+type AuditValidator struct {
+	Sink    audit.Sink
+	Log     *zap.SugaredLogger
+	decoder *admission.Decoder
+}
+
+func (v *AuditValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+
+	return nil
+}
+
+func (v *AuditValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// ensure decoder is set
+	if v.decoder == nil {
+		v.Log.Errorw("webhook decoder seems to be nil while it should not, the request is not audited")
+
+		return admission.Allowed("")
+	}
+
+	// dry-run requests have no side effect, so they are not audited
+	if req.DryRun != nil && *req.DryRun {
+		return admission.Allowed("")
+	}
+
+	dis, oldDis := v1beta1.Disruption{}, v1beta1.Disruption{}
+
+	var action audittypes.Action
+
+	switch req.Operation {
+	case admissionv1.Create:
+		if err := v.decoder.DecodeRaw(req.Object, &dis); err != nil {
+			v.Log.Errorw("error decoding disruption object", "error", err, "disruptionName", req.Name, "disruptionNamespace", req.Namespace)
+
+			return admission.Allowed("")
+		}
+
+		action = audittypes.ActionCreate
+	case admissionv1.Update:
+		if err := v.decoder.DecodeRaw(req.Object, &dis); err != nil {
+			v.Log.Errorw("error decoding disruption object", "error", err, "disruptionName", req.Name, "disruptionNamespace", req.Namespace)
+
+			return admission.Allowed("")
+		}
+
+		if err := v.decoder.DecodeRaw(req.OldObject, &oldDis); err != nil {
+			v.Log.Errorw("error decoding old disruption object", "error", err, "disruptionName", req.Name, "disruptionNamespace", req.Namespace)
+
+			return admission.Allowed("")
+		}
+
+		action = updateAction(oldDis, dis)
+	case admissionv1.Delete:
+		// the object being deleted is the old object
+		if err := v.decoder.DecodeRaw(req.OldObject, &dis); err != nil {
+			v.Log.Errorw("error decoding disruption object", "error", err, "disruptionName", req.Name, "disruptionNamespace", req.Namespace)
+
+			return admission.Allowed("")
+		}
+
+		action = audittypes.ActionDelete
+	}
+
+	// other updates (finalizers, annotations, status...) are not audited
+	if action == "" {
+		return admission.Allowed("")
+	}
+
+	// admission webhooks are called in parallel, so the request may still be rejected by the disruption validating webhook
+	entry := audit.NewEntry(audittypes.SourceWebhook, action, req.UserInfo, dis, time.Now())
+	entry.Requested = true
+
+	if action == audittypes.ActionCountChange && oldDis.Spec.Count != nil {
+		entry.PreviousCount = oldDis.Spec.Count.String()
+	}
+
+	if err := v.Sink.Write(entry); err != nil {
+		v.Log.Errorw("error writing audit entry", "error", err, "action", action, "disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "sink", v.Sink.GetSinkName())
+	}
+
+	return admission.Allowed("")
+}
+
+// updateAction returns the audited action of the given disruption update, if any
+// the count and the paused fields are the only spec fields which can be updated
+func updateAction(oldDis, dis v1beta1.Disruption) audittypes.Action {
+	switch {
+	case oldDis.Spec.Count.String() != dis.Spec.Count.String():
+		return audittypes.ActionCountChange
+	case !oldDis.Spec.Paused && dis.Spec.Paused:
+		return audittypes.ActionPause
+	case oldDis.Spec.Paused && !dis.Spec.Paused:
+		return audittypes.ActionResume
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,185 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/audit"
+	audittypes "github.com/DataDog/chaos-controller/audit/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zaptest"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("AuditValidator", func() {
+	newDisruption := func(count string, paused bool) v1beta1.Disruption {
+		c := intstr.Parse(count)
+
+		return v1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{Name: "network-drop", Namespace: "chaos-engineering", UID: "uid"},
+			Spec: v1beta1.DisruptionSpec{
+				Count:    &c,
+				Duration: "1h",
+				Paused:   paused,
+				Network:  &v1beta1.NetworkDisruptionSpec{Drop: 100},
+			},
+		}
+	}
+
+	raw := func(dis *v1beta1.Disruption) runtime.RawExtension {
+		if dis == nil {
+			return runtime.RawExtension{}
+		}
+
+		data, err := json.Marshal(dis)
+		Expect(err).ToNot(HaveOccurred())
+
+		return runtime.RawExtension{Raw: data}
+	}
+
+	DescribeTable("updateAction",
+		func(oldDis, dis v1beta1.Disruption, expected audittypes.Action) {
+			Expect(updateAction(oldDis, dis)).To(Equal(expected))
+		},
+		Entry("audits a count change", newDisruption("1", false), newDisruption("50%", false), audittypes.ActionCountChange),
+		Entry("audits a pause", newDisruption("1", false), newDisruption("1", true), audittypes.ActionPause),
+		Entry("audits a resume", newDisruption("1", true), newDisruption("1", false), audittypes.ActionResume),
+		Entry("favors the count change over the pause", newDisruption("1", false), newDisruption("2", true), audittypes.ActionCountChange),
+		Entry("ignores an update keeping the count and the paused state", newDisruption("1", true), newDisruption("1", true), audittypes.Action("")),
+	)
+
+	Describe("Handle", func() {
+		var (
+			sink      *audit.SinkMock
+			validator *AuditValidator
+			written   []audittypes.Entry
+			userInfo  authv1.UserInfo
+		)
+
+		BeforeEach(func() {
+			written = nil
+			userInfo = authv1.UserInfo{Username: "jane.doe@example.com", Groups: []string{"system:authenticated"}}
+
+			sink = audit.NewSinkMock(GinkgoT())
+			sink.EXPECT().Write(mock.Anything).RunAndReturn(func(entry audittypes.Entry) error {
+				written = append(written, entry)
+
+				return nil
+			}).Maybe()
+			sink.EXPECT().GetSinkName().Return("mock").Maybe()
+
+			scheme := runtime.NewScheme()
+			Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+
+			decoder, err := admission.NewDecoder(scheme)
+			Expect(err).ToNot(HaveOccurred())
+
+			validator = &AuditValidator{Sink: sink, Log: zaptest.NewLogger(GinkgoT()).Sugar()}
+			Expect(validator.InjectDecoder(decoder)).To(Succeed())
+		})
+
+		DescribeTable("audited requests",
+			func(operation admissionv1.Operation, oldDis, dis *v1beta1.Disruption, dryRun bool, expectedAction audittypes.Action, expectedPreviousCount string) {
+				req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "network-drop",
+					Namespace: "chaos-engineering",
+					Operation: operation,
+					UserInfo:  userInfo,
+					Object:    raw(dis),
+					OldObject: raw(oldDis),
+					DryRun:    pointer.Bool(dryRun),
+				}}
+
+				resp := validator.Handle(context.Background(), req)
+				Expect(resp.Allowed).To(BeTrue())
+
+				if expectedAction == "" {
+					Expect(written).To(BeEmpty())
+
+					return
+				}
+
+				Expect(written).To(HaveLen(1))
+				Expect(written[0].Source).To(Equal(audittypes.SourceWebhook))
+				Expect(written[0].Action).To(Equal(expectedAction))
+				Expect(written[0].Requested).To(BeTrue())
+				Expect(written[0].User).To(Equal("jane.doe@example.com"))
+				Expect(written[0].Groups).To(Equal([]string{"system:authenticated"}))
+				Expect(written[0].DisruptionName).To(Equal("network-drop"))
+				Expect(written[0].DisruptionNamespace).To(Equal("chaos-engineering"))
+				Expect(written[0].PreviousCount).To(Equal(expectedPreviousCount))
+			},
+			Entry("audits a creation",
+				admissionv1.Create, nil, ptrTo(newDisruption("1", false)), false, audittypes.ActionCreate, ""),
+			Entry("audits a deletion from the old object",
+				admissionv1.Delete, ptrTo(newDisruption("1", false)), nil, false, audittypes.ActionDelete, ""),
+			Entry("audits a count change along with the previous count",
+				admissionv1.Update, ptrTo(newDisruption("1", false)), ptrTo(newDisruption("50%", false)), false, audittypes.ActionCountChange, "1"),
+			Entry("audits a pause",
+				admissionv1.Update, ptrTo(newDisruption("1", false)), ptrTo(newDisruption("1", true)), false, audittypes.ActionPause, ""),
+			Entry("audits a resume",
+				admissionv1.Update, ptrTo(newDisruption("1", true)), ptrTo(newDisruption("1", false)), false, audittypes.ActionResume, ""),
+			Entry("ignores the other updates",
+				admissionv1.Update, ptrTo(newDisruption("1", false)), ptrTo(newDisruption("1", false)), false, audittypes.Action(""), ""),
+			Entry("ignores a dry-run creation",
+				admissionv1.Create, nil, ptrTo(newDisruption("1", false)), true, audittypes.Action(""), ""),
+			Entry("ignores a dry-run deletion",
+				admissionv1.Delete, ptrTo(newDisruption("1", false)), nil, true, audittypes.Action(""), ""),
+		)
+
+		It("should allow the request when the object can't be decoded", func() {
+			resp := validator.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: []byte("{")},
+			}})
+
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(written).To(BeEmpty())
+		})
+
+		It("should allow the request when the entry can't be written", func() {
+			sink = audit.NewSinkMock(GinkgoT())
+			sink.EXPECT().Write(mock.Anything).Return(errors.New("sink unavailable")).Once()
+			sink.EXPECT().GetSinkName().Return("mock").Maybe()
+			validator.Sink = sink
+
+			dis := newDisruption("1", false)
+
+			resp := validator.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  userInfo,
+				Object:    raw(&dis),
+			}})
+
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should allow the request when the decoder is not injected", func() {
+			validator.decoder = nil
+
+			resp := validator.Handle(context.Background(), admission.Request{})
+
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(written).To(BeEmpty())
+		})
+	})
+})
+
+func ptrTo(dis v1beta1.Disruption) *v1beta1.Disruption {
+	return &dis
+}